@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/errors"
+	"github.com/hazadus/gh-repomon/internal/history"
+	"github.com/hazadus/gh-repomon/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyRepo     string
+	historyUser     string
+	historyFrom     string
+	historyTo       string
+	historyInterval string
+	historyFormat   string
+	historyStoreDir string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show activity trends from stored report snapshots",
+	Long: `Render time series of commits, merged pull requests, closed issues and
+contributors from the snapshots stored by previous reports, without calling the GitHub API`,
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().StringVarP(&historyRepo, "repo", "r", "", "Repository name (owner/repo)")
+	historyCmd.Flags().StringVarP(&historyUser, "user", "u", "", "Use the snapshots of reports filtered by this user")
+	historyCmd.Flags().StringVar(&historyFrom, "from", "", "Only snapshots starting on or after this date (YYYY-MM-DD)")
+	historyCmd.Flags().StringVar(&historyTo, "to", "", "Only snapshots ending on or before this date (YYYY-MM-DD)")
+	historyCmd.Flags().StringVar(&historyInterval, "interval", history.IntervalSnapshot, "Group snapshots by interval (snapshot, week, month)")
+	historyCmd.Flags().StringVarP(&historyFormat, "format", "f", report.FormatMarkdown, "Output format (markdown, json)")
+	historyCmd.Flags().StringVar(&historyStoreDir, "history-dir", history.DefaultDir(), "Directory of the report snapshot store")
+
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	if historyRepo == "" {
+		return errors.NewInvalidParamsError("repo", "repository flag is required")
+	}
+
+	if historyFormat != report.FormatMarkdown && historyFormat != report.FormatJSON {
+		return errors.NewInvalidParamsError("format", fmt.Sprintf("unsupported output format %q (expected markdown or json)", historyFormat))
+	}
+
+	var from, to time.Time
+	var err error
+	if historyFrom != "" {
+		from, err = parseDate(historyFrom)
+		if err != nil {
+			return errors.NewInvalidParamsError("from", fmt.Sprintf("invalid date format: %v", err))
+		}
+	}
+	if historyTo != "" {
+		to, err = parseDate(historyTo)
+		if err != nil {
+			return errors.NewInvalidParamsError("to", fmt.Sprintf("invalid date format: %v", err))
+		}
+		// Include snapshots ending during the given day
+		to = to.AddDate(0, 0, 1)
+	}
+
+	snapshots, err := history.NewStore(historyStoreDir).List(historyRepo, historyUser)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	selected, skipped := history.SelectNonOverlapping(history.FilterPeriod(snapshots, from, to))
+	points, err := history.BuildSeries(selected, historyInterval)
+	if err != nil {
+		return errors.NewInvalidParamsError("interval", err.Error())
+	}
+
+	historyReport := &history.Report{
+		Repository: historyRepo,
+		User:       historyUser,
+		Interval:   historyInterval,
+		Skipped:    skipped,
+		Points:     points,
+	}
+
+	var output string
+	if historyFormat == report.FormatJSON {
+		output, err = history.RenderJSON(historyReport)
+		if err != nil {
+			return err
+		}
+	} else {
+		output = history.RenderMarkdown(historyReport)
+	}
+
+	fmt.Println(output)
+	return nil
+}
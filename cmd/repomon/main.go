@@ -1,13 +1,20 @@
 package main
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/hazadus/gh-repomon/internal/errors"
 	"github.com/hazadus/gh-repomon/internal/github"
+	"github.com/hazadus/gh-repomon/internal/history"
 	"github.com/hazadus/gh-repomon/internal/llm"
+	"github.com/hazadus/gh-repomon/internal/localgit"
 	"github.com/hazadus/gh-repomon/internal/logger"
 	"github.com/hazadus/gh-repomon/internal/report"
 	"github.com/hazadus/gh-repomon/internal/types"
@@ -15,7 +22,10 @@ import (
 )
 
 var (
-	repo        string
+	repos       []string
+	org         string
+	topics      []string
+	namePattern string
 	days        int
 	fromDate    string
 	toDate      string
@@ -25,6 +35,27 @@ var (
 	language    string
 	noAI        bool
 	verbose     bool
+	format      string
+	tmplPath    string
+	maxItems    int
+	backend     string
+	localPath   string
+	noCache     bool
+	cacheDir    string
+	timeout     time.Duration
+	compare     string
+	noHistory   bool
+	historyDir  string
+	incidents   []string
+	hygiene     bool
+	staleBranch int
+	stalePR     int
+)
+
+// Supported data collection backends
+const (
+	backendREST    = "rest"
+	backendGraphQL = "graphql"
 )
 
 var rootCmd = &cobra.Command{
@@ -36,8 +67,10 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	// Required flags
-	rootCmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository name (owner/repo) (required)")
-	_ = rootCmd.MarkFlagRequired("repo")
+	rootCmd.Flags().StringSliceVarP(&repos, "repo", "r", nil, "Repository name (owner/repo); repeat or separate with commas for a combined report")
+	rootCmd.Flags().StringVar(&org, "org", "", "Report on all repositories of an organization")
+	rootCmd.Flags().StringSliceVar(&topics, "topic", nil, "With --org: only repositories with one of these topics")
+	rootCmd.Flags().StringVar(&namePattern, "name-pattern", "", "With --org: only repositories whose name matches this glob pattern (e.g. 'api-*')")
 
 	// Optional flags
 	rootCmd.Flags().IntVarP(&days, "days", "d", 1, "Number of days back from today")
@@ -49,6 +82,21 @@ func init() {
 	rootCmd.Flags().StringVarP(&language, "language", "l", "english", "Output language")
 	rootCmd.Flags().BoolVar(&noAI, "no-ai", false, "Disable AI summary generation (faster)")
 	rootCmd.Flags().BoolVar(&verbose, "verbose", false, "Enable verbose logging")
+	rootCmd.Flags().StringVarP(&format, "format", "f", report.FormatMarkdown, "Output format (markdown, json, html)")
+	rootCmd.Flags().StringVar(&tmplPath, "template", "", "Path to a Go text/template file used to render the report")
+	rootCmd.Flags().IntVar(&maxItems, "max-items", 0, "Maximum number of items fetched per GitHub listing (0 = no limit)")
+	rootCmd.Flags().StringVar(&backend, "backend", backendREST, "GitHub API used to collect data (rest, graphql)")
+	rootCmd.Flags().BoolVar(&noCache, "no-cache", false, "Disable the on-disk cache of GitHub API responses")
+	rootCmd.Flags().StringVar(&cacheDir, "cache-dir", github.DefaultCacheDir(), "Directory of the GitHub API response cache")
+	rootCmd.Flags().StringVar(&localPath, "local-path", "", "Read branches and commits from a local clone at this path instead of the API")
+	rootCmd.Flags().DurationVar(&timeout, "timeout", 0, "Abort data collection after this duration and render a partial report (e.g. 5m, 0 = no limit)")
+	rootCmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not store a snapshot of the report for the history command")
+	rootCmd.Flags().StringVar(&historyDir, "history-dir", history.DefaultDir(), "Directory of the report snapshot store")
+	rootCmd.Flags().StringVar(&compare, "compare", "", "Compare with another period and show trend deltas (previous)")
+	rootCmd.Flags().StringSliceVar(&incidents, "incident-label", report.DefaultIncidentLabels, "Issue labels that mark incidents for the delivery metrics")
+	rootCmd.Flags().BoolVar(&hygiene, "hygiene", false, "Add the repository hygiene section (costs extra API requests per branch and open pull request)")
+	rootCmd.Flags().IntVar(&staleBranch, "stale-branch-days", report.DefaultStaleBranchDays, "Days without commits after which a branch is reported as stale")
+	rootCmd.Flags().IntVar(&stalePR, "stale-pr-days", report.DefaultStalePRDays, "Days without activity after which an open pull request is reported as stale")
 }
 
 func run(cmd *cobra.Command, args []string) error {
@@ -58,9 +106,55 @@ func run(cmd *cobra.Command, args []string) error {
 		log.SetVerbose(true)
 	}
 
-	// Validate that --repo is provided
-	if repo == "" {
-		return errors.NewInvalidParamsError("repo", "repository flag is required")
+	// Validate that --repo or --org is provided
+	if len(repos) == 0 && org == "" {
+		return errors.NewInvalidParamsError("repo", "repository flag is required (or --org for all repositories of an organization)")
+	}
+	if org == "" && (len(topics) > 0 || namePattern != "") {
+		return errors.NewInvalidParamsError("org", "--topic and --name-pattern require --org")
+	}
+
+	// Validate output format
+	if format != report.FormatMarkdown && format != report.FormatJSON && format != report.FormatHTML {
+		return errors.NewInvalidParamsError("format", fmt.Sprintf("unsupported output format %q (expected markdown, json or html)", format))
+	}
+
+	// Validate data collection backend
+	if backend != backendREST && backend != backendGraphQL {
+		return errors.NewInvalidParamsError("backend", fmt.Sprintf("unsupported backend %q (expected rest or graphql)", backend))
+	}
+
+	// Validate item cap
+	if maxItems < 0 {
+		return errors.NewInvalidParamsError("max-items", "must be zero (no limit) or a positive number")
+	}
+
+	// Validate timeout
+	if timeout < 0 {
+		return errors.NewInvalidParamsError("timeout", "must be zero (no limit) or a positive duration")
+	}
+
+	// Validate hygiene thresholds
+	if staleBranch <= 0 {
+		return errors.NewInvalidParamsError("stale-branch-days", "must be a positive number of days")
+	}
+	if stalePR <= 0 {
+		return errors.NewInvalidParamsError("stale-pr-days", "must be a positive number of days")
+	}
+
+	// Validate period comparison
+	if compare != "" && compare != report.ComparePrevious {
+		return errors.NewInvalidParamsError("compare", fmt.Sprintf("unsupported comparison %q (expected previous)", compare))
+	}
+
+	// Validate template file before doing any API calls
+	if tmplPath != "" {
+		if format != report.FormatMarkdown {
+			return errors.NewInvalidParamsError("template", fmt.Sprintf("cannot be combined with --format %s", format))
+		}
+		if _, err := report.NewTemplateRendererFromFile(tmplPath); err != nil {
+			return errors.NewInvalidParamsError("template", err.Error())
+		}
 	}
 
 	// Calculate period
@@ -90,13 +184,55 @@ func run(cmd *cobra.Command, args []string) error {
 		from = to.AddDate(0, 0, -days)
 	}
 
+	// Stop on Ctrl-C or SIGTERM and render what was collected so far.
+	// The handler is removed after the first signal, so a second one terminates immediately.
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	// Create GitHub client
 	log.Info("Connecting to GitHub API...")
-	ghClient, err := github.NewClient(excludeBots)
+	ghClient, err := newGitHubClient()
 	if err != nil {
 		return errors.NewGitHubAuthError("failed to create GitHub client", err)
 	}
-	log.Success("Connected to GitHub API")
+	log.Success(fmt.Sprintf("Connected to GitHub API (%s)", backend))
+
+	// Resolve the repositories of the report
+	repositories, err := resolveRepositories(ctx, ghClient)
+	if err != nil {
+		return err
+	}
+	if len(repositories) == 0 {
+		return errors.NewInvalidParamsError("org", fmt.Sprintf("no repositories of %s match the given filters", org))
+	}
+
+	// Custom templates are written against the data of a single repository
+	if tmplPath != "" && len(repositories) > 1 {
+		return errors.NewInvalidParamsError("template", "a custom template can only be used for a single repository")
+	}
+
+	// Read commit data from a local clone if requested
+	if localPath != "" {
+		if len(repositories) > 1 {
+			return errors.NewInvalidParamsError("local-path", "a local clone can only be used for a single repository")
+		}
+
+		ghClient, err = localgit.NewClient(localPath, ghClient, excludeBots)
+		if err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Reading branches and commits from local clone %s", localPath))
+	}
 
 	// Create report generator
 	var generator *report.Generator
@@ -114,35 +250,58 @@ func run(cmd *cobra.Command, args []string) error {
 			generator = report.NewGeneratorWithClients(ghClient, nil)
 		} else {
 			log.Success("Connected to LLM API")
-			generator = report.NewGenerator(ghClient, llmClient)
+			generator = report.NewGeneratorWithClients(ghClient, llmClient)
 		}
 	}
 
-	log.Info(fmt.Sprintf("Analyzing repository %s (%s to %s)",
-		repo,
-		from.Format("2006-01-02"),
-		to.Format("2006-01-02")))
+	// Store report snapshots for the history command
+	if !noHistory {
+		generator.SetSnapshotStore(history.NewStore(historyDir))
+	}
+
+	if len(repositories) == 1 {
+		log.Info(fmt.Sprintf("Analyzing repository %s (%s to %s)",
+			repositories[0],
+			from.Format("2006-01-02"),
+			to.Format("2006-01-02")))
+	} else {
+		log.Info(fmt.Sprintf("Analyzing %d repositories (%s to %s)",
+			len(repositories),
+			from.Format("2006-01-02"),
+			to.Format("2006-01-02")))
+	}
 
 	// Create report options
 	opts := report.Options{
-		Repository: repo,
+		Repositories: repositories,
 		Period: types.Period{
 			From: from,
 			To:   to,
 		},
-		User:     user,
-		Model:    model,
-		Language: language,
+		User:            user,
+		Model:           model,
+		Language:        language,
+		Format:          format,
+		Template:        tmplPath,
+		Compare:         compare,
+		IncidentLabels:  incidents,
+		Hygiene:         hygiene,
+		StaleBranchDays: staleBranch,
+		StalePRDays:     stalePR,
 	}
 
 	// Generate report
 	log.Progress("Collecting repository data...")
-	reportText, err := generator.Generate(opts)
+	reportText, err := generator.Generate(ctx, opts)
 	if err != nil {
 		return fmt.Errorf("failed to generate report: %w", err)
 	}
 
-	log.Success("Report generated successfully!")
+	if ctx.Err() != nil {
+		log.Warning("Report is incomplete: the run was stopped before all data was collected")
+	} else {
+		log.Success("Report generated successfully!")
+	}
 
 	// Output report to stdout
 	fmt.Println(reportText)
@@ -150,6 +309,68 @@ func run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// newGitHubClient creates the GitHub client for the selected backend
+func newGitHubClient() (report.GitHubClient, error) {
+	opts := github.ClientOptions{
+		ExcludeBots: excludeBots,
+		MaxItems:    maxItems,
+	}
+	if !noCache {
+		opts.CacheDir = cacheDir
+	}
+
+	if backend == backendGraphQL {
+		return github.NewGraphQLClient(opts)
+	}
+	return github.NewClientWithOptions(opts)
+}
+
+// organizationLister is implemented by GitHub clients that can list organization repositories
+type organizationLister interface {
+	GetOrganizationRepositories(ctx context.Context, org string) ([]types.Repository, error)
+}
+
+// resolveRepositories returns the repositories given with --repo followed by
+// the repositories of --org that pass the topic and name filters, without duplicates
+func resolveRepositories(ctx context.Context, client report.GitHubClient) ([]string, error) {
+	result := make([]string, 0, len(repos))
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+
+	for _, name := range repos {
+		add(strings.TrimSpace(name))
+	}
+
+	if org == "" {
+		return result, nil
+	}
+
+	lister, ok := client.(organizationLister)
+	if !ok {
+		return nil, errors.NewInvalidParamsError("org", "the selected backend cannot list organization repositories")
+	}
+
+	orgRepos, err := lister.GetOrganizationRepositories(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := github.FilterRepositories(orgRepos, topics, namePattern)
+	if err != nil {
+		return nil, errors.NewInvalidParamsError("name-pattern", err.Error())
+	}
+	for _, name := range names {
+		add(name)
+	}
+
+	return result, nil
+}
+
 // parseDate parses a date string in YYYY-MM-DD format
 func parseDate(dateStr string) (time.Time, error) {
 	t, err := time.Parse("2006-01-02", dateStr)
@@ -159,29 +380,69 @@ func parseDate(dateStr string) (time.Time, error) {
 	return t, nil
 }
 
+// Exit codes reported for the different failure classes
+const (
+	exitGeneral          = 1
+	exitInvalidParams    = 2
+	exitAuth             = 3
+	exitRepoNotFound     = 4
+	exitPermissionDenied = 5
+	exitRateLimited      = 6
+	exitGitHubAPI        = 7
+	exitLLMAPI           = 8
+)
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		// Handle specific error types
-		switch e := err.(type) {
-		case *errors.ErrGitHubAuth:
-			fmt.Fprintf(os.Stderr, "❌ Authentication Error: %v\n", e)
-			fmt.Fprintf(os.Stderr, "Please ensure you are authenticated with GitHub CLI: gh auth login\n")
-			os.Exit(1)
-		case *errors.ErrGitHubAPI:
-			fmt.Fprintf(os.Stderr, "❌ GitHub API Error: %v\n", e)
-			os.Exit(1)
-		case *errors.ErrRepoNotFound:
-			fmt.Fprintf(os.Stderr, "❌ Repository Not Found: %v\n", e)
-			os.Exit(1)
-		case *errors.ErrInvalidParams:
-			fmt.Fprintf(os.Stderr, "❌ Invalid Parameters: %v\n", e)
-			os.Exit(1)
-		case *errors.ErrLLMAPI:
-			fmt.Fprintf(os.Stderr, "❌ LLM API Error: %v\n", e)
-			os.Exit(1)
-		default:
-			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
-			os.Exit(1)
-		}
+		os.Exit(reportError(err))
+	}
+}
+
+// reportError prints an actionable message for err and returns the exit code
+func reportError(err error) int {
+	var (
+		authErr       *errors.ErrGitHubAuth
+		notFoundErr   *errors.ErrRepoNotFound
+		permissionErr *errors.ErrPermissionDenied
+		rateLimitErr  *errors.ErrRateLimited
+		serverErr     *errors.ErrGitHubServer
+		apiErr        *errors.ErrGitHubAPI
+		paramsErr     *errors.ErrInvalidParams
+		llmErr        *errors.ErrLLMAPI
+	)
+
+	switch {
+	case stderrors.As(err, &paramsErr):
+		fmt.Fprintf(os.Stderr, "❌ Invalid Parameters: %v\n", paramsErr)
+		return exitInvalidParams
+	case stderrors.As(err, &authErr):
+		fmt.Fprintf(os.Stderr, "❌ Authentication Error: %v\n", authErr)
+		fmt.Fprintf(os.Stderr, "Please ensure you are authenticated with GitHub CLI: gh auth login\n")
+		return exitAuth
+	case stderrors.As(err, &notFoundErr):
+		fmt.Fprintf(os.Stderr, "❌ Repository Not Found: %v\n", notFoundErr)
+		fmt.Fprintf(os.Stderr, "Check the owner/repo spelling and that your account can access it: gh auth status\n")
+		return exitRepoNotFound
+	case stderrors.As(err, &permissionErr):
+		fmt.Fprintf(os.Stderr, "❌ Permission Denied: %v\n", permissionErr)
+		fmt.Fprintf(os.Stderr, "Your token lacks access to this data. Request the missing scopes with: gh auth refresh -s repo,read:org\n")
+		return exitPermissionDenied
+	case stderrors.As(err, &rateLimitErr):
+		fmt.Fprintf(os.Stderr, "❌ Rate Limit Exceeded: %v\n", rateLimitErr)
+		fmt.Fprintf(os.Stderr, "Try again after the reset, or use fewer requests with --backend graphql, --local-path or --max-items\n")
+		return exitRateLimited
+	case stderrors.As(err, &serverErr):
+		fmt.Fprintf(os.Stderr, "❌ GitHub Server Error: %v\n", serverErr)
+		fmt.Fprintf(os.Stderr, "GitHub is having problems, please try again later (https://www.githubstatus.com)\n")
+		return exitGitHubAPI
+	case stderrors.As(err, &apiErr):
+		fmt.Fprintf(os.Stderr, "❌ GitHub API Error: %v\n", apiErr)
+		return exitGitHubAPI
+	case stderrors.As(err, &llmErr):
+		fmt.Fprintf(os.Stderr, "❌ LLM API Error: %v\n", llmErr)
+		return exitLLMAPI
+	default:
+		fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+		return exitGeneral
 	}
 }
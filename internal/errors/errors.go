@@ -3,6 +3,7 @@ package errors
 
 import (
 	"fmt"
+	"time"
 )
 
 // ErrGitHubAuth represents GitHub authentication errors
@@ -18,6 +19,10 @@ func (e *ErrGitHubAuth) Error() string {
 	return fmt.Sprintf("GitHub authentication error: %s", e.Message)
 }
 
+func (e *ErrGitHubAuth) Unwrap() error {
+	return e.Cause
+}
+
 // NewGitHubAuthError creates a new GitHub authentication error
 func NewGitHubAuthError(message string, cause error) *ErrGitHubAuth {
 	return &ErrGitHubAuth{
@@ -46,6 +51,10 @@ func (e *ErrGitHubAPI) Error() string {
 	return fmt.Sprintf("GitHub API error: %s", e.Message)
 }
 
+func (e *ErrGitHubAPI) Unwrap() error {
+	return e.Cause
+}
+
 // NewGitHubAPIError creates a new GitHub API error
 func NewGitHubAPIError(message string, statusCode int, cause error) *ErrGitHubAPI {
 	return &ErrGitHubAPI{
@@ -71,6 +80,86 @@ func NewRepoNotFoundError(repository string) *ErrRepoNotFound {
 	}
 }
 
+// ErrRateLimited represents GitHub API rate limit errors
+type ErrRateLimited struct {
+	// ResetAt is when the rate limit resets (zero if unknown)
+	ResetAt time.Time
+	Cause   error
+}
+
+func (e *ErrRateLimited) Error() string {
+	msg := "GitHub API rate limit exceeded"
+	if !e.ResetAt.IsZero() {
+		msg += fmt.Sprintf(" (resets at %s)", e.ResetAt.Local().Format("15:04:05"))
+	}
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", msg, e.Cause)
+	}
+	return msg
+}
+
+func (e *ErrRateLimited) Unwrap() error {
+	return e.Cause
+}
+
+// NewRateLimitedError creates a new rate limit error
+func NewRateLimitedError(resetAt time.Time, cause error) *ErrRateLimited {
+	return &ErrRateLimited{
+		ResetAt: resetAt,
+		Cause:   cause,
+	}
+}
+
+// ErrPermissionDenied represents requests rejected because the token lacks access
+type ErrPermissionDenied struct {
+	Resource string
+	Cause    error
+}
+
+func (e *ErrPermissionDenied) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("permission denied for %s: %v", e.Resource, e.Cause)
+	}
+	return fmt.Sprintf("permission denied for %s", e.Resource)
+}
+
+func (e *ErrPermissionDenied) Unwrap() error {
+	return e.Cause
+}
+
+// NewPermissionDeniedError creates a new permission denied error
+func NewPermissionDeniedError(resource string, cause error) *ErrPermissionDenied {
+	return &ErrPermissionDenied{
+		Resource: resource,
+		Cause:    cause,
+	}
+}
+
+// ErrGitHubServer represents GitHub server-side (5xx) errors
+type ErrGitHubServer struct {
+	StatusCode int
+	Cause      error
+}
+
+func (e *ErrGitHubServer) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("GitHub server error (status %d): %v", e.StatusCode, e.Cause)
+	}
+	return fmt.Sprintf("GitHub server error (status %d)", e.StatusCode)
+}
+
+func (e *ErrGitHubServer) Unwrap() error {
+	return e.Cause
+}
+
+// NewGitHubServerError creates a new GitHub server error
+func NewGitHubServerError(statusCode int, cause error) *ErrGitHubServer {
+	return &ErrGitHubServer{
+		StatusCode: statusCode,
+		Cause:      cause,
+	}
+}
+
 // ErrInvalidParams represents invalid parameter errors
 type ErrInvalidParams struct {
 	Parameter string
@@ -109,6 +198,10 @@ func (e *ErrLLMAPI) Error() string {
 	return fmt.Sprintf("LLM API error: %s", e.Message)
 }
 
+func (e *ErrLLMAPI) Unwrap() error {
+	return e.Cause
+}
+
 // NewLLMAPIError creates a new LLM API error
 func NewLLMAPIError(message string, statusCode int, cause error) *ErrLLMAPI {
 	return &ErrLLMAPI{
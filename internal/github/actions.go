@@ -0,0 +1,180 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
+)
+
+// jobLookupLimit caps the number of runs whose jobs are fetched; jobs are
+// only needed for the longest runs
+const jobLookupLimit = 10
+
+// rerunLookupLimit caps the number of re-run runs whose earlier attempts are fetched
+const rerunLookupLimit = 20
+
+// workflowRunsResponse represents the GitHub API response for a workflow run listing
+type workflowRunsResponse struct {
+	WorkflowRuns []workflowRunResponse `json:"workflow_runs"`
+}
+
+// workflowRunResponse represents the GitHub API response for a workflow run
+type workflowRunResponse struct {
+	ID           int64     `json:"id"`
+	WorkflowID   int64     `json:"workflow_id"`
+	Name         string    `json:"name"`
+	HeadBranch   string    `json:"head_branch"`
+	HeadSHA      string    `json:"head_sha"`
+	Event        string    `json:"event"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	RunAttempt   int       `json:"run_attempt"`
+	RunStartedAt time.Time `json:"run_started_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	HTMLURL      string    `json:"html_url"`
+	Actor        struct {
+		Login   string `json:"login"`
+		HTMLURL string `json:"html_url"`
+	} `json:"actor"`
+}
+
+// workflowJobsResponse represents the GitHub API response for the jobs of a workflow run
+type workflowJobsResponse struct {
+	Jobs []workflowJobResponse `json:"jobs"`
+}
+
+// workflowJobResponse represents the GitHub API response for a workflow job
+type workflowJobResponse struct {
+	Name        string     `json:"name"`
+	Conclusion  string     `json:"conclusion"`
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	HTMLURL     string     `json:"html_url"`
+}
+
+// GetWorkflowRuns retrieves the GitHub Actions workflow runs created during
+// the period, newest first. Jobs are fetched for the longest completed runs
+// only, earlier attempts for the most recent re-runs only.
+func (c *Client) GetWorkflowRuns(ctx context.Context, repo string, from, to time.Time) ([]types.WorkflowRun, error) {
+	path := fmt.Sprintf("repos/%s/actions/runs?created=%s..%s",
+		repo,
+		from.UTC().Format(time.RFC3339),
+		to.UTC().Format(time.RFC3339))
+
+	var runs []types.WorkflowRun
+	err := paginateWrapped(ctx, c, fmt.Sprintf("workflow runs of %s", repo), path,
+		func(r workflowRunsResponse) []workflowRunResponse { return r.WorkflowRuns },
+		func(page []workflowRunResponse) bool {
+			for _, r := range page {
+				runs = append(runs, types.WorkflowRun{
+					ID:         r.ID,
+					WorkflowID: r.WorkflowID,
+					Workflow:   r.Name,
+					Branch:     r.HeadBranch,
+					SHA:        r.HeadSHA,
+					Event:      r.Event,
+					Status:     r.Status,
+					Conclusion: r.Conclusion,
+					Attempt:    r.RunAttempt,
+					Actor:      types.Author{Login: r.Actor.Login, ProfileURL: r.Actor.HTMLURL, IsBot: c.isBot(r.Actor.Login)},
+					StartedAt:  r.RunStartedAt,
+					UpdatedAt:  r.UpdatedAt,
+					URL:        r.HTMLURL,
+				})
+			}
+			return true
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow runs: %w", err)
+	}
+
+	c.fillLongestRunJobs(ctx, repo, runs)
+	c.fillPreviousAttempts(ctx, repo, runs)
+
+	return runs, nil
+}
+
+// fillPreviousAttempts fetches the conclusions of the earlier attempts of the
+// most recent completed re-runs. Runs whose attempts cannot be fetched are
+// left without them.
+func (c *Client) fillPreviousAttempts(ctx context.Context, repo string, runs []types.WorkflowRun) {
+	// Runs are listed newest first
+	var indexes []int
+	for i, run := range runs {
+		if run.Status == "completed" && run.Attempt > 1 && len(indexes) < rerunLookupLimit {
+			indexes = append(indexes, i)
+		}
+	}
+
+	// Each worker writes only its own run
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, c.concurrency(5), func(ctx context.Context, i int) error {
+		run := &runs[i]
+
+		conclusions := make([]string, 0, run.Attempt-1)
+		for attempt := 1; attempt < run.Attempt; attempt++ {
+			var r workflowRunResponse
+			path := fmt.Sprintf("repos/%s/actions/runs/%d/attempts/%d", repo, run.ID, attempt)
+			if err := c.doWithRetry(ctx, "GET", path, nil, &r); err != nil {
+				return nil
+			}
+			conclusions = append(conclusions, r.Conclusion)
+		}
+		run.PreviousConclusions = conclusions
+		return nil
+	})
+}
+
+// fillLongestRunJobs fetches the jobs of the longest completed runs.
+// Runs whose jobs cannot be fetched are left without jobs.
+func (c *Client) fillLongestRunJobs(ctx context.Context, repo string, runs []types.WorkflowRun) {
+	var indexes []int
+	for i, run := range runs {
+		if run.Status == "completed" {
+			indexes = append(indexes, i)
+		}
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return runDuration(runs[indexes[i]]) > runDuration(runs[indexes[j]])
+	})
+	if len(indexes) > jobLookupLimit {
+		indexes = indexes[:jobLookupLimit]
+	}
+
+	// Each worker writes only its own run
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, c.concurrency(5), func(ctx context.Context, i int) error {
+		run := &runs[i]
+		path := fmt.Sprintf("repos/%s/actions/runs/%d/jobs", repo, run.ID)
+
+		var jobs []types.WorkflowJob
+		err := paginateWrapped(ctx, c, fmt.Sprintf("jobs of workflow run %d", run.ID), path,
+			func(r workflowJobsResponse) []workflowJobResponse { return r.Jobs },
+			func(page []workflowJobResponse) bool {
+				for _, j := range page {
+					if j.CompletedAt == nil {
+						continue
+					}
+					jobs = append(jobs, types.WorkflowJob{
+						Name:        j.Name,
+						Conclusion:  j.Conclusion,
+						StartedAt:   j.StartedAt,
+						CompletedAt: *j.CompletedAt,
+						URL:         j.HTMLURL,
+					})
+				}
+				return true
+			})
+		if err == nil {
+			run.Jobs = jobs
+		}
+		return nil
+	})
+}
+
+// runDuration returns how long the latest attempt of a run took
+func runDuration(run types.WorkflowRun) time.Duration {
+	return run.UpdatedAt.Sub(run.StartedAt)
+}
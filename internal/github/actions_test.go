@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetWorkflowRuns(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/actions/runs": `{"total_count":2,"workflow_runs":[
+			{"id":2,"name":"CI","head_branch":"main","head_sha":"bbb","event":"push","status":"in_progress","conclusion":null,
+				"run_attempt":1,"run_started_at":"2025-01-10T12:00:00Z","updated_at":"2025-01-10T12:05:00Z",
+				"html_url":"https://github.com/o/r/actions/runs/2","actor":{"login":"alice","html_url":"https://github.com/alice"}},
+			{"id":1,"workflow_id":10,"name":"CI","head_branch":"main","head_sha":"aaa","event":"push","status":"completed","conclusion":"failure",
+				"run_attempt":2,"run_started_at":"2025-01-09T12:00:00Z","updated_at":"2025-01-09T12:30:00Z",
+				"html_url":"https://github.com/o/r/actions/runs/1","actor":{"login":"dependabot[bot]","html_url":"https://github.com/apps/dependabot"}}
+		]}`,
+		"/repos/o/r/actions/runs/1/attempts/1": `{"id":1,"run_attempt":1,"status":"completed","conclusion":"success"}`,
+		"/repos/o/r/actions/runs/1/jobs": `{"total_count":2,"jobs":[
+			{"name":"test","conclusion":"failure","started_at":"2025-01-09T12:01:00Z","completed_at":"2025-01-09T12:29:00Z",
+				"html_url":"https://github.com/o/r/actions/runs/1/job/11"},
+			{"name":"deploy","conclusion":null,"started_at":"2025-01-09T12:29:00Z","completed_at":null,
+				"html_url":"https://github.com/o/r/actions/runs/1/job/12"}
+		]}`,
+	}}
+	c := newTestClient(t, transport, 0)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	runs, err := c.GetWorkflowRuns(context.Background(), "o/r", from, to)
+	if err != nil {
+		t.Fatalf("GetWorkflowRuns() error = %v", err)
+	}
+
+	if len(runs) != 2 {
+		t.Fatalf("got %d runs, want 2", len(runs))
+	}
+
+	running := runs[0]
+	if running.ID != 2 || running.Status != "in_progress" || running.Conclusion != "" || running.Jobs != nil {
+		t.Errorf("got first run %+v, want run 2 in progress without jobs", running)
+	}
+
+	completed := runs[1]
+	if completed.Workflow != "CI" || completed.Branch != "main" || completed.SHA != "aaa" || completed.Attempt != 2 {
+		t.Errorf("got second run %+v, want CI on main for aaa, attempt 2", completed)
+	}
+	if completed.WorkflowID != 10 {
+		t.Errorf("got workflow ID %d, want 10", completed.WorkflowID)
+	}
+	if len(completed.PreviousConclusions) != 1 || completed.PreviousConclusions[0] != "success" {
+		t.Errorf("got previous conclusions %v, want [success] from the first attempt", completed.PreviousConclusions)
+	}
+	if running.PreviousConclusions != nil {
+		t.Errorf("got previous conclusions %v for a first attempt, want none", running.PreviousConclusions)
+	}
+	if !completed.Actor.IsBot {
+		t.Errorf("got actor %+v, want a bot", completed.Actor)
+	}
+	if len(completed.Jobs) != 1 || completed.Jobs[0].Name != "test" {
+		t.Errorf("got jobs %+v, want only the completed job test", completed.Jobs)
+	}
+	if got := completed.Jobs[0].CompletedAt.Sub(completed.Jobs[0].StartedAt); got != 28*time.Minute {
+		t.Errorf("got job duration %v, want 28m", got)
+	}
+}
@@ -1,11 +1,13 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"time"
 
 	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
 )
 
 // branchResponse represents the GitHub API response for a branch
@@ -18,18 +20,12 @@ type branchResponse struct {
 }
 
 // GetBranches retrieves all branches from a repository
-func (c *Client) GetBranches(repo string) ([]string, error) {
-	// Build API path
-	path := fmt.Sprintf("repos/%s/branches", repo)
-
-	// Make API request with retry
-	var response []branchResponse
-	err := c.doWithRetry("GET", path, nil, &response)
+func (c *Client) GetBranches(ctx context.Context, repo string) ([]string, error) {
+	response, err := c.listBranches(ctx, repo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get branches: %w", err)
+		return nil, err
 	}
 
-	// Extract branch names
 	branches := make([]string, 0, len(response))
 	for _, br := range response {
 		branches = append(branches, br.Name)
@@ -38,55 +34,173 @@ func (c *Client) GetBranches(repo string) ([]string, error) {
 	return branches, nil
 }
 
-// GetActiveBranches retrieves branches that have commits during the specified period
-func (c *Client) GetActiveBranches(repo string, from, to time.Time) ([]types.Branch, error) {
+// GetBranchHeads retrieves the latest commit of every branch of a repository.
+// Branches whose latest commit cannot be fetched are skipped.
+func (c *Client) GetBranchHeads(ctx context.Context, repo string) ([]types.BranchHead, error) {
+	response, err := c.listBranches(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each worker writes only its own element of heads
+	heads := make([]types.BranchHead, len(response))
+	found := make([]bool, len(response))
+	indexes := make([]int, len(response))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, c.concurrency(5), func(ctx context.Context, i int) error {
+		cr, err := c.getCommit(ctx, repo, response[i].Commit.SHA)
+		if err != nil {
+			return nil
+		}
+
+		commit := c.toCommit(cr)
+		heads[i] = types.BranchHead{
+			Name:        response[i].Name,
+			SHA:         commit.SHA,
+			Author:      commit.Author,
+			CommittedAt: commit.Date,
+		}
+		found[i] = true
+		return nil
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get branch heads: %w", err)
+	}
+
+	result := make([]types.BranchHead, 0, len(heads))
+	for i, head := range heads {
+		if !found[i] || (c.excludeBots && head.Author.IsBot) {
+			continue
+		}
+		result = append(result, head)
+	}
+
+	return result, nil
+}
+
+// listBranches retrieves all branches of a repository with their latest commit SHA
+func (c *Client) listBranches(ctx context.Context, repo string) ([]branchResponse, error) {
+	// Build API path
+	path := fmt.Sprintf("repos/%s/branches", repo)
+
+	// Fetch all pages
+	var branches []branchResponse
+	err := paginate(ctx, c, fmt.Sprintf("branches of %s", repo), path, func(page []branchResponse) bool {
+		branches = append(branches, page...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	return branches, nil
+}
+
+// GetActiveBranches retrieves branches that have commits during the specified
+// period. Commits reachable from several branches are listed on each of them,
+// but their details are fetched once.
+func (c *Client) GetActiveBranches(ctx context.Context, repo string, from, to time.Time) ([]types.Branch, error) {
 	// Get all branches
-	branchNames, err := c.GetBranches(repo)
+	branchNames, err := c.GetBranches(ctx, repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get branches: %w", err)
 	}
 
-	// Collect active branches
-	activeBranches := make([]types.Branch, 0)
+	// Details of commits already seen on another branch are not fetched again
+	names := make([]string, 0, len(branchNames))
+	listings := make([][]commitResponse, 0, len(branchNames))
+	details := make(map[string]commitResponse)
 
 	for _, branchName := range branchNames {
 		// Get commits for this branch during the period
-		commits, err := c.GetCommits(repo, branchName, from, to)
+		listed, err := c.listCommits(ctx, repo, branchName, from, to)
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, fmt.Errorf("failed to get active branches: %w", ctxErr)
+			}
 			// If we can't get commits for a branch, skip it but don't fail
 			continue
 		}
 
 		// Skip branches with no activity
-		if len(commits) == 0 {
+		if len(listed) == 0 {
 			continue
 		}
 
-		// Calculate statistics
-		totalAdded := 0
-		totalDeleted := 0
-		for _, commit := range commits {
-			totalAdded += commit.Additions
-			totalDeleted += commit.Deletions
-		}
+		c.fillCommitDetails(ctx, repo, listed, details)
+		names = append(names, branchName)
+		listings = append(listings, listed)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get active branches: %w", err)
+	}
 
-		// Get unique authors
-		authors := uniqueAuthors(commits)
-
-		// Create branch object
-		branch := types.Branch{
-			Name:         branchName,
-			Commits:      commits,
-			PRs:          []types.PullRequest{}, // Will be populated later if needed
-			TotalAdded:   totalAdded,
-			TotalDeleted: totalDeleted,
-			Authors:      authors,
+	// Collect active branches
+	activeBranches := make([]types.Branch, 0, len(names))
+	for i, name := range names {
+		activeBranches = append(activeBranches, NewBranch(name, c.withDetails(listings[i], details)))
+	}
+
+	return activeBranches, nil
+}
+
+// NewBranch builds a branch object with statistics calculated from its commits
+func NewBranch(name string, commits []types.Commit) types.Branch {
+	// Calculate statistics
+	totalAdded := 0
+	totalDeleted := 0
+	var lastActivity time.Time
+	for _, commit := range commits {
+		totalAdded += commit.Additions
+		totalDeleted += commit.Deletions
+		if commit.Date.After(lastActivity) {
+			lastActivity = commit.Date
 		}
+	}
 
-		activeBranches = append(activeBranches, branch)
+	return types.Branch{
+		Name:         name,
+		Commits:      commits,
+		PRs:          []types.PullRequest{}, // Populated by the report generator
+		TotalAdded:   totalAdded,
+		TotalDeleted: totalDeleted,
+		Authors:      uniqueAuthors(commits),
+		LastActivity: lastActivity,
 	}
+}
 
-	return activeBranches, nil
+// CompareBranches compares head with base and lists the commits of head that
+// are not on base
+func (c *Client) CompareBranches(ctx context.Context, repo, base, head string) (*types.BranchComparison, error) {
+	compared, err := c.compare(ctx, repo, base, head, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", head, base, err)
+	}
+
+	comparison := &types.BranchComparison{
+		Ahead:     compared.AheadBy,
+		Behind:    compared.BehindBy,
+		Truncated: compared.Truncated,
+	}
+	for _, cr := range compared.Commits {
+		comparison.Commits = append(comparison.Commits, cr.SHA)
+	}
+
+	return comparison, nil
+}
+
+// CountBranchCommits compares head with base without listing commits, which
+// takes a single request regardless of the size of the branch
+func (c *Client) CountBranchCommits(ctx context.Context, repo, base, head string) (*types.BranchComparison, error) {
+	compared, err := c.compare(ctx, repo, base, head, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", head, base, err)
+	}
+
+	return &types.BranchComparison{Ahead: compared.AheadBy, Behind: compared.BehindBy}, nil
 }
 
 // uniqueAuthors extracts unique author logins from commits and returns them sorted
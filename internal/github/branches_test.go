@@ -0,0 +1,156 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestNewBranch(t *testing.T) {
+	commits := []types.Commit{
+		{SHA: "b", Author: types.Author{Login: "bob"}, Date: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), Additions: 5, Deletions: 1},
+		{SHA: "a", Author: types.Author{Login: "alice"}, Date: time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC), Additions: 3},
+	}
+
+	branch := NewBranch("feature", commits)
+
+	if branch.TotalAdded != 8 || branch.TotalDeleted != 1 {
+		t.Errorf("got +%d/-%d, want +8/-1", branch.TotalAdded, branch.TotalDeleted)
+	}
+	if len(branch.Authors) != 2 || branch.Authors[0] != "alice" {
+		t.Errorf("got authors %v, want alice and bob", branch.Authors)
+	}
+	if !branch.LastActivity.Equal(commits[1].Date) {
+		t.Errorf("got last activity %v, want %v", branch.LastActivity, commits[1].Date)
+	}
+}
+
+func TestCompareBranches(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/compare/main...feature/search": `{"status":"diverged","ahead_by":2,"behind_by":5,"commits":[
+			{"sha":"c1","commit":{"author":{"name":"Alice","date":"2025-01-08T09:00:00Z"},"message":"Add search"}},
+			{"sha":"c2","commit":{"author":{"name":"Alice","date":"2025-01-09T09:00:00Z"},"message":"Tune search"}}
+		]}`,
+	}}
+	c := newTestClient(t, transport, 0)
+
+	comparison, err := c.CompareBranches(context.Background(), "o/r", "main", "feature/search")
+	if err != nil {
+		t.Fatalf("CompareBranches() error = %v", err)
+	}
+
+	if comparison.Ahead != 2 || comparison.Behind != 5 {
+		t.Errorf("got %d ahead, %d behind, want 2 ahead, 5 behind", comparison.Ahead, comparison.Behind)
+	}
+	if len(comparison.Commits) != 2 || comparison.Commits[0] != "c1" || comparison.Commits[1] != "c2" {
+		t.Errorf("got commits %v, want c1 and c2", comparison.Commits)
+	}
+}
+
+func TestGetBranchHeads(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/branches": `[
+			{"name":"main","commit":{"sha":"m1"}},
+			{"name":"old-feature","commit":{"sha":"f1"}},
+			{"name":"deps","commit":{"sha":"d1"}},
+			{"name":"gone","commit":{"sha":"x1"}}
+		]`,
+		"/repos/o/r/commits/m1": `{"sha":"m1","commit":{"author":{"name":"Alice","date":"2025-01-10T09:00:00Z"}},"author":{"login":"alice"}}`,
+		"/repos/o/r/commits/f1": `{"sha":"f1","commit":{"author":{"name":"Bob","date":"2024-06-01T09:00:00Z"}},"author":{"login":"bob"}}`,
+		"/repos/o/r/commits/d1": `{"sha":"d1","commit":{"author":{"name":"dependabot","date":"2025-01-09T09:00:00Z"}},"author":{"login":"dependabot[bot]"}}`,
+	}}
+	c := newTestClient(t, transport, 0)
+	c.excludeBots = true
+
+	heads, err := c.GetBranchHeads(context.Background(), "o/r")
+	if err != nil {
+		t.Fatalf("GetBranchHeads() error = %v", err)
+	}
+
+	// The bot branch is excluded and the branch whose commit is missing skipped
+	if len(heads) != 2 || heads[0].Name != "main" || heads[1].Name != "old-feature" {
+		t.Fatalf("got heads %+v, want main and old-feature", heads)
+	}
+	feature := heads[1]
+	if feature.SHA != "f1" || feature.Author.Login != "bob" || !feature.CommittedAt.Equal(time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("got head %+v, want f1 by bob on 2024-06-01", feature)
+	}
+}
+
+// cancelingTransport serves routes and cancels the context once path was requested
+type cancelingTransport struct {
+	routedTransport
+	path   string
+	cancel context.CancelFunc
+}
+
+func (t *cancelingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.routedTransport.RoundTrip(req)
+	if req.URL.Path == t.path {
+		t.cancel()
+	}
+	return resp, err
+}
+
+func TestGetActiveBranchesCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transport := &cancelingTransport{
+		routedTransport: routedTransport{routes: map[string]string{
+			"/repos/o/r/branches": `[{"name":"main","commit":{"sha":"m1"}},{"name":"feature","commit":{"sha":"f1"}}]`,
+			"/repos/o/r/commits":  `[]`,
+		}},
+		path:   "/repos/o/r/branches",
+		cancel: cancel,
+	}
+	c := newTestClient(t, transport, 0)
+
+	// A canceled run is reported as an error, not as a repository without active branches
+	branches, err := c.GetActiveBranches(ctx, "o/r", time.Now().AddDate(0, 0, -7), time.Now())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetActiveBranches() = %v, %v, want context.Canceled", branches, err)
+	}
+}
+
+func TestGetActiveBranchesSharedCommits(t *testing.T) {
+	// Both branches list the same commit
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/branches": `[{"name":"main","commit":{"sha":"c1"}},{"name":"feature","commit":{"sha":"c1"}}]`,
+		"/repos/o/r/commits": `[
+			{"sha":"c1","commit":{"author":{"name":"Alice","date":"2025-01-08T09:00:00Z"},"message":"Add search"},"author":{"login":"alice"}}
+		]`,
+		"/repos/o/r/commits/c1": `{"sha":"c1","stats":{"additions":12,"deletions":3}}`,
+	}}
+	c := newTestClient(t, transport, 0)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	branches, err := c.GetActiveBranches(context.Background(), "o/r", from, to)
+	if err != nil {
+		t.Fatalf("GetActiveBranches() error = %v", err)
+	}
+
+	if len(branches) != 2 {
+		t.Fatalf("got %d branches, want 2", len(branches))
+	}
+	for _, branch := range branches {
+		if len(branch.Commits) != 1 || branch.TotalAdded != 12 || branch.TotalDeleted != 3 {
+			t.Errorf("branch %s: got %d commits, +%d/-%d, want 1 commit, +12/-3", branch.Name, len(branch.Commits), branch.TotalAdded, branch.TotalDeleted)
+		}
+	}
+
+	details := 0
+	for _, path := range transport.requests {
+		if path == "/repos/o/r/commits/c1" {
+			details++
+		}
+	}
+	if details != 1 {
+		t.Errorf("fetched the details of the shared commit %d times, want once", details)
+	}
+}
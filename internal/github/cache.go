@@ -0,0 +1,206 @@
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// commitBySHAPattern matches the path of a single commit addressed by its full SHA.
+// Such responses never change, so they are served from the cache without revalidation.
+var commitBySHAPattern = regexp.MustCompile(`^/(api/v3/)?repos/[^/]+/[^/]+/commits/[0-9a-fA-F]{40}$`)
+
+// Cache is an on-disk cache of GitHub API GET responses. It is an
+// http.RoundTripper placed under the API client: immutable resources are
+// served from disk forever, everything else that carries an ETag or
+// Last-Modified header is revalidated with a conditional request, so
+// unchanged data comes back as 304 Not Modified, which GitHub does not count
+// against the rate limit.
+type Cache struct {
+	dir       string
+	transport http.RoundTripper
+}
+
+// cacheEntry is a stored response
+type cacheEntry struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// NewCache creates a cache storing responses in dir and sending requests
+// through transport (http.DefaultTransport if nil)
+func NewCache(dir string, transport http.RoundTripper) *Cache {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Cache{
+		dir:       dir,
+		transport: transport,
+	}
+}
+
+// DefaultCacheDir returns the default location of the response cache
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "gh-repomon")
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *Cache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return c.transport.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	entry, cached := c.load(key)
+
+	// Commits addressed by SHA never change
+	if cached && isImmutable(req) {
+		return entry.response(req), nil
+	}
+
+	// Ask the server whether the cached copy is still current
+	if cached {
+		conditional := req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			conditional.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			conditional.Header.Set("If-Modified-Since", lastModified)
+		}
+		req = conditional
+	}
+
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+
+		// Keep the fresh rate limit headers of the 304 response
+		for name, values := range resp.Header {
+			entry.Header[name] = values
+		}
+		return entry.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || !isCacheable(req, resp) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// A failed write only costs a future cache miss
+	_ = c.store(key, &cacheEntry{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+	})
+
+	return resp, nil
+}
+
+// load reads a cached entry
+func (c *Cache) load(key string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if entry.Header == nil {
+		entry.Header = http.Header{}
+	}
+
+	return &entry, true
+}
+
+// store writes an entry atomically so concurrent readers never see partial files
+func (c *Cache) store(key string, entry *cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// path returns the file of a cache entry
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, "http", key[:2], key+".json")
+}
+
+// response builds an HTTP response from a cached entry
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheKey identifies a request. The credentials are part of the key so that
+// responses are never shared between accounts with different access.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s", req.Method, req.URL.String(), req.Header.Get("Accept"), req.Header.Get("Authorization"))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isImmutable reports whether the requested resource can never change
+func isImmutable(req *http.Request) bool {
+	return commitBySHAPattern.MatchString(req.URL.Path)
+}
+
+// isCacheable reports whether a response can be stored: either it never
+// changes or it can be revalidated later
+func isCacheable(req *http.Request, resp *http.Response) bool {
+	return isImmutable(req) || resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// countingTransport serves a fixed body and records the requests it receives
+type countingTransport struct {
+	etag     string
+	requests []*http.Request
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+
+	header := http.Header{"Content-Type": []string{"application/json"}, "X-Ratelimit-Remaining": []string{"4999"}}
+	if t.etag != "" {
+		header.Set("ETag", t.etag)
+		if req.Header.Get("If-None-Match") == t.etag {
+			header.Set("X-Ratelimit-Remaining", "4998")
+			return &http.Response{StatusCode: http.StatusNotModified, Header: header, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		}
+	}
+
+	return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(`{"ok":true}`)), Request: req}, nil
+}
+
+func doCached(t *testing.T, cache *Cache, url, token string) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "token "+token)
+
+	resp, err := cache.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp, string(body)
+}
+
+func TestCacheImmutableCommit(t *testing.T) {
+	upstream := &countingTransport{}
+	cache := NewCache(t.TempDir(), upstream)
+	url := "https://api.github.com/repos/o/r/commits/0123456789abcdef0123456789abcdef01234567"
+
+	doCached(t, cache, url, "a")
+	resp, body := doCached(t, cache, url, "a")
+
+	if len(upstream.requests) != 1 {
+		t.Errorf("commit by SHA should be fetched once, got %d requests", len(upstream.requests))
+	}
+	if resp.StatusCode != http.StatusOK || body != `{"ok":true}` {
+		t.Errorf("cached response = %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestCacheConditionalRequest(t *testing.T) {
+	upstream := &countingTransport{etag: `"v1"`}
+	cache := NewCache(t.TempDir(), upstream)
+	url := "https://api.github.com/repos/o/r/pulls?state=open&per_page=100"
+
+	doCached(t, cache, url, "a")
+	resp, body := doCached(t, cache, url, "a")
+
+	if len(upstream.requests) != 2 {
+		t.Fatalf("list endpoint should be revalidated, got %d requests", len(upstream.requests))
+	}
+	if got := upstream.requests[1].Header.Get("If-None-Match"); got != `"v1"` {
+		t.Errorf("If-None-Match = %q, want %q", got, `"v1"`)
+	}
+	if resp.StatusCode != http.StatusOK || body != `{"ok":true}` {
+		t.Errorf("304 should be served from cache, got %d %q", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("X-Ratelimit-Remaining"); got != "4998" {
+		t.Errorf("rate limit header = %q, want the value of the 304 response", got)
+	}
+}
+
+func TestCacheSkipsUnvalidatableResponses(t *testing.T) {
+	upstream := &countingTransport{}
+	cache := NewCache(t.TempDir(), upstream)
+	url := "https://api.github.com/repos/o/r/branches"
+
+	doCached(t, cache, url, "a")
+	doCached(t, cache, url, "a")
+
+	if got := upstream.requests[1].Header.Get("If-None-Match"); got != "" || len(upstream.requests) != 2 {
+		t.Errorf("responses without ETag should not be cached")
+	}
+}
+
+func TestCacheKeyIncludesCredentials(t *testing.T) {
+	upstream := &countingTransport{}
+	cache := NewCache(t.TempDir(), upstream)
+	url := "https://api.github.com/repos/o/r/commits/0123456789abcdef0123456789abcdef01234567"
+
+	doCached(t, cache, url, "a")
+	doCached(t, cache, url, "b")
+
+	if len(upstream.requests) != 2 {
+		t.Errorf("responses must not be shared between tokens, got %d requests", len(upstream.requests))
+	}
+}
@@ -1,99 +1,151 @@
 package github
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/hazadus/gh-repomon/internal/errors"
+	"github.com/hazadus/gh-repomon/internal/utils"
 )
 
 // Client is a GitHub API client wrapper
 type Client struct {
 	client      *api.RESTClient
 	excludeBots bool
+	maxItems    int
+	limiter     *RateLimiter
+
+	truncatedMu sync.Mutex
+	truncated   []string
+}
+
+// ClientOptions configures a GitHub API client
+type ClientOptions struct {
+	// ExcludeBots filters out activity of bot accounts
+	ExcludeBots bool
+	// MaxItems caps the number of items fetched from a single list endpoint (0 = no limit)
+	MaxItems int
+	// CacheDir enables the on-disk response cache in this directory (empty = no cache)
+	CacheDir string
 }
 
 // NewClient creates a new GitHub API client
 func NewClient(excludeBots bool) (*Client, error) {
-	client, err := api.DefaultRESTClient()
+	return NewClientWithOptions(ClientOptions{ExcludeBots: excludeBots})
+}
+
+// NewClientWithOptions creates a new GitHub API client with the given options
+func NewClientWithOptions(opts ClientOptions) (*Client, error) {
+	// Cache hits never reach the rate limiter
+	limiter := NewRateLimiter(nil)
+	restOpts := api.ClientOptions{Transport: limiter}
+	if opts.CacheDir != "" {
+		restOpts.Transport = NewCache(opts.CacheDir, limiter)
+	}
+
+	client, err := api.NewRESTClient(restOpts)
 	if err != nil {
 		return nil, errors.NewGitHubAuthError("failed to create GitHub REST client", err)
 	}
 
 	return &Client{
 		client:      client,
-		excludeBots: excludeBots,
+		excludeBots: opts.ExcludeBots,
+		maxItems:    opts.MaxItems,
+		limiter:     limiter,
 	}, nil
 }
 
+// concurrency returns how many requests may run in parallel, at most max
+func (c *Client) concurrency(max int) int {
+	if c.limiter == nil {
+		return max
+	}
+	return c.limiter.Concurrency(max)
+}
+
 // doWithRetry performs a GET request with retry logic for transient errors
-func (c *Client) doWithRetry(method, path string, body interface{}, response interface{}) error {
+func (c *Client) doWithRetry(ctx context.Context, method, path string, body interface{}, response interface{}) error {
+	_, err := c.requestWithRetry(ctx, method, path, response)
+	return err
+}
+
+// requestWithRetry performs a GET request with retry logic for transient errors,
+// decodes the JSON body into response and returns the response headers
+func (c *Client) requestWithRetry(ctx context.Context, method, path string, response interface{}) (http.Header, error) {
 	maxRetries := 3
 	retryDelay := time.Second
 
 	var lastErr error
 	for i := 0; i < maxRetries; i++ {
 		if i > 0 {
-			time.Sleep(retryDelay)
+			if err := utils.Sleep(ctx, retryDelay); err != nil {
+				return nil, classifyError(path, err)
+			}
 			retryDelay *= 2 // Exponential backoff
 		}
 
 		// We only support GET requests with retry for now
-		var err error
-		if method == "GET" {
-			err = c.client.Get(path, response)
-		} else {
-			return errors.NewGitHubAPIError("unsupported method for retry", 0, nil)
+		if method != "GET" {
+			return nil, errors.NewGitHubAPIError("unsupported method for retry", 0, nil)
 		}
 
+		header, err := c.get(ctx, path, response)
 		if err == nil {
-			return nil
+			return header, nil
 		}
 
 		lastErr = err
 
-		// Check if it's a rate limit error (status 403)
-		if strings.Contains(err.Error(), "403") {
-			return errors.NewGitHubAPIError("rate limit exceeded", http.StatusForbidden, err)
-		}
-
-		// Check if it's a 404 error (not found)
-		if strings.Contains(err.Error(), "404") {
-			return errors.NewGitHubAPIError("resource not found", http.StatusNotFound, err)
-		}
-
 		// Retry on network errors or 5xx errors
 		if isRetryableError(err) {
 			continue
 		}
 
 		// Non-retryable error, return immediately
-		return errors.NewGitHubAPIError("API request failed", 0, err)
+		return nil, classifyError(path, err)
 	}
 
-	return errors.NewGitHubAPIError("API request failed after retries", 0, lastErr)
+	return nil, classifyError(path, lastErr)
 }
 
-// isRetryableError checks if an error is worth retrying
-func isRetryableError(err error) bool {
-	if err == nil {
-		return false
+// get performs a single GET request and decodes the JSON body into response
+func (c *Client) get(ctx context.Context, path string, response interface{}) (http.Header, error) {
+	resp, err := c.client.RequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	errStr := err.Error()
-	// Network errors and 5xx errors are retryable
-	return strings.Contains(errStr, "timeout") ||
-		strings.Contains(errStr, "connection") ||
-		strings.Contains(errStr, "500") ||
-		strings.Contains(errStr, "502") ||
-		strings.Contains(errStr, "503") ||
-		strings.Contains(errStr, "504")
+	if resp.StatusCode == http.StatusNoContent {
+		return resp.Header, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, err
+	}
+
+	return resp.Header, nil
 }
 
 // isBot checks if a login belongs to a bot account
 func (c *Client) isBot(login string) bool {
+	return IsBot(login)
+}
+
+// IsBot checks if a login belongs to a bot account
+func IsBot(login string) bool {
 	// Check if login ends with [bot]
 	if strings.HasSuffix(login, "[bot]") {
 		return true
@@ -1,7 +1,17 @@
 package github
 
 import (
+	"context"
+	stderrors "errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
 	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/hazadus/gh-repomon/internal/errors"
+	"github.com/hazadus/gh-repomon/internal/types"
 )
 
 func TestIsBot(t *testing.T) {
@@ -74,77 +84,82 @@ func TestIsBot(t *testing.T) {
 
 func TestIsRetryableError(t *testing.T) {
 	tests := []struct {
-		name   string
-		errMsg string
-		want   bool
+		name string
+		err  error
+		want bool
 	}{
 		{
-			name:   "Nil error",
-			errMsg: "",
-			want:   false,
+			name: "Nil error",
+			err:  nil,
+			want: false,
+		},
+		{
+			name: "Timeout error",
+			err:  &url.Error{Op: "Get", URL: "https://api.github.com", Err: &timeoutError{}},
+			want: true,
 		},
 		{
-			name:   "Timeout error",
-			errMsg: "request timeout",
-			want:   true,
+			name: "Connection error",
+			err:  &url.Error{Op: "Get", URL: "https://api.github.com", Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}},
+			want: true,
 		},
 		{
-			name:   "Connection error",
-			errMsg: "connection refused",
-			want:   true,
+			name: "500 Internal Server Error",
+			err:  &api.HTTPError{StatusCode: http.StatusInternalServerError},
+			want: true,
 		},
 		{
-			name:   "500 Internal Server Error",
-			errMsg: "HTTP 500",
-			want:   true,
+			name: "502 Bad Gateway",
+			err:  &api.HTTPError{StatusCode: http.StatusBadGateway},
+			want: true,
 		},
 		{
-			name:   "502 Bad Gateway",
-			errMsg: "HTTP 502",
-			want:   true,
+			name: "503 Service Unavailable",
+			err:  &api.HTTPError{StatusCode: http.StatusServiceUnavailable},
+			want: true,
 		},
 		{
-			name:   "503 Service Unavailable",
-			errMsg: "HTTP 503",
-			want:   true,
+			name: "504 Gateway Timeout",
+			err:  &api.HTTPError{StatusCode: http.StatusGatewayTimeout},
+			want: true,
 		},
 		{
-			name:   "504 Gateway Timeout",
-			errMsg: "HTTP 504",
-			want:   true,
+			name: "404 Not Found",
+			err:  &api.HTTPError{StatusCode: http.StatusNotFound},
+			want: false,
 		},
 		{
-			name:   "404 Not Found",
-			errMsg: "HTTP 404",
-			want:   false,
+			name: "403 Forbidden",
+			err:  &api.HTTPError{StatusCode: http.StatusForbidden},
+			want: false,
 		},
 		{
-			name:   "403 Forbidden",
-			errMsg: "HTTP 403",
-			want:   false,
+			name: "401 Unauthorized",
+			err:  &api.HTTPError{StatusCode: http.StatusUnauthorized},
+			want: false,
 		},
 		{
-			name:   "401 Unauthorized",
-			errMsg: "HTTP 401",
-			want:   false,
+			name: "Status code only in message",
+			err:  &mockError{msg: "pull request #500 is invalid"},
+			want: false,
 		},
 		{
-			name:   "Other error",
-			errMsg: "something went wrong",
-			want:   false,
+			name: "Canceled request",
+			err:  &url.Error{Op: "Get", URL: "https://api.github.com", Err: context.Canceled},
+			want: false,
+		},
+		{
+			name: "Other error",
+			err:  &mockError{msg: "something went wrong"},
+			want: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var err error
-			if tt.errMsg != "" {
-				err = &mockError{msg: tt.errMsg}
-			}
-
-			got := isRetryableError(err)
+			got := isRetryableError(tt.err)
 			if got != tt.want {
-				t.Errorf("isRetryableError(%v) = %v, want %v", tt.errMsg, got, tt.want)
+				t.Errorf("isRetryableError(%v) = %v, want %v", tt.err, got, tt.want)
 			}
 		})
 	}
@@ -158,3 +173,289 @@ type mockError struct {
 func (e *mockError) Error() string {
 	return e.msg
 }
+
+// timeoutError is a network error reporting a timeout
+type timeoutError struct{}
+
+func (e *timeoutError) Error() string   { return "i/o timeout" }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+
+func TestClassifyError(t *testing.T) {
+	rateLimited := http.Header{"X-Ratelimit-Remaining": []string{"0"}, "X-Ratelimit-Reset": []string{"1704110400"}}
+
+	tests := []struct {
+		name  string
+		path  string
+		err   error
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "Unauthorized",
+			path: "repos/o/r/branches",
+			err:  &api.HTTPError{StatusCode: http.StatusUnauthorized},
+			check: func(t *testing.T, err error) {
+				var target *errors.ErrGitHubAuth
+				if !stderrors.As(err, &target) {
+					t.Errorf("got %T, want ErrGitHubAuth", err)
+				}
+			},
+		},
+		{
+			name: "Primary rate limit",
+			path: "repos/o/r/commits",
+			err:  &api.HTTPError{StatusCode: http.StatusForbidden, Headers: rateLimited},
+			check: func(t *testing.T, err error) {
+				var target *errors.ErrRateLimited
+				if !stderrors.As(err, &target) {
+					t.Fatalf("got %T, want ErrRateLimited", err)
+				}
+				if target.ResetAt.Unix() != 1704110400 {
+					t.Errorf("ResetAt = %v, want reset from header", target.ResetAt)
+				}
+			},
+		},
+		{
+			name: "Permission denied",
+			path: "repos/o/r/pulls/1/reviews",
+			err:  &api.HTTPError{StatusCode: http.StatusForbidden, Message: "Resource not accessible by integration"},
+			check: func(t *testing.T, err error) {
+				var target *errors.ErrPermissionDenied
+				if !stderrors.As(err, &target) {
+					t.Fatalf("got %T, want ErrPermissionDenied", err)
+				}
+				if target.Resource != "pulls/1/reviews in o/r" {
+					t.Errorf("Resource = %q", target.Resource)
+				}
+			},
+		},
+		{
+			name: "Repository not found",
+			path: "https://api.github.com/repos/o/missing/branches?per_page=100&page=2",
+			err:  &api.HTTPError{StatusCode: http.StatusNotFound},
+			check: func(t *testing.T, err error) {
+				var target *errors.ErrRepoNotFound
+				if !stderrors.As(err, &target) {
+					t.Fatalf("got %T, want ErrRepoNotFound", err)
+				}
+				if target.Repository != "o/missing" {
+					t.Errorf("Repository = %q, want o/missing", target.Repository)
+				}
+			},
+		},
+		{
+			name: "Missing commit is not a missing repository",
+			path: "repos/o/r/commits/abc",
+			err:  &api.HTTPError{StatusCode: http.StatusNotFound},
+			check: func(t *testing.T, err error) {
+				var target *errors.ErrGitHubAPI
+				if !stderrors.As(err, &target) || target.StatusCode != http.StatusNotFound {
+					t.Errorf("got %v, want ErrGitHubAPI with status 404", err)
+				}
+			},
+		},
+		{
+			name: "Server error",
+			path: "repos/o/r/issues",
+			err:  &api.HTTPError{StatusCode: http.StatusBadGateway},
+			check: func(t *testing.T, err error) {
+				var target *errors.ErrGitHubServer
+				if !stderrors.As(err, &target) || target.StatusCode != http.StatusBadGateway {
+					t.Errorf("got %v, want ErrGitHubServer with status 502", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyError(tt.path, tt.err)
+			tt.check(t, err)
+
+			// The original HTTP error must stay reachable
+			var httpErr *api.HTTPError
+			if _, ok := err.(*errors.ErrRepoNotFound); !ok && !stderrors.As(err, &httpErr) {
+				t.Errorf("classified error should wrap the HTTP error")
+			}
+		})
+	}
+}
+
+func TestParseReview(t *testing.T) {
+	client := &Client{}
+
+	review := Review{State: "APPROVED", SubmittedAt: "2024-01-15T14:30:00Z", HTMLURL: "https://github.com/owner/repo/pull/1#pullrequestreview-1"}
+	review.User.Login = "octocat"
+
+	got := client.parseReview(review)
+
+	if got.Author.Login != "octocat" {
+		t.Errorf("parseReview() Login = %v, want octocat", got.Author.Login)
+	}
+	if got.Author.ProfileURL != "https://github.com/octocat" {
+		t.Errorf("parseReview() ProfileURL = %v, want https://github.com/octocat", got.Author.ProfileURL)
+	}
+	if got.State != "APPROVED" {
+		t.Errorf("parseReview() State = %v, want APPROVED", got.State)
+	}
+	if got.SubmittedAt.IsZero() || got.SubmittedAt.Hour() != 14 {
+		t.Errorf("parseReview() SubmittedAt = %v, want 2024-01-15 14:30", got.SubmittedAt)
+	}
+	if got.URL != review.HTMLURL {
+		t.Errorf("parseReview() URL = %v, want %v", got.URL, review.HTMLURL)
+	}
+}
+
+func TestParsePullRequest(t *testing.T) {
+	client := &Client{}
+
+	tests := []struct {
+		name  string
+		data  map[string]interface{}
+		check func(t *testing.T, pr types.PullRequest)
+	}{
+		{
+			name: "merged",
+			data: map[string]interface{}{
+				"number":    float64(7),
+				"state":     "closed",
+				"closed_at": "2024-01-05T10:00:00Z",
+				"merged_at": "2024-01-05T10:00:00Z",
+				"merged_by": map[string]interface{}{"login": "carol", "html_url": "https://github.com/carol"},
+				"base":      map[string]interface{}{"ref": "main"},
+				"head":      map[string]interface{}{"ref": "feature/login", "repo": map[string]interface{}{"full_name": "fork/repo"}},
+				"labels":    []interface{}{map[string]interface{}{"name": "bug"}, map[string]interface{}{"name": "ui"}},
+			},
+			check: func(t *testing.T, pr types.PullRequest) {
+				if pr.State != types.PRStateMerged || !pr.IsMerged() {
+					t.Errorf("State = %q, want merged", pr.State)
+				}
+				if pr.MergedBy == nil || pr.MergedBy.Login != "carol" {
+					t.Errorf("MergedBy = %+v, want carol", pr.MergedBy)
+				}
+				if pr.ClosedAt == nil || pr.ClosedAt.Day() != 5 {
+					t.Errorf("ClosedAt = %v, want 2024-01-05", pr.ClosedAt)
+				}
+				if pr.BaseRef != "main" || pr.HeadRef != "feature/login" {
+					t.Errorf("refs = %q <- %q, want main <- feature/login", pr.BaseRef, pr.HeadRef)
+				}
+				if pr.HeadRepo != "fork/repo" {
+					t.Errorf("HeadRepo = %q, want fork/repo", pr.HeadRepo)
+				}
+				if len(pr.Labels) != 2 || pr.Labels[0] != "bug" {
+					t.Errorf("Labels = %v, want [bug ui]", pr.Labels)
+				}
+			},
+		},
+		{
+			name: "closed without merge",
+			data: map[string]interface{}{
+				"number":    float64(8),
+				"state":     "closed",
+				"closed_at": "2024-01-06T10:00:00Z",
+				"merged_at": nil,
+			},
+			check: func(t *testing.T, pr types.PullRequest) {
+				if pr.State != types.PRStateClosed || pr.IsMerged() {
+					t.Errorf("State = %q, merged = %v, want closed and not merged", pr.State, pr.IsMerged())
+				}
+				if pr.ClosedAt == nil {
+					t.Error("ClosedAt = nil, want 2024-01-06")
+				}
+			},
+		},
+		{
+			name: "open draft",
+			data: map[string]interface{}{
+				"number": float64(9),
+				"state":  "open",
+				"draft":  true,
+			},
+			check: func(t *testing.T, pr types.PullRequest) {
+				if pr.State != types.PRStateOpen || !pr.Draft {
+					t.Errorf("State = %q, Draft = %v, want open draft", pr.State, pr.Draft)
+				}
+				if pr.ClosedAt != nil || pr.MergedAt != nil || pr.MergedBy != nil {
+					t.Errorf("open PR has close metadata: %+v", pr)
+				}
+				if pr.HasConflicts {
+					t.Error("HasConflicts = true, want false without mergeable_state")
+				}
+			},
+		},
+		{
+			name: "open with conflicts",
+			data: map[string]interface{}{
+				"number":          float64(10),
+				"state":           "open",
+				"mergeable_state": "dirty",
+			},
+			check: func(t *testing.T, pr types.PullRequest) {
+				if !pr.HasConflicts {
+					t.Error("HasConflicts = false, want true for mergeable_state dirty")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr, err := client.parsePullRequest(tt.data)
+			if err != nil {
+				t.Fatalf("parsePullRequest() error = %v", err)
+			}
+			tt.check(t, pr)
+		})
+	}
+}
+
+func TestGetConflictingPullRequests(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/pulls": `[
+			{"number":1,"state":"open","user":{"login":"alice"}}
+		]`,
+		"/repos/o/r/pulls/1": `{"number":1,"state":"open","mergeable":false,"mergeable_state":"dirty"}`,
+		"/repos/o/r/pulls/2": `{"number":2,"state":"open","mergeable":true,"mergeable_state":"clean"}`,
+	}}
+	c := newTestClient(t, transport, 0)
+
+	// Listing open pull requests does not fetch each one
+	if _, err := c.GetOpenPullRequests(context.Background(), "o/r"); err != nil {
+		t.Fatalf("GetOpenPullRequests() error = %v", err)
+	}
+	if len(transport.requests) != 1 {
+		t.Errorf("made %d requests to list open pull requests, want 1", len(transport.requests))
+	}
+
+	// Pull request 3 cannot be fetched and is reported without conflicts
+	got, err := c.GetConflictingPullRequests(context.Background(), "o/r", []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("GetConflictingPullRequests() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("got conflicting pull requests %v, want [1]", got)
+	}
+}
+
+func TestGetMergedPullRequests(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/pulls": `[
+			{"number":1,"state":"closed","merged_at":"2024-06-01T09:00:00Z","head":{"ref":"feature/x","repo":{"full_name":"o/r"}}},
+			{"number":2,"state":"closed","merged_at":null,"head":{"ref":"feature/x","repo":{"full_name":"o/r"}}}
+		]`,
+	}}
+	c := newTestClient(t, transport, 0)
+
+	prs, err := c.GetMergedPullRequests(context.Background(), "o/r", "feature/x")
+	if err != nil {
+		t.Fatalf("GetMergedPullRequests() error = %v", err)
+	}
+
+	// Pull requests are looked up by branch, whenever they were merged
+	if len(transport.heads) != 1 || transport.heads[0] != "o:feature/x" {
+		t.Errorf("got head filters %v, want [o:feature/x]", transport.heads)
+	}
+	if len(prs) != 1 || prs[0].Number != 1 || prs[0].State != types.PRStateMerged {
+		t.Errorf("got pull requests %+v, want only the merged #1", prs)
+	}
+}
@@ -1,11 +1,14 @@
 package github
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"sync"
 	"time"
 
 	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
 )
 
 // commitResponse represents the GitHub API response for a commit
@@ -29,10 +32,33 @@ type commitResponse struct {
 		Additions int `json:"additions"`
 		Deletions int `json:"deletions"`
 	} `json:"stats"`
+	// Files is only present in single commit responses
+	Files []struct {
+		Filename  string `json:"filename"`
+		Additions int    `json:"additions"`
+		Deletions int    `json:"deletions"`
+	} `json:"files"`
 }
 
 // GetCommits retrieves commits from a repository for the specified period
-func (c *Client) GetCommits(repo, branch string, from, to time.Time) ([]types.Commit, error) {
+func (c *Client) GetCommits(ctx context.Context, repo, branch string, from, to time.Time) ([]types.Commit, error) {
+	listed, err := c.listCommits(ctx, repo, branch, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make(map[string]commitResponse, len(listed))
+	c.fillCommitDetails(ctx, repo, listed, details)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	return c.withDetails(listed, details), nil
+}
+
+// listCommits lists the commits of a branch during the period without their
+// line statistics, leaving out bots if requested
+func (c *Client) listCommits(ctx context.Context, repo, branch string, from, to time.Time) ([]commitResponse, error) {
 	// Build API path with query parameters
 	path := fmt.Sprintf("repos/%s/commits?since=%s&until=%s",
 		repo,
@@ -40,102 +66,134 @@ func (c *Client) GetCommits(repo, branch string, from, to time.Time) ([]types.Co
 		to.Format(time.RFC3339))
 
 	// Add branch parameter if specified
+	resource := fmt.Sprintf("commits of %s", repo)
 	if branch != "" {
-		path += fmt.Sprintf("&sha=%s", branch)
+		path += fmt.Sprintf("&sha=%s", url.QueryEscape(branch))
+		resource = fmt.Sprintf("commits of %s on branch %s", repo, branch)
 	}
 
-	// Make API request with retry
+	// Fetch all pages of the commit listing
 	var response []commitResponse
-	err := c.doWithRetry("GET", path, nil, &response)
+	err := paginate(ctx, c, resource, path, func(page []commitResponse) bool {
+		response = append(response, page...)
+		return true
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get commits: %w", err)
 	}
 
-	// Convert to types.Commit with parallel stats fetching
-	commits := make([]types.Commit, 0, len(response))
-	commitsMutex := sync.Mutex{}
+	listed := make([]commitResponse, 0, len(response))
+	for _, cr := range response {
+		if c.excludeBots && cr.Author.Login != "" && c.isBot(cr.Author.Login) {
+			continue
+		}
+		listed = append(listed, cr)
+	}
+
+	return listed, nil
+}
 
-	// Worker pool for fetching commit stats
-	maxWorkers := 10
-	if len(response) < maxWorkers {
-		maxWorkers = len(response)
+// fillCommitDetails fetches the line statistics and changed files of the
+// listed commits that are not in details yet, once per SHA, and adds them to
+// details. Commits whose details cannot be fetched are left out.
+func (c *Client) fillCommitDetails(ctx context.Context, repo string, listed []commitResponse, details map[string]commitResponse) {
+	var shas []string
+	queued := make(map[string]bool)
+	for _, cr := range listed {
+		if _, ok := details[cr.SHA]; !ok && !queued[cr.SHA] {
+			queued[cr.SHA] = true
+			shas = append(shas, cr.SHA)
+		}
 	}
 
-	commitChan := make(chan commitResponse, len(response))
-	var wg sync.WaitGroup
-
-	// Start workers
-	for i := 0; i < maxWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for cr := range commitChan {
-				// Check if we should filter out bots
-				if c.excludeBots && cr.Author.Login != "" && c.isBot(cr.Author.Login) {
-					continue
-				}
-
-				// Get detailed commit stats
-				additions, deletions, err := c.GetCommitStats(repo, cr.SHA)
-				if err != nil {
-					// If we can't get stats, use zeros but don't fail
-					additions = 0
-					deletions = 0
-				}
-
-				// Create Author
-				author := types.Author{
-					Login:      cr.Author.Login,
-					Name:       cr.Commit.Author.Name,
-					ProfileURL: cr.Author.HTMLURL,
-					IsBot:      c.isBot(cr.Author.Login),
-				}
-
-				// If author.Login is empty (deleted user), use name
-				if author.Login == "" {
-					author.Login = cr.Commit.Author.Name
-				}
-
-				commit := types.Commit{
-					SHA:       cr.SHA,
-					Message:   cr.Commit.Message,
-					Author:    author,
-					Date:      cr.Commit.Author.Date,
-					Additions: additions,
-					Deletions: deletions,
-					URL:       cr.HTMLURL,
-				}
-
-				commitsMutex.Lock()
-				commits = append(commits, commit)
-				commitsMutex.Unlock()
-			}
-		}()
+	// Worker pool for fetching commit stats, throttled when the rate limit runs low
+	var mu sync.Mutex
+	_ = utils.ProcessInParallelWithContext(ctx, shas, c.concurrency(10), func(ctx context.Context, sha string) error {
+		cr, err := c.getCommit(ctx, repo, sha)
+		if err != nil {
+			return nil
+		}
+		mu.Lock()
+		details[sha] = cr
+		mu.Unlock()
+		return nil
+	})
+}
+
+// withDetails converts listed commits, adding the line statistics and changed
+// files from details; commits without details keep zeros
+func (c *Client) withDetails(listed []commitResponse, details map[string]commitResponse) []types.Commit {
+	commits := make([]types.Commit, 0, len(listed))
+	for _, cr := range listed {
+		commit := c.toCommit(cr)
+		if detail, ok := details[cr.SHA]; ok {
+			commit.Additions = detail.Stats.Additions
+			commit.Deletions = detail.Stats.Deletions
+			commit.Files = toFileChanges(detail)
+		}
+		commits = append(commits, commit)
 	}
+	return commits
+}
 
-	// Send commits to workers
-	for _, cr := range response {
-		commitChan <- cr
+// toCommit converts a commit API response to types.Commit without line stats
+func (c *Client) toCommit(cr commitResponse) types.Commit {
+	author := types.Author{
+		Login:      cr.Author.Login,
+		Name:       cr.Commit.Author.Name,
+		ProfileURL: cr.Author.HTMLURL,
+		IsBot:      c.isBot(cr.Author.Login),
 	}
-	close(commitChan)
 
-	// Wait for all workers to finish
-	wg.Wait()
+	// If author.Login is empty (deleted user), use name
+	if author.Login == "" {
+		author.Login = cr.Commit.Author.Name
+	}
 
-	return commits, nil
+	return types.Commit{
+		SHA:     cr.SHA,
+		Message: cr.Commit.Message,
+		Author:  author,
+		Date:    cr.Commit.Author.Date,
+		URL:     cr.HTMLURL,
+	}
 }
 
 // GetCommitStats retrieves detailed statistics for a specific commit
-func (c *Client) GetCommitStats(repo, sha string) (additions, deletions int, err error) {
+func (c *Client) GetCommitStats(ctx context.Context, repo, sha string) (additions, deletions int, err error) {
+	response, err := c.getCommit(ctx, repo, sha)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get commit stats: %w", err)
+	}
+
+	return response.Stats.Additions, response.Stats.Deletions, nil
+}
+
+// getCommit retrieves a single commit with its line statistics and changed files
+func (c *Client) getCommit(ctx context.Context, repo, sha string) (commitResponse, error) {
 	// Build API path
 	path := fmt.Sprintf("repos/%s/commits/%s", repo, sha)
 
 	// Make API request with retry
 	var response commitResponse
-	err = c.doWithRetry("GET", path, nil, &response)
-	if err != nil {
-		return 0, 0, fmt.Errorf("failed to get commit stats: %w", err)
+	err := c.doWithRetry(ctx, "GET", path, nil, &response)
+	return response, err
+}
+
+// toFileChanges converts the changed files of a single commit response.
+// GitHub lists at most 300 files on the first page; the rest are not counted.
+func toFileChanges(cr commitResponse) []types.FileChange {
+	if len(cr.Files) == 0 {
+		return nil
 	}
 
-	return response.Stats.Additions, response.Stats.Deletions, nil
+	files := make([]types.FileChange, len(cr.Files))
+	for i, f := range cr.Files {
+		files[i] = types.FileChange{
+			Path:      f.Filename,
+			Additions: f.Additions,
+			Deletions: f.Deletions,
+		}
+	}
+	return files
 }
@@ -0,0 +1,38 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetCommitsFileChanges(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/commits": `[
+			{"sha":"c1","commit":{"author":{"name":"Alice","date":"2025-01-08T09:00:00Z"},"message":"Add search"},"author":{"login":"alice"}}
+		]`,
+		"/repos/o/r/commits/c1": `{"sha":"c1","stats":{"additions":12,"deletions":3},"files":[
+			{"filename":"internal/search/index.go","additions":10,"deletions":1},
+			{"filename":"README.md","additions":2,"deletions":2}
+		]}`,
+	}}
+	c := newTestClient(t, transport, 0)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	commits, err := c.GetCommits(context.Background(), "o/r", "main", from, to)
+	if err != nil {
+		t.Fatalf("GetCommits() error = %v", err)
+	}
+
+	if len(commits) != 1 {
+		t.Fatalf("got %d commits, want 1", len(commits))
+	}
+	commit := commits[0]
+	if commit.Additions != 12 || commit.Deletions != 3 {
+		t.Errorf("got +%d/-%d, want +12/-3", commit.Additions, commit.Deletions)
+	}
+	if len(commit.Files) != 2 || commit.Files[0].Path != "internal/search/index.go" || commit.Files[0].Additions != 10 || commit.Files[1].Deletions != 2 {
+		t.Errorf("got files %+v, want index.go +10/-1 and README.md +2/-2", commit.Files)
+	}
+}
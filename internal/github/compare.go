@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// compareCommitLimit caps the number of commits listed by a single comparison
+const compareCommitLimit = 1000
+
+// compareResponse represents the GitHub API response for a commit comparison
+type compareResponse struct {
+	// Status is how head relates to base: ahead, behind, diverged or identical
+	Status   string           `json:"status"`
+	AheadBy  int              `json:"ahead_by"`
+	BehindBy int              `json:"behind_by"`
+	Commits  []commitResponse `json:"commits"`
+}
+
+// comparison is the result of comparing two commits
+type comparison struct {
+	// Status is how head relates to base: ahead, behind, diverged or identical
+	Status string
+	// AheadBy is the number of commits reachable from head but not from base
+	AheadBy int
+	// BehindBy is the number of commits reachable from base but not from head
+	BehindBy int
+	// Commits lists the commits reachable from head but not from base, oldest first
+	Commits []commitResponse
+	// Truncated is true when Commits stops at compareCommitLimit
+	Truncated bool
+}
+
+// compare compares head with base. With listCommits the commits reachable
+// from head but not from base are paginated up to compareCommitLimit;
+// otherwise a single one-commit page is requested, which is enough for the
+// status and the ahead and behind counts. The item cap does not apply:
+// callers rely on the commit list being complete unless Truncated is set.
+func (c *Client) compare(ctx context.Context, repo, base, head string, listCommits bool) (*comparison, error) {
+	path := fmt.Sprintf("repos/%s/compare/%s...%s", repo, url.PathEscape(base), url.PathEscape(head))
+
+	if !listCommits {
+		var response compareResponse
+		if err := c.doWithRetry(ctx, "GET", path+"?per_page=1", nil, &response); err != nil {
+			return nil, err
+		}
+		return &comparison{Status: response.Status, AheadBy: response.AheadBy, BehindBy: response.BehindBy}, nil
+	}
+
+	result := &comparison{}
+	next := withPerPage(path)
+	for next != "" {
+		var response compareResponse
+		header, err := c.requestWithRetry(ctx, "GET", next, &response)
+		if err != nil {
+			return nil, err
+		}
+		next = parseNextLink(header.Get("Link"))
+
+		result.Status = response.Status
+		result.AheadBy = response.AheadBy
+		result.BehindBy = response.BehindBy
+		result.Commits = append(result.Commits, response.Commits...)
+
+		if len(result.Commits) >= compareCommitLimit {
+			result.Truncated = len(result.Commits) > compareCommitLimit || next != ""
+			result.Commits = result.Commits[:compareCommitLimit]
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// toCommits converts compared commits, leaving out bots if requested
+func (c *Client) toCommits(responses []commitResponse) []types.Commit {
+	var commits []types.Commit
+	for _, cr := range responses {
+		if c.excludeBots && cr.Author.Login != "" && c.isBot(cr.Author.Login) {
+			continue
+		}
+		commits = append(commits, c.toCommit(cr))
+	}
+	return commits
+}
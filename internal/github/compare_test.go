@@ -0,0 +1,94 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// compareTransport serves a comparison of a branch that is ahead by total
+// commits, split into pages
+type compareTransport struct {
+	total    int
+	requests []string
+}
+
+func (t *compareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req.URL.RawQuery)
+
+	query := req.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page == 0 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("per_page"))
+
+	var commits []string
+	for i := (page-1)*perPage + 1; i <= page*perPage && i <= t.total; i++ {
+		commits = append(commits, fmt.Sprintf(`{"sha":"c%d"}`, i))
+	}
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if page*perPage < t.total {
+		next := *req.URL
+		query.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+		header.Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+
+	body := fmt.Sprintf(`{"status":"diverged","ahead_by":%d,"behind_by":3,"commits":[%s]}`, t.total, strings.Join(commits, ","))
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name          string
+		total         int
+		listCommits   bool
+		wantCommits   int
+		wantRequests  int
+		wantTruncated bool
+	}{
+		{name: "Counts only", total: 250, wantRequests: 1},
+		{name: "All commits", total: 250, listCommits: true, wantCommits: 250, wantRequests: 3},
+		{name: "Commit limit", total: 1200, listCommits: true, wantCommits: compareCommitLimit, wantRequests: 10, wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &compareTransport{total: tt.total}
+			// The item cap does not apply to comparisons
+			c := newTestClient(t, transport, 50)
+
+			got, err := c.compare(context.Background(), "o/r", "main", "feature", tt.listCommits)
+			if err != nil {
+				t.Fatalf("compare() error = %v", err)
+			}
+
+			if got.Status != "diverged" || got.AheadBy != tt.total || got.BehindBy != 3 {
+				t.Errorf("got %s, %d ahead, %d behind, want diverged, %d ahead, 3 behind", got.Status, got.AheadBy, got.BehindBy, tt.total)
+			}
+			if len(got.Commits) != tt.wantCommits {
+				t.Errorf("got %d commits, want %d", len(got.Commits), tt.wantCommits)
+			}
+			if len(transport.requests) != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", len(transport.requests), tt.wantRequests)
+			}
+			if got.Truncated != tt.wantTruncated {
+				t.Errorf("truncated = %v, want %v", got.Truncated, tt.wantTruncated)
+			}
+			if !tt.listCommits && transport.requests[0] != "per_page=1" {
+				t.Errorf("requested %q, want a single one-commit page", transport.requests[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
+)
+
+// deploymentLookupLimit caps the number of deployments before the period that
+// are inspected to find the preceding deployment of each environment
+const deploymentLookupLimit = 100
+
+// deploymentResponse represents the GitHub API response for a deployment
+type deploymentResponse struct {
+	ID          int64     `json:"id"`
+	Environment string    `json:"environment"`
+	Production  bool      `json:"production_environment"`
+	Ref         string    `json:"ref"`
+	SHA         string    `json:"sha"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	Creator     struct {
+		Login   string `json:"login"`
+		HTMLURL string `json:"html_url"`
+	} `json:"creator"`
+}
+
+// deploymentStatusResponse represents the GitHub API response for a deployment status
+type deploymentStatusResponse struct {
+	State          string    `json:"state"`
+	CreatedAt      time.Time `json:"created_at"`
+	LogURL         string    `json:"log_url"`
+	TargetURL      string    `json:"target_url"`
+	EnvironmentURL string    `json:"environment_url"`
+}
+
+// GetDeployments retrieves the deployments created during the period, newest
+// first. Each one carries its latest status and the commits included since
+// the preceding successful deployment to the same environment; a deployment
+// of an older commit than the preceding one is marked as rolled back.
+func (c *Client) GetDeployments(ctx context.Context, repo string, from, to time.Time) ([]types.Deployment, error) {
+	deployments, err := c.listDeployments(ctx, repo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deployments before the period already got their state while listing
+	c.fillDeploymentStates(ctx, repo, deployments, from)
+
+	// Deployments are newest first, so the preceding deployments of each one follow it
+	var result []types.Deployment
+	var previous []int
+	for i, deployment := range deployments {
+		if deployment.CreatedAt.Before(from) {
+			break
+		}
+		result = append(result, deployment)
+
+		p := -1
+		for j := i + 1; j < len(deployments); j++ {
+			if deployments[j].Environment == deployment.Environment && !deployments[j].IsFailed() {
+				p = j
+				break
+			}
+		}
+		previous = append(previous, p)
+	}
+
+	// Each worker writes only its own deployment. Deployments whose commits
+	// cannot be compared, e.g. after a force push, are left without commits.
+	indexes := make([]int, len(result))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, c.concurrency(5), func(ctx context.Context, i int) error {
+		if previous[i] < 0 {
+			return nil
+		}
+		deployment := &result[i]
+		deployment.PreviousSHA = deployments[previous[i]].SHA
+
+		compared, err := c.compare(ctx, repo, deployment.PreviousSHA, deployment.SHA, true)
+		if err != nil {
+			return nil
+		}
+		if compared.Status == "behind" {
+			deployment.RolledBack = true
+			return nil
+		}
+		deployment.Commits = c.toCommits(compared.Commits)
+		return nil
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	return result, nil
+}
+
+// listDeployments lists the deployments created up to the end of the period,
+// followed by the deployments before the period of each environment deployed
+// to during the period, down to the first one that did not fail. The states of
+// those older deployments are filled in while listing.
+func (c *Client) listDeployments(ctx context.Context, repo string, from, to time.Time) ([]types.Deployment, error) {
+	path := fmt.Sprintf("repos/%s/deployments", repo)
+
+	// Deployments are listed newest first
+	var deployments []types.Deployment
+	environments := make(map[string]bool)
+	preceded := make(map[string]bool)
+	older := 0
+	err := paginate(ctx, c, fmt.Sprintf("deployments of %s", repo), path, func(page []deploymentResponse) bool {
+		for _, d := range page {
+			if d.CreatedAt.After(to) {
+				continue
+			}
+
+			deployment := types.Deployment{
+				ID:          d.ID,
+				Environment: d.Environment,
+				Production:  d.Production || strings.EqualFold(d.Environment, "production"),
+				Ref:         d.Ref,
+				SHA:         d.SHA,
+				Description: d.Description,
+				Creator:     types.Author{Login: d.Creator.Login, ProfileURL: d.Creator.HTMLURL, IsBot: c.isBot(d.Creator.Login)},
+				CreatedAt:   d.CreatedAt,
+			}
+
+			if d.CreatedAt.Before(from) {
+				if older >= deploymentLookupLimit {
+					return false
+				}
+				older++
+				if !environments[d.Environment] || preceded[d.Environment] {
+					continue
+				}
+				// Failed deployments don't count as the preceding one; keep looking
+				c.fillDeploymentState(ctx, repo, &deployment)
+				if !deployment.IsFailed() {
+					preceded[d.Environment] = true
+				}
+			} else {
+				environments[d.Environment] = true
+			}
+
+			deployments = append(deployments, deployment)
+		}
+		return older == 0 || (len(preceded) < len(environments) && older < deploymentLookupLimit)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployments: %w", err)
+	}
+
+	return deployments, nil
+}
+
+// fillDeploymentStates fills in the latest status of each deployment created
+// since from. Deployments whose statuses cannot be fetched are left without a state.
+func (c *Client) fillDeploymentStates(ctx context.Context, repo string, deployments []types.Deployment, from time.Time) {
+	var indexes []int
+	for i, deployment := range deployments {
+		if !deployment.CreatedAt.Before(from) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	// Each worker writes only its own deployment
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, c.concurrency(5), func(ctx context.Context, i int) error {
+		c.fillDeploymentState(ctx, repo, &deployments[i])
+		return nil
+	})
+}
+
+// fillDeploymentState fills in the latest status of a deployment, if it has one
+func (c *Client) fillDeploymentState(ctx context.Context, repo string, deployment *types.Deployment) {
+	// Statuses are listed newest first
+	path := fmt.Sprintf("repos/%s/deployments/%d/statuses?per_page=1", repo, deployment.ID)
+
+	var statuses []deploymentStatusResponse
+	if err := c.doWithRetry(ctx, "GET", path, nil, &statuses); err != nil || len(statuses) == 0 {
+		return
+	}
+
+	status := statuses[0]
+	deployment.State = status.State
+	deployment.StateAt = &status.CreatedAt
+	for _, url := range []string{status.LogURL, status.TargetURL, status.EnvironmentURL} {
+		if url != "" {
+			deployment.URL = url
+			break
+		}
+	}
+}
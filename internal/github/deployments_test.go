@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetDeployments(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/deployments": `[
+			{"id":5,"environment":"production","ref":"v1.1.0","sha":"aaa","created_at":"2025-01-12T12:00:00Z",
+				"creator":{"login":"alice","html_url":"https://github.com/alice"}},
+			{"id":4,"environment":"production","ref":"v1.2.0","sha":"ccc","created_at":"2025-01-11T12:00:00Z",
+				"creator":{"login":"alice","html_url":"https://github.com/alice"}},
+			{"id":3,"environment":"staging","ref":"main","sha":"ccc","created_at":"2025-01-10T12:00:00Z",
+				"creator":{"login":"github-actions[bot]","html_url":"https://github.com/apps/github-actions"}},
+			{"id":2,"environment":"production","ref":"v1.1.0","sha":"aaa","created_at":"2024-12-20T12:00:00Z",
+				"creator":{"login":"alice","html_url":"https://github.com/alice"}},
+			{"id":1,"environment":"production","ref":"v1.0.0","sha":"000","created_at":"2024-12-01T12:00:00Z",
+				"creator":{"login":"alice","html_url":"https://github.com/alice"}}
+		]`,
+		"/repos/o/r/deployments/5/statuses": `[{"state":"success","created_at":"2025-01-12T12:05:00Z",
+			"log_url":"https://github.com/o/r/actions/runs/5","environment_url":"https://example.com"}]`,
+		"/repos/o/r/deployments/4/statuses": `[{"state":"failure","created_at":"2025-01-11T12:05:00Z",
+			"log_url":"https://github.com/o/r/actions/runs/4"}]`,
+		"/repos/o/r/deployments/3/statuses": `[{"state":"inactive","created_at":"2025-01-10T12:05:00Z",
+			"environment_url":"https://staging.example.com"}]`,
+		"/repos/o/r/deployments/2/statuses": `[{"state":"success","created_at":"2024-12-20T12:05:00Z"}]`,
+		"/repos/o/r/compare/aaa...ccc": `{"status":"ahead","commits":[
+			{"sha":"bbb","commit":{"author":{"name":"Bob","date":"2025-01-09T09:00:00Z"},"message":"Add search (#12)"},
+				"author":{"login":"bob","html_url":"https://github.com/bob"},"html_url":"https://github.com/o/r/commit/bbb"},
+			{"sha":"ccc","commit":{"author":{"name":"Bob","date":"2025-01-09T10:00:00Z"},"message":"Fix search"},
+				"author":{"login":"bob","html_url":"https://github.com/bob"},"html_url":"https://github.com/o/r/commit/ccc"}
+		]}`,
+		"/repos/o/r/compare/aaa...aaa": `{"status":"identical","commits":[]}`,
+	}}
+	c := newTestClient(t, transport, 0)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	deployments, err := c.GetDeployments(context.Background(), "o/r", from, to)
+	if err != nil {
+		t.Fatalf("GetDeployments() error = %v", err)
+	}
+
+	if len(deployments) != 3 {
+		t.Fatalf("got %d deployments, want 3", len(deployments))
+	}
+
+	// Redeploys the commit of the last successful deployment, as the deployment of ccc failed
+	redeploy := deployments[0]
+	if redeploy.ID != 5 || redeploy.State != "success" || redeploy.URL != "https://github.com/o/r/actions/runs/5" {
+		t.Errorf("got first deployment %+v, want successful deployment 5 with its log", redeploy)
+	}
+	if redeploy.PreviousSHA != "aaa" || redeploy.RolledBack || len(redeploy.Commits) != 0 {
+		t.Errorf("got first deployment since %q (rolled back %v) with %d commits, want aaa without commits",
+			redeploy.PreviousSHA, redeploy.RolledBack, len(redeploy.Commits))
+	}
+
+	failed := deployments[1]
+	if !failed.IsFailed() || failed.PreviousSHA != "aaa" || len(failed.Commits) != 2 {
+		t.Errorf("got second deployment %+v, want failed deployment with 2 commits since aaa", failed)
+	}
+
+	staging := deployments[2]
+	if staging.Environment != "staging" || staging.PreviousSHA != "" || staging.Commits != nil {
+		t.Errorf("got third deployment %+v, want first staging deployment without commits", staging)
+	}
+	if !redeploy.Production || staging.Production {
+		t.Errorf("got production flags %v and %v, want only the production deployment flagged", redeploy.Production, staging.Production)
+	}
+	if !staging.Creator.IsBot || staging.URL != "https://staging.example.com" {
+		t.Errorf("got staging creator %+v and URL %q, want a bot and the environment URL", staging.Creator, staging.URL)
+	}
+
+	// The oldest production deployment is not needed as a predecessor
+	for _, path := range transport.requests {
+		if path == "/repos/o/r/deployments/1/statuses" {
+			t.Errorf("requested statuses of deployment 1, want only the preceding deployment 2")
+		}
+	}
+}
+
+func TestGetDeploymentsRollback(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/deployments": `[
+			{"id":2,"environment":"production","ref":"v1.0.0","sha":"aaa","created_at":"2025-01-11T12:00:00Z",
+				"creator":{"login":"alice","html_url":"https://github.com/alice"}},
+			{"id":1,"environment":"production","ref":"v1.1.0","sha":"bbb","created_at":"2025-01-10T12:00:00Z",
+				"creator":{"login":"alice","html_url":"https://github.com/alice"}}
+		]`,
+		"/repos/o/r/deployments/2/statuses": `[{"state":"success","created_at":"2025-01-11T12:05:00Z"}]`,
+		"/repos/o/r/deployments/1/statuses": `[{"state":"inactive","created_at":"2025-01-11T12:05:00Z"}]`,
+		"/repos/o/r/compare/bbb...aaa":      `{"status":"behind","commits":[]}`,
+	}}
+	c := newTestClient(t, transport, 0)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	deployments, err := c.GetDeployments(context.Background(), "o/r", from, to)
+	if err != nil {
+		t.Fatalf("GetDeployments() error = %v", err)
+	}
+
+	if len(deployments) != 2 {
+		t.Fatalf("got %d deployments, want 2", len(deployments))
+	}
+	if !deployments[0].RolledBack || deployments[0].PreviousSHA != "bbb" {
+		t.Errorf("got latest deployment %+v, want a rollback from bbb", deployments[0])
+	}
+	if deployments[1].RolledBack {
+		t.Errorf("got first deployment %+v, want no rollback", deployments[1])
+	}
+}
+
+func TestGetDeploymentsSkipsFailedPredecessors(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/deployments": `[
+			{"id":3,"environment":"production","ref":"v1.2.0","sha":"ccc","created_at":"2025-01-10T12:00:00Z",
+				"creator":{"login":"alice","html_url":"https://github.com/alice"}},
+			{"id":2,"environment":"production","ref":"v1.1.0","sha":"bbb","created_at":"2024-12-20T12:00:00Z",
+				"creator":{"login":"alice","html_url":"https://github.com/alice"}},
+			{"id":1,"environment":"production","ref":"v1.0.0","sha":"aaa","created_at":"2024-12-01T12:00:00Z",
+				"creator":{"login":"alice","html_url":"https://github.com/alice"}}
+		]`,
+		"/repos/o/r/deployments/3/statuses": `[{"state":"success","created_at":"2025-01-10T12:05:00Z"}]`,
+		"/repos/o/r/deployments/2/statuses": `[{"state":"failure","created_at":"2024-12-20T12:05:00Z"}]`,
+		"/repos/o/r/deployments/1/statuses": `[{"state":"inactive","created_at":"2024-12-20T12:05:00Z"}]`,
+		"/repos/o/r/compare/aaa...ccc": `{"status":"ahead","commits":[
+			{"sha":"bbb","commit":{"author":{"name":"Bob","date":"2024-12-15T09:00:00Z"},"message":"Add search"},
+				"author":{"login":"bob","html_url":"https://github.com/bob"},"html_url":"https://github.com/o/r/commit/bbb"},
+			{"sha":"ccc","commit":{"author":{"name":"Bob","date":"2025-01-09T10:00:00Z"},"message":"Fix search"},
+				"author":{"login":"bob","html_url":"https://github.com/bob"},"html_url":"https://github.com/o/r/commit/ccc"}
+		]}`,
+	}}
+	c := newTestClient(t, transport, 0)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	deployments, err := c.GetDeployments(context.Background(), "o/r", from, to)
+	if err != nil {
+		t.Fatalf("GetDeployments() error = %v", err)
+	}
+
+	if len(deployments) != 1 {
+		t.Fatalf("got %d deployments, want 1", len(deployments))
+	}
+	// The failed deployment of bbb before the period is skipped
+	if got := deployments[0]; got.PreviousSHA != "aaa" || len(got.Commits) != 2 {
+		t.Errorf("got deployment since %q with %d commits, want 2 commits since aaa", got.PreviousSHA, len(got.Commits))
+	}
+}
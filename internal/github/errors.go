@@ -0,0 +1,130 @@
+package github
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/hazadus/gh-repomon/internal/errors"
+)
+
+// classifyError converts a failed request to path into one of the
+// internal/errors types based on the HTTP status code of the response
+func classifyError(path string, err error) error {
+	var httpErr *api.HTTPError
+	if !stderrors.As(err, &httpErr) {
+		return errors.NewGitHubAPIError("API request failed", 0, err)
+	}
+
+	switch status := httpErr.StatusCode; {
+	case status == http.StatusUnauthorized:
+		return errors.NewGitHubAuthError("GitHub rejected the credentials", err)
+
+	case status == http.StatusForbidden || status == http.StatusTooManyRequests:
+		if isRateLimitError(httpErr) {
+			return errors.NewRateLimitedError(rateLimitReset(httpErr.Headers), err)
+		}
+		return errors.NewPermissionDeniedError(resourceName(path), err)
+
+	case status == http.StatusNotFound:
+		// A missing repository-level collection means the repository itself is
+		// missing or not visible to the token (GitHub answers 404 for both)
+		if repo, ok := repositoryCollection(path); ok {
+			return errors.NewRepoNotFoundError(repo)
+		}
+		return errors.NewGitHubAPIError("resource not found", status, err)
+
+	case status >= 500:
+		return errors.NewGitHubServerError(status, err)
+
+	default:
+		return errors.NewGitHubAPIError("API request failed", status, err)
+	}
+}
+
+// isRetryableError checks if an error is worth retrying:
+// server errors (5xx), timeouts and dropped connections
+func isRetryableError(err error) bool {
+	if err == nil || stderrors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var httpErr *api.HTTPError
+	if stderrors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if stderrors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return stderrors.Is(err, syscall.ECONNREFUSED) ||
+		stderrors.Is(err, syscall.ECONNRESET) ||
+		stderrors.Is(err, io.ErrUnexpectedEOF) ||
+		stderrors.Is(err, io.EOF)
+}
+
+// isRateLimitError tells a rate limit rejection from a permission error
+func isRateLimitError(err *api.HTTPError) bool {
+	if err.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if err.Headers.Get("X-RateLimit-Remaining") == "0" || err.Headers.Get("Retry-After") != "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Message), "rate limit")
+}
+
+// rateLimitReset returns when the primary rate limit resets, if known
+func rateLimitReset(header http.Header) time.Time {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(reset, 0)
+	}
+	return time.Time{}
+}
+
+// apiPath strips the host and query from a request path or next-page URL
+func apiPath(path string) string {
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+	path = strings.TrimPrefix(path, "/")
+	return strings.TrimPrefix(path, "api/v3/")
+}
+
+// repositoryCollection reports whether path is a repository-level listing
+// (repos/{owner}/{repo}/{collection}) and returns the repository name
+func repositoryCollection(path string) (string, bool) {
+	parts := strings.Split(apiPath(path), "/")
+	if len(parts) != 4 || parts[0] != "repos" {
+		return "", false
+	}
+	return parts[1] + "/" + parts[2], true
+}
+
+// resourceName describes the requested resource for error messages
+func resourceName(path string) string {
+	parts := strings.Split(apiPath(path), "/")
+	if len(parts) < 3 || parts[0] != "repos" {
+		return apiPath(path)
+	}
+
+	repo := parts[1] + "/" + parts[2]
+	if len(parts) == 3 {
+		return "repository " + repo
+	}
+	return fmt.Sprintf("%s in %s", strings.Join(parts[3:], "/"), repo)
+}
@@ -0,0 +1,931 @@
+package github
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/hazadus/gh-repomon/internal/errors"
+	"github.com/hazadus/gh-repomon/internal/logger"
+	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
+)
+
+// GraphQLClient collects repository activity through the GitHub GraphQL API.
+// Branches with their commit history (including line stats), pull requests
+// with reviews and issues are fetched in a few batched queries instead of one
+// REST call per branch and per commit. Methods not overridden here fall back
+// to the REST implementation of the embedded Client.
+type GraphQLClient struct {
+	*Client
+	gql     *api.GraphQLClient
+	limiter *RateLimiter
+
+	reviewsMu sync.Mutex
+	reviews   map[string][]types.Review
+
+	// now and sleep are replaced in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewGraphQLClient creates a new GitHub GraphQL API client
+func NewGraphQLClient(opts ClientOptions) (*GraphQLClient, error) {
+	rest, err := NewClientWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// GraphQL has its own rate limit, tracked separately from the REST one
+	limiter := NewRateLimiter(nil)
+	gql, err := api.NewGraphQLClient(api.ClientOptions{Transport: limiter})
+	if err != nil {
+		return nil, errors.NewGitHubAuthError("failed to create GitHub GraphQL client", err)
+	}
+
+	return &GraphQLClient{
+		Client:  rest,
+		gql:     gql,
+		limiter: limiter,
+		reviews: make(map[string][]types.Review),
+		now:     time.Now,
+		sleep:   utils.Sleep,
+	}, nil
+}
+
+// Page sizes of GraphQL connections. Branches are fetched together with up to
+// gqlHistoryPageSize commits each, so the refs page is kept small.
+const (
+	gqlRefsPageSize    = 25
+	gqlHistoryPageSize = 100
+	gqlPullsPageSize   = 50
+	gqlIssuesPageSize  = 100
+)
+
+const gqlCommitFields = `
+	oid
+	message
+	authoredDate
+	additions
+	deletions
+	url
+	author { name email user { login url } }`
+
+const gqlActorFields = `__typename login url`
+
+var branchesQuery = fmt.Sprintf(`query($owner: String!, $name: String!, $since: GitTimestamp!, $until: GitTimestamp!, $cursor: String) {
+	repository(owner: $owner, name: $name) {
+		refs(refPrefix: "refs/heads/", first: %d, after: $cursor) {
+			pageInfo { hasNextPage endCursor }
+			nodes {
+				name
+				target {
+					... on Commit {
+						history(first: %d, since: $since, until: $until) {
+							pageInfo { hasNextPage endCursor }
+							nodes { %s }
+						}
+					}
+				}
+			}
+		}
+	}
+}`, gqlRefsPageSize, gqlHistoryPageSize, gqlCommitFields)
+
+var historyQuery = fmt.Sprintf(`query($owner: String!, $name: String!, $ref: String!, $since: GitTimestamp!, $until: GitTimestamp!, $cursor: String) {
+	repository(owner: $owner, name: $name) {
+		ref(qualifiedName: $ref) {
+			target {
+				... on Commit {
+					history(first: %d, after: $cursor, since: $since, until: $until) {
+						pageInfo { hasNextPage endCursor }
+						nodes { %s }
+					}
+				}
+			}
+		}
+	}
+}`, gqlHistoryPageSize, gqlCommitFields)
+
+var pullRequestsQuery = fmt.Sprintf(`query($owner: String!, $name: String!, $states: [PullRequestState!], $cursor: String) {
+	repository(owner: $owner, name: $name) {
+		pullRequests(first: %d, after: $cursor, states: $states, orderBy: {field: UPDATED_AT, direction: DESC}) {
+			pageInfo { hasNextPage endCursor }
+			nodes {
+				number
+				title
+				body
+				state
+				url
+				createdAt
+				updatedAt
+				closedAt
+				mergedAt
+				mergedBy { %s }
+				baseRefName
+				headRefName
+				headRepository { nameWithOwner }
+				isDraft
+				mergeable
+				author { %s }
+				labels(first: 20) { nodes { name } }
+				commits(first: 1) { nodes { commit { authoredDate } } }
+				comments { totalCount }
+				reviews(first: 100) {
+					pageInfo { hasNextPage }
+					nodes { author { %s } state submittedAt url }
+				}
+			}
+		}
+	}
+}`, gqlPullsPageSize, gqlActorFields, gqlActorFields, gqlActorFields)
+
+var issuesQuery = fmt.Sprintf(`query($owner: String!, $name: String!, $states: [IssueState!], $since: DateTime, $cursor: String) {
+	repository(owner: $owner, name: $name) {
+		issues(first: %d, after: $cursor, states: $states, filterBy: {since: $since}, orderBy: {field: UPDATED_AT, direction: DESC}) {
+			pageInfo { hasNextPage endCursor }
+			nodes {
+				number
+				title
+				body
+				state
+				url
+				createdAt
+				closedAt
+				author { %s }
+				labels(first: 20) { nodes { name } }
+				assignees(first: 20) { nodes { login url name } }
+			}
+		}
+	}
+}`, gqlIssuesPageSize, gqlActorFields)
+
+// gqlPageInfo is the pagination info of a GraphQL connection
+type gqlPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// gqlActor is a GraphQL user, bot or organization
+type gqlActor struct {
+	Typename string `json:"__typename"`
+	Login    string `json:"login"`
+	URL      string `json:"url"`
+}
+
+// gqlCommit is a commit node of a GraphQL history connection
+type gqlCommit struct {
+	OID          string    `json:"oid"`
+	Message      string    `json:"message"`
+	AuthoredDate time.Time `json:"authoredDate"`
+	Additions    int       `json:"additions"`
+	Deletions    int       `json:"deletions"`
+	URL          string    `json:"url"`
+	Author       struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+		User  *struct {
+			Login string `json:"login"`
+			URL   string `json:"url"`
+		} `json:"user"`
+	} `json:"author"`
+}
+
+// gqlHistory is a commit history connection
+type gqlHistory struct {
+	PageInfo gqlPageInfo `json:"pageInfo"`
+	Nodes    []gqlCommit `json:"nodes"`
+}
+
+// gqlRef is a branch reference with its commit history
+type gqlRef struct {
+	Name   string `json:"name"`
+	Target struct {
+		History gqlHistory `json:"history"`
+	} `json:"target"`
+}
+
+// gqlReview is a pull request review node
+type gqlReview struct {
+	Author      *gqlActor `json:"author"`
+	State       string    `json:"state"`
+	SubmittedAt time.Time `json:"submittedAt"`
+	URL         string    `json:"url"`
+}
+
+// gqlPullRequest is a pull request node
+type gqlPullRequest struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	State       string     `json:"state"`
+	URL         string     `json:"url"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	ClosedAt    *time.Time `json:"closedAt"`
+	MergedAt    *time.Time `json:"mergedAt"`
+	MergedBy    *gqlActor  `json:"mergedBy"`
+	BaseRefName string     `json:"baseRefName"`
+	HeadRefName string     `json:"headRefName"`
+	// HeadRepository is nil when the fork of the pull request was deleted
+	HeadRepository *struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"headRepository"`
+	IsDraft   bool      `json:"isDraft"`
+	Mergeable string    `json:"mergeable"` // UNKNOWN until GitHub has computed it
+	Author    *gqlActor `json:"author"`
+	Labels    struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				AuthoredDate time.Time `json:"authoredDate"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+	Reviews struct {
+		PageInfo gqlPageInfo `json:"pageInfo"`
+		Nodes    []gqlReview `json:"nodes"`
+	} `json:"reviews"`
+}
+
+// gqlIssue is an issue node
+type gqlIssue struct {
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     string     `json:"state"`
+	URL       string     `json:"url"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ClosedAt  *time.Time `json:"closedAt"`
+	Author    *gqlActor  `json:"author"`
+	Labels    struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		Nodes []struct {
+			Login string `json:"login"`
+			URL   string `json:"url"`
+			Name  string `json:"name"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+}
+
+// GetActiveBranches retrieves branches that have commits during the specified period
+func (g *GraphQLClient) GetActiveBranches(ctx context.Context, repo string, from, to time.Time) ([]types.Branch, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"since":  from.Format(time.RFC3339),
+		"until":  to.Format(time.RFC3339),
+		"cursor": nil,
+	}
+
+	activeBranches := make([]types.Branch, 0)
+	fetched := 0
+
+	for {
+		var response struct {
+			Repository struct {
+				Refs struct {
+					PageInfo gqlPageInfo `json:"pageInfo"`
+					Nodes    []gqlRef    `json:"nodes"`
+				} `json:"refs"`
+			} `json:"repository"`
+		}
+
+		if err := g.query(ctx, repo, branchesQuery, variables, &response); err != nil {
+			return nil, fmt.Errorf("failed to get branches: %w", err)
+		}
+
+		refs := response.Repository.Refs
+		nodes, truncated := applyItemCap(g.Client, fetched, refs.Nodes, refs.PageInfo.HasNextPage)
+		fetched += len(nodes)
+
+		for _, ref := range nodes {
+			commits, err := g.branchCommits(ctx, repo, ref, from, to)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return nil, fmt.Errorf("failed to get active branches: %w", ctxErr)
+				}
+				// If we can't get commits for a branch, skip it but don't fail
+				continue
+			}
+
+			// Skip branches with no activity
+			if len(commits) == 0 {
+				continue
+			}
+
+			activeBranches = append(activeBranches, NewBranch(ref.Name, commits))
+		}
+
+		if truncated {
+			g.recordTruncated(fmt.Sprintf("branches of %s", repo))
+			break
+		}
+		if !refs.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = refs.PageInfo.EndCursor
+	}
+
+	return activeBranches, nil
+}
+
+// branchCommits returns the commits of a branch in the period, fetching the
+// remaining history pages when the first batch did not cover all of them
+func (g *GraphQLClient) branchCommits(ctx context.Context, repo string, ref gqlRef, from, to time.Time) ([]types.Commit, error) {
+	resource := fmt.Sprintf("commits of %s on branch %s", repo, ref.Name)
+	history := ref.Target.History
+
+	nodes, truncated := applyItemCap(g.Client, 0, history.Nodes, history.PageInfo.HasNextPage)
+	fetched := len(nodes)
+	commits := g.toCommits(nodes)
+
+	if truncated {
+		g.recordTruncated(resource)
+		return commits, nil
+	}
+	if !history.PageInfo.HasNextPage {
+		return commits, nil
+	}
+
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"ref":    "refs/heads/" + ref.Name,
+		"since":  from.Format(time.RFC3339),
+		"until":  to.Format(time.RFC3339),
+		"cursor": history.PageInfo.EndCursor,
+	}
+
+	for {
+		var response struct {
+			Repository struct {
+				Ref *struct {
+					Target struct {
+						History gqlHistory `json:"history"`
+					} `json:"target"`
+				} `json:"ref"`
+			} `json:"repository"`
+		}
+
+		if err := g.query(ctx, repo, historyQuery, variables, &response); err != nil {
+			return nil, fmt.Errorf("failed to get commits: %w", err)
+		}
+		if response.Repository.Ref == nil {
+			return commits, nil
+		}
+
+		page := response.Repository.Ref.Target.History
+		nodes, truncated := applyItemCap(g.Client, fetched, page.Nodes, page.PageInfo.HasNextPage)
+		fetched += len(nodes)
+		commits = append(commits, g.toCommits(nodes)...)
+
+		if truncated {
+			g.recordTruncated(resource)
+			break
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = page.PageInfo.EndCursor
+	}
+
+	return commits, nil
+}
+
+// GetOpenPullRequests retrieves all open pull requests for a repository
+func (g *GraphQLClient) GetOpenPullRequests(ctx context.Context, repo string) ([]types.PullRequest, error) {
+	nodes, err := g.fetchPullRequests(ctx, repo, fmt.Sprintf("open pull requests of %s", repo), []string{"OPEN"}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open pull requests: %w", err)
+	}
+
+	var prs []types.PullRequest
+	for _, node := range nodes {
+		pr := g.toPullRequest(repo, node)
+
+		// Filter bots if requested
+		if g.excludeBots && pr.Author.IsBot {
+			continue
+		}
+
+		prs = append(prs, pr)
+	}
+
+	return prs, nil
+}
+
+// GetUpdatedPullRequests retrieves pull requests updated during the specified period
+func (g *GraphQLClient) GetUpdatedPullRequests(ctx context.Context, repo, from, to string) ([]types.PullRequest, error) {
+	fromTime, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date: %w", err)
+	}
+
+	toTime, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date: %w", err)
+	}
+
+	// PRs are sorted by update time, so stop at the first one older than the period
+	nodes, err := g.fetchPullRequests(ctx, repo, fmt.Sprintf("updated pull requests of %s", repo), nil, func(node gqlPullRequest) bool {
+		return node.UpdatedAt.Before(fromTime)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated pull requests: %w", err)
+	}
+
+	var prs []types.PullRequest
+	for _, node := range nodes {
+		// Filter by updated_at within the period
+		if node.UpdatedAt.Before(fromTime) || node.UpdatedAt.After(toTime) {
+			continue
+		}
+
+		pr := g.toPullRequest(repo, node)
+
+		// Filter bots if requested
+		if g.excludeBots && pr.Author.IsBot {
+			continue
+		}
+
+		prs = append(prs, pr)
+	}
+
+	return prs, nil
+}
+
+// fetchPullRequests pages through pull requests sorted by update time.
+// A nil states list returns pull requests in any state. If stop is set,
+// no further pages are requested once it returns true for a node.
+func (g *GraphQLClient) fetchPullRequests(ctx context.Context, repo, resource string, states []string, stop func(gqlPullRequest) bool) ([]gqlPullRequest, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"cursor": nil,
+	}
+	if states != nil {
+		variables["states"] = states
+	}
+
+	var result []gqlPullRequest
+	for {
+		var response struct {
+			Repository struct {
+				PullRequests struct {
+					PageInfo gqlPageInfo      `json:"pageInfo"`
+					Nodes    []gqlPullRequest `json:"nodes"`
+				} `json:"pullRequests"`
+			} `json:"repository"`
+		}
+
+		if err := g.query(ctx, repo, pullRequestsQuery, variables, &response); err != nil {
+			return nil, err
+		}
+
+		conn := response.Repository.PullRequests
+		nodes, truncated := applyItemCap(g.Client, len(result), conn.Nodes, conn.PageInfo.HasNextPage)
+		result = append(result, nodes...)
+
+		if stop != nil {
+			for _, node := range nodes {
+				if stop(node) {
+					return result, nil
+				}
+			}
+		}
+
+		if truncated {
+			g.recordTruncated(resource)
+			break
+		}
+		if !conn.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = conn.PageInfo.EndCursor
+	}
+
+	return result, nil
+}
+
+// GetPullRequestReviews returns the reviews fetched together with the pull
+// request listings, falling back to the REST API for pull requests not seen yet
+func (g *GraphQLClient) GetPullRequestReviews(ctx context.Context, repo string, prNumber int) ([]types.Review, error) {
+	g.reviewsMu.Lock()
+	reviews, ok := g.reviews[reviewsKey(repo, prNumber)]
+	g.reviewsMu.Unlock()
+
+	if ok {
+		return reviews, nil
+	}
+
+	return g.Client.GetPullRequestReviews(ctx, repo, prNumber)
+}
+
+// GetOpenIssues retrieves all open issues from the repository
+func (g *GraphQLClient) GetOpenIssues(ctx context.Context, repo string) ([]types.Issue, error) {
+	nodes, err := g.fetchIssues(ctx, repo, fmt.Sprintf("open issues of %s", repo), "OPEN", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open issues: %w", err)
+	}
+
+	var issues []types.Issue
+	for _, node := range nodes {
+		issue := g.toIssue(node)
+
+		// Filter bots if needed
+		if g.excludeBots && issue.Author.IsBot {
+			continue
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+// GetClosedIssues retrieves issues closed during the specified period
+func (g *GraphQLClient) GetClosedIssues(ctx context.Context, repo, from, to string) ([]types.Issue, error) {
+	fromTime, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date: %w", err)
+	}
+
+	toTime, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date: %w", err)
+	}
+
+	// Issues closed in the period were necessarily updated since its start
+	nodes, err := g.fetchIssues(ctx, repo, fmt.Sprintf("closed issues of %s", repo), "CLOSED", &fromTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get closed issues: %w", err)
+	}
+
+	var issues []types.Issue
+	for _, node := range nodes {
+		// Check if issue was closed in the period
+		if node.ClosedAt == nil || node.ClosedAt.Before(fromTime) || node.ClosedAt.After(toTime) {
+			continue
+		}
+
+		issue := g.toIssue(node)
+
+		// Filter bots if needed
+		if g.excludeBots && issue.Author.IsBot {
+			continue
+		}
+
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+// fetchIssues pages through issues in the given state, optionally limited to
+// issues updated since the given time
+func (g *GraphQLClient) fetchIssues(ctx context.Context, repo, resource, state string, since *time.Time) ([]gqlIssue, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"states": []string{state},
+		"since":  nil,
+		"cursor": nil,
+	}
+	if since != nil {
+		variables["since"] = since.Format(time.RFC3339)
+	}
+
+	var result []gqlIssue
+	for {
+		var response struct {
+			Repository struct {
+				Issues struct {
+					PageInfo gqlPageInfo `json:"pageInfo"`
+					Nodes    []gqlIssue  `json:"nodes"`
+				} `json:"issues"`
+			} `json:"repository"`
+		}
+
+		if err := g.query(ctx, repo, issuesQuery, variables, &response); err != nil {
+			return nil, err
+		}
+
+		conn := response.Repository.Issues
+		nodes, truncated := applyItemCap(g.Client, len(result), conn.Nodes, conn.PageInfo.HasNextPage)
+		result = append(result, nodes...)
+
+		if truncated {
+			g.recordTruncated(resource)
+			break
+		}
+		if !conn.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = conn.PageInfo.EndCursor
+	}
+
+	return result, nil
+}
+
+// query runs a GraphQL query with retry logic for transient errors. A
+// RATE_LIMITED error is answered with HTTP 200, so the rate limiter transport
+// does not see it; the query waits for the limit to reset and is repeated.
+func (g *GraphQLClient) query(ctx context.Context, repo, query string, variables map[string]interface{}, response interface{}) error {
+	maxRetries := 3
+	retryDelay := time.Second
+	limited := 0
+
+	var lastErr error
+	for i := 0; i < maxRetries; {
+		err := g.gql.DoWithContext(ctx, query, variables, response)
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+
+		// A missing repository is reported as a NOT_FOUND GraphQL error
+		var gqlErr *api.GraphQLError
+		if stderrors.As(err, &gqlErr) {
+			if gqlErr.Match("NOT_FOUND", "repository") {
+				return errors.NewRepoNotFoundError(repo)
+			}
+			if gqlErr.Match("RATE_LIMITED", "") {
+				reset := g.rateLimitReset(ctx)
+				if limited >= maxRateLimitRetries {
+					return errors.NewRateLimitedError(reset, err)
+				}
+				limited++
+				if err := g.waitForReset(ctx, reset, limited); err != nil {
+					return classifyError("graphql", err)
+				}
+				continue
+			}
+			return errors.NewGitHubAPIError("GraphQL query failed", 0, err)
+		}
+
+		// Retry on network errors or 5xx errors
+		if !isRetryableError(err) {
+			// Non-retryable error, return immediately
+			return classifyError("graphql", err)
+		}
+
+		i++
+		if i < maxRetries {
+			if err := utils.Sleep(ctx, retryDelay); err != nil {
+				return classifyError("graphql", err)
+			}
+			retryDelay *= 2 // Exponential backoff
+		}
+	}
+
+	return classifyError("graphql", lastErr)
+}
+
+// rateLimitQuery asks when the GraphQL rate limit resets
+const rateLimitQuery = `query { rateLimit { resetAt } }`
+
+// rateLimitReset returns when the GraphQL rate limit resets: from the
+// X-RateLimit-Reset header of the rejected response, or from the rateLimit
+// query when the header is missing. The zero time means unknown.
+func (g *GraphQLClient) rateLimitReset(ctx context.Context) time.Time {
+	if g.limiter != nil {
+		if reset := g.limiter.exhaustedUntil(); !reset.IsZero() {
+			return reset
+		}
+	}
+
+	var response struct {
+		RateLimit struct {
+			ResetAt time.Time `json:"resetAt"`
+		} `json:"rateLimit"`
+	}
+	if err := g.gql.DoWithContext(ctx, rateLimitQuery, nil, &response); err != nil {
+		return time.Time{}
+	}
+	return response.RateLimit.ResetAt
+}
+
+// waitForReset sleeps until the rate limit resets, or for a minute when the
+// reset time is unknown
+func (g *GraphQLClient) waitForReset(ctx context.Context, reset time.Time, attempt int) error {
+	wait := secondaryLimitWait
+	if !reset.IsZero() {
+		// Small buffer for clock skew
+		wait = reset.Sub(g.now()) + time.Second
+		if wait < time.Second {
+			wait = time.Second
+		}
+	}
+
+	logger.Warningf("GitHub GraphQL API rate limit reached, waiting %v before retry (attempt %d/%d)",
+		wait.Round(time.Second), attempt, maxRateLimitRetries)
+	return g.sleep(ctx, wait)
+}
+
+// toCommits converts GraphQL commit nodes to types.Commit, skipping bots if requested
+func (g *GraphQLClient) toCommits(nodes []gqlCommit) []types.Commit {
+	commits := make([]types.Commit, 0, len(nodes))
+
+	for _, node := range nodes {
+		author := types.Author{
+			Name: node.Author.Name,
+		}
+		if node.Author.User != nil {
+			author.Login = node.Author.User.Login
+			author.ProfileURL = node.Author.User.URL
+		}
+
+		// Check if we should filter out bots
+		if g.excludeBots && author.Login != "" && g.isBot(author.Login) {
+			continue
+		}
+		author.IsBot = g.isBot(author.Login)
+
+		// If author.Login is empty (deleted user), use name
+		if author.Login == "" {
+			author.Login = node.Author.Name
+		}
+
+		commits = append(commits, types.Commit{
+			SHA:       node.OID,
+			Message:   node.Message,
+			Author:    author,
+			Date:      node.AuthoredDate,
+			Additions: node.Additions,
+			Deletions: node.Deletions,
+			URL:       node.URL,
+		})
+	}
+
+	return commits
+}
+
+// toPullRequest converts a GraphQL pull request node to types.PullRequest
+// and caches its reviews for GetPullRequestReviews
+func (g *GraphQLClient) toPullRequest(repo string, node gqlPullRequest) types.PullRequest {
+	pr := types.PullRequest{
+		Number:       node.Number,
+		Title:        node.Title,
+		Body:         node.Body,
+		State:        strings.ToLower(node.State),
+		Author:       g.toAuthor(node.Author),
+		CreatedAt:    node.CreatedAt,
+		UpdatedAt:    node.UpdatedAt,
+		ClosedAt:     node.ClosedAt,
+		MergedAt:     node.MergedAt,
+		BaseRef:      node.BaseRefName,
+		HeadRef:      node.HeadRefName,
+		Draft:        node.IsDraft,
+		HasConflicts: node.Mergeable == "CONFLICTING",
+		Comments:     node.Comments.TotalCount,
+		URL:          node.URL,
+	}
+
+	if node.MergedBy != nil {
+		mergedBy := g.toAuthor(node.MergedBy)
+		pr.MergedBy = &mergedBy
+	}
+
+	if node.HeadRepository != nil {
+		pr.HeadRepo = node.HeadRepository.NameWithOwner
+	}
+
+	for _, label := range node.Labels.Nodes {
+		pr.Labels = append(pr.Labels, label.Name)
+	}
+
+	if len(node.Commits.Nodes) > 0 {
+		firstCommitAt := node.Commits.Nodes[0].Commit.AuthoredDate
+		pr.FirstCommitAt = &firstCommitAt
+	}
+
+	// Pull requests with more reviews than a single batch are left to the REST fallback
+	if !node.Reviews.PageInfo.HasNextPage {
+		reviews := make([]types.Review, 0, len(node.Reviews.Nodes))
+		for _, r := range node.Reviews.Nodes {
+			// Pending reviews are drafts visible only to their author
+			if r.State == "PENDING" {
+				continue
+			}
+
+			review := types.Review{
+				Author:      g.toAuthor(r.Author),
+				State:       r.State,
+				SubmittedAt: r.SubmittedAt,
+				URL:         r.URL,
+			}
+
+			// Filter out bot reviews if excludeBots is enabled
+			if g.excludeBots && review.Author.IsBot {
+				continue
+			}
+
+			reviews = append(reviews, review)
+		}
+
+		g.reviewsMu.Lock()
+		g.reviews[reviewsKey(repo, node.Number)] = reviews
+		g.reviewsMu.Unlock()
+	}
+
+	return pr
+}
+
+// toIssue converts a GraphQL issue node to types.Issue
+func (g *GraphQLClient) toIssue(node gqlIssue) types.Issue {
+	issue := types.Issue{
+		Number:    node.Number,
+		Title:     node.Title,
+		Body:      node.Body,
+		State:     strings.ToLower(node.State),
+		Author:    g.toAuthor(node.Author),
+		CreatedAt: node.CreatedAt,
+		ClosedAt:  node.ClosedAt,
+		URL:       node.URL,
+	}
+
+	for _, label := range node.Labels.Nodes {
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+
+	for _, assignee := range node.Assignees.Nodes {
+		issue.Assignees = append(issue.Assignees, types.Author{
+			Login:      assignee.Login,
+			Name:       assignee.Name,
+			ProfileURL: assignee.URL,
+		})
+	}
+
+	return issue
+}
+
+// toAuthor converts a GraphQL actor to types.Author. Bot logins get the
+// "[bot]" suffix used by the REST API so both backends report the same names.
+func (g *GraphQLClient) toAuthor(actor *gqlActor) types.Author {
+	if actor == nil {
+		return types.Author{}
+	}
+
+	login := actor.Login
+	if actor.Typename == "Bot" && !strings.HasSuffix(login, "[bot]") {
+		login += "[bot]"
+	}
+
+	return types.Author{
+		Login:      login,
+		ProfileURL: actor.URL,
+		IsBot:      g.isBot(login),
+	}
+}
+
+// reviewsKey identifies a pull request in the reviews cache
+func reviewsKey(repo string, prNumber int) string {
+	return fmt.Sprintf("%s#%d", repo, prNumber)
+}
+
+// splitRepo splits an owner/name repository into its parts
+func splitRepo(repo string) (owner, name string, err error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.NewInvalidParamsError("repo", fmt.Sprintf("invalid repository %q (expected owner/repo)", repo))
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,330 @@
+package github
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/hazadus/gh-repomon/internal/errors"
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// scriptedTransport answers GraphQL requests with canned responses in order
+type scriptedTransport struct {
+	responses []string
+	queries   []string
+	header    http.Header
+}
+
+func (t *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	t.queries = append(t.queries, string(body))
+
+	response := `{"data":{}}`
+	if len(t.responses) > 0 {
+		response = t.responses[0]
+		t.responses = t.responses[1:]
+	}
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	for key, values := range t.header {
+		header[key] = values
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(response)),
+		Request:    req,
+	}, nil
+}
+
+func newTestGraphQLClient(t *testing.T, transport *scriptedTransport, excludeBots bool) *GraphQLClient {
+	t.Helper()
+
+	gql, err := api.NewGraphQLClient(api.ClientOptions{
+		AuthToken: "test-token",
+		Host:      "github.com",
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("failed to create GraphQL client: %v", err)
+	}
+
+	return &GraphQLClient{
+		Client:  &Client{excludeBots: excludeBots},
+		gql:     gql,
+		reviews: make(map[string][]types.Review),
+		now:     time.Now,
+		sleep: func(context.Context, time.Duration) error {
+			t.Fatal("unexpected rate limit wait")
+			return nil
+		},
+	}
+}
+
+func TestGraphQLGetActiveBranches(t *testing.T) {
+	transport := &scriptedTransport{responses: []string{
+		`{"data":{"repository":{"refs":{
+			"pageInfo":{"hasNextPage":false,"endCursor":"r1"},
+			"nodes":[
+				{"name":"main","target":{"history":{
+					"pageInfo":{"hasNextPage":true,"endCursor":"h1"},
+					"nodes":[{"oid":"aaa","message":"First","authoredDate":"2024-01-02T10:00:00Z","additions":10,"deletions":2,"url":"https://github.com/o/r/commit/aaa",
+						"author":{"name":"Dev One","email":"dev1@example.com","user":{"login":"dev1","url":"https://github.com/dev1"}}}]
+				}}},
+				{"name":"stale","target":{"history":{"pageInfo":{"hasNextPage":false,"endCursor":""},"nodes":[]}}}
+			]
+		}}}}`,
+		`{"data":{"repository":{"ref":{"target":{"history":{
+			"pageInfo":{"hasNextPage":false,"endCursor":"h2"},
+			"nodes":[{"oid":"bbb","message":"Second","authoredDate":"2024-01-01T10:00:00Z","additions":5,"deletions":1,"url":"https://github.com/o/r/commit/bbb",
+				"author":{"name":"Former Dev","email":"former@example.com","user":null}}]
+		}}}}}}`,
+	}}
+	g := newTestGraphQLClient(t, transport, false)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)
+	branches, err := g.GetActiveBranches(context.Background(), "o/r", from, to)
+	if err != nil {
+		t.Fatalf("GetActiveBranches() error = %v", err)
+	}
+
+	if len(transport.queries) != 2 {
+		t.Errorf("made %d queries, want 2", len(transport.queries))
+	}
+	if len(branches) != 1 {
+		t.Fatalf("got %d active branches, want 1", len(branches))
+	}
+
+	main := branches[0]
+	if main.Name != "main" || len(main.Commits) != 2 {
+		t.Fatalf("got branch %q with %d commits, want main with 2", main.Name, len(main.Commits))
+	}
+	if main.TotalAdded != 15 || main.TotalDeleted != 3 {
+		t.Errorf("got +%d/-%d, want +15/-3", main.TotalAdded, main.TotalDeleted)
+	}
+	if main.Commits[1].Author.Login != "Former Dev" {
+		t.Errorf("commit without GitHub user should use author name, got %q", main.Commits[1].Author.Login)
+	}
+	if !strings.Contains(transport.queries[1], `"cursor":"h1"`) {
+		t.Errorf("history query should continue from the first page cursor, got %s", transport.queries[1])
+	}
+}
+
+func TestGraphQLPullRequestsWithReviews(t *testing.T) {
+	transport := &scriptedTransport{responses: []string{
+		`{"data":{"repository":{"pullRequests":{
+			"pageInfo":{"hasNextPage":false,"endCursor":"p1"},
+			"nodes":[
+				{"number":1,"title":"Feature","body":"","state":"OPEN","url":"https://github.com/o/r/pull/1",
+					"createdAt":"2024-01-01T10:00:00Z","updatedAt":"2024-01-02T10:00:00Z",
+					"author":{"__typename":"User","login":"dev1","url":"https://github.com/dev1"},
+					"headRepository":{"nameWithOwner":"fork/r"},
+					"mergeable":"CONFLICTING",
+					"commits":{"nodes":[{"commit":{"authoredDate":"2023-12-30T08:00:00Z"}}]},
+					"comments":{"totalCount":3},
+					"reviews":{"pageInfo":{"hasNextPage":false},"nodes":[
+						{"author":{"__typename":"User","login":"dev2","url":"https://github.com/dev2"},"state":"APPROVED","submittedAt":"2024-01-02T09:00:00Z","url":"https://github.com/o/r/pull/1#r1"},
+						{"author":{"__typename":"User","login":"dev3","url":"https://github.com/dev3"},"state":"PENDING","submittedAt":null,"url":""}
+					]}},
+				{"number":2,"title":"Bump deps","body":"","state":"OPEN","url":"https://github.com/o/r/pull/2",
+					"createdAt":"2024-01-01T10:00:00Z","updatedAt":"2024-01-02T10:00:00Z",
+					"author":{"__typename":"Bot","login":"dependabot","url":"https://github.com/apps/dependabot"},
+					"comments":{"totalCount":0},
+					"reviews":{"pageInfo":{"hasNextPage":false},"nodes":[]}}
+			]
+		}}}}`,
+	}}
+	g := newTestGraphQLClient(t, transport, true)
+
+	prs, err := g.GetOpenPullRequests(context.Background(), "o/r")
+	if err != nil {
+		t.Fatalf("GetOpenPullRequests() error = %v", err)
+	}
+
+	if len(prs) != 1 {
+		t.Fatalf("got %d PRs, want 1 (bot PR excluded)", len(prs))
+	}
+	if prs[0].State != "open" || prs[0].Comments != 3 {
+		t.Errorf("got state %q with %d comments, want open with 3", prs[0].State, prs[0].Comments)
+	}
+	if !prs[0].HasConflicts {
+		t.Error("got HasConflicts false, want true for a conflicting pull request")
+	}
+	if prs[0].HeadRepo != "fork/r" {
+		t.Errorf("got head repository %q, want fork/r", prs[0].HeadRepo)
+	}
+	if want := time.Date(2023, 12, 30, 8, 0, 0, 0, time.UTC); prs[0].FirstCommitAt == nil || !prs[0].FirstCommitAt.Equal(want) {
+		t.Errorf("got first commit at %v, want %v", prs[0].FirstCommitAt, want)
+	}
+
+	reviews, err := g.GetPullRequestReviews(context.Background(), "o/r", 1)
+	if err != nil {
+		t.Fatalf("GetPullRequestReviews() error = %v", err)
+	}
+	if len(transport.queries) != 1 {
+		t.Errorf("reviews should be served from the PR listing, made %d queries", len(transport.queries))
+	}
+	if len(reviews) != 1 || reviews[0].Author.Login != "dev2" || reviews[0].State != types.ReviewStateApproved {
+		t.Errorf("got reviews %+v, want a single approval by dev2", reviews)
+	}
+}
+
+func TestGraphQLUpdatedPullRequestsStopsAtPeriodStart(t *testing.T) {
+	transport := &scriptedTransport{responses: []string{
+		`{"data":{"repository":{"pullRequests":{
+			"pageInfo":{"hasNextPage":true,"endCursor":"p1"},
+			"nodes":[
+				{"number":5,"title":"Recent","state":"MERGED","createdAt":"2024-01-01T10:00:00Z","updatedAt":"2024-01-03T10:00:00Z",
+					"author":{"__typename":"User","login":"dev1","url":""},"reviews":{"pageInfo":{"hasNextPage":false},"nodes":[]}},
+				{"number":4,"title":"Old","state":"CLOSED","createdAt":"2023-12-01T10:00:00Z","updatedAt":"2023-12-20T10:00:00Z",
+					"author":{"__typename":"User","login":"dev1","url":""},"reviews":{"pageInfo":{"hasNextPage":false},"nodes":[]}}
+			]
+		}}}}`,
+	}}
+	g := newTestGraphQLClient(t, transport, false)
+
+	prs, err := g.GetUpdatedPullRequests(context.Background(), "o/r", "2024-01-01T00:00:00Z", "2024-01-07T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GetUpdatedPullRequests() error = %v", err)
+	}
+
+	if len(transport.queries) != 1 {
+		t.Errorf("made %d queries, want 1", len(transport.queries))
+	}
+	if len(prs) != 1 || prs[0].Number != 5 {
+		t.Fatalf("got %+v, want only PR #5", prs)
+	}
+	if prs[0].State != types.PRStateMerged {
+		t.Errorf("merged PR state = %q, want merged", prs[0].State)
+	}
+}
+
+func TestGraphQLRepoNotFound(t *testing.T) {
+	transport := &scriptedTransport{responses: []string{
+		`{"data":{"repository":null},"errors":[{"type":"NOT_FOUND","path":["repository"],"message":"Could not resolve to a Repository with the name 'o/missing'."}]}`,
+	}}
+	g := newTestGraphQLClient(t, transport, false)
+
+	_, err := g.GetOpenIssues(context.Background(), "o/missing")
+
+	var notFound *errors.ErrRepoNotFound
+	if !stderrors.As(err, &notFound) {
+		t.Errorf("GetOpenIssues() error = %v, want ErrRepoNotFound", err)
+	}
+}
+
+const gqlRateLimited = `{"data":null,"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded for user ID 1."}]}`
+
+func TestGraphQLRateLimitedWaitsForHeaderReset(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	transport := &scriptedTransport{
+		responses: []string{gqlRateLimited, `{"data":{"viewer":{"login":"dev"}}}`},
+		header: http.Header{
+			"X-Ratelimit-Remaining": []string{"0"},
+			"X-Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(10*time.Minute).Unix(), 10)},
+		},
+	}
+	limiter := NewRateLimiter(transport)
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(context.Context, time.Duration) error { return nil }
+	gql, err := api.NewGraphQLClient(api.ClientOptions{AuthToken: "test-token", Host: "github.com", Transport: limiter})
+	if err != nil {
+		t.Fatalf("failed to create GraphQL client: %v", err)
+	}
+
+	var waits []time.Duration
+	g := &GraphQLClient{
+		Client:  &Client{},
+		gql:     gql,
+		limiter: limiter,
+		now:     func() time.Time { return now },
+		sleep: func(_ context.Context, d time.Duration) error {
+			waits = append(waits, d)
+			return nil
+		},
+	}
+
+	var response struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+	}
+	if err := g.query(context.Background(), "o/r", "query { viewer { login } }", nil, &response); err != nil {
+		t.Fatalf("query() error = %v", err)
+	}
+
+	if response.Viewer.Login != "dev" {
+		t.Errorf("query() login = %q, want dev", response.Viewer.Login)
+	}
+	if len(waits) != 1 || waits[0] != 10*time.Minute+time.Second {
+		t.Errorf("query() waits = %v, want [10m1s]", waits)
+	}
+	if len(transport.queries) != 2 {
+		t.Errorf("query() sent %d requests, want 2", len(transport.queries))
+	}
+}
+
+func TestGraphQLRateLimitedQueriesReset(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	transport := &scriptedTransport{responses: []string{
+		gqlRateLimited,
+		`{"data":{"rateLimit":{"resetAt":"2024-01-01T12:05:00Z"}}}`,
+		`{"data":{"viewer":{"login":"dev"}}}`,
+	}}
+	g := newTestGraphQLClient(t, transport, false)
+	g.now = func() time.Time { return now }
+	var waits []time.Duration
+	g.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	var response struct{}
+	if err := g.query(context.Background(), "o/r", "query { viewer { login } }", nil, &response); err != nil {
+		t.Fatalf("query() error = %v", err)
+	}
+
+	if len(waits) != 1 || waits[0] != 5*time.Minute+time.Second {
+		t.Errorf("query() waits = %v, want [5m1s]", waits)
+	}
+	if len(transport.queries) != 3 || !strings.Contains(transport.queries[1], "rateLimit") {
+		t.Errorf("query() requests = %v, want the rate limit queried before the retry", transport.queries)
+	}
+}
+
+func TestGraphQLRateLimitedGivesUp(t *testing.T) {
+	responses := make([]string, 0, 2*(maxRateLimitRetries+1))
+	for i := 0; i <= maxRateLimitRetries; i++ {
+		responses = append(responses, gqlRateLimited, `{"data":{"rateLimit":{"resetAt":"2024-01-01T12:05:00Z"}}}`)
+	}
+	transport := &scriptedTransport{responses: responses}
+	g := newTestGraphQLClient(t, transport, false)
+	waits := 0
+	g.sleep = func(context.Context, time.Duration) error {
+		waits++
+		return nil
+	}
+
+	var response struct{}
+	err := g.query(context.Background(), "o/r", "query { viewer { login } }", nil, &response)
+
+	var limited *errors.ErrRateLimited
+	if !stderrors.As(err, &limited) {
+		t.Fatalf("query() error = %v, want ErrRateLimited", err)
+	}
+	if !limited.ResetAt.Equal(time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC)) {
+		t.Errorf("ErrRateLimited.ResetAt = %v, want 2024-01-01 12:05 UTC", limited.ResetAt)
+	}
+	if waits != maxRateLimitRetries {
+		t.Errorf("query() waited %d times, want %d", waits, maxRateLimitRetries)
+	}
+}
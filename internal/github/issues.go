@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -9,25 +10,12 @@ import (
 
 // GetOpenIssues retrieves all open issues from the repository.
 // It filters out pull requests (which GitHub API returns as issues).
-func (c *Client) GetOpenIssues(repo string) ([]types.Issue, error) {
+func (c *Client) GetOpenIssues(ctx context.Context, repo string) ([]types.Issue, error) {
 	var issues []types.Issue
-	page := 1
-	perPage := 100
+	path := fmt.Sprintf("repos/%s/issues?state=open", repo)
 
-	for {
-		path := fmt.Sprintf("repos/%s/issues?state=open&per_page=%d&page=%d", repo, perPage, page)
-		var response []map[string]interface{}
-
-		err := c.doWithRetry("GET", path, nil, &response)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get open issues: %w", err)
-		}
-
-		if len(response) == 0 {
-			break
-		}
-
-		for _, item := range response {
+	err := paginate(ctx, c, fmt.Sprintf("open issues of %s", repo), path, func(page []map[string]interface{}) bool {
+		for _, item := range page {
 			// Skip pull requests
 			if isPullRequest(item) {
 				continue
@@ -45,12 +33,10 @@ func (c *Client) GetOpenIssues(repo string) ([]types.Issue, error) {
 
 			issues = append(issues, issue)
 		}
-
-		// Check if there are more pages
-		if len(response) < perPage {
-			break
-		}
-		page++
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open issues: %w", err)
 	}
 
 	return issues, nil
@@ -58,10 +44,9 @@ func (c *Client) GetOpenIssues(repo string) ([]types.Issue, error) {
 
 // GetClosedIssues retrieves issues closed during the specified period.
 // It filters out pull requests and only returns issues closed between from and to dates.
-func (c *Client) GetClosedIssues(repo, from, to string) ([]types.Issue, error) {
+func (c *Client) GetClosedIssues(ctx context.Context, repo, from, to string) ([]types.Issue, error) {
 	var issues []types.Issue
-	page := 1
-	perPage := 100
+	path := fmt.Sprintf("repos/%s/issues?state=closed&sort=updated&direction=desc", repo)
 
 	fromTime, err := time.Parse(time.RFC3339, from)
 	if err != nil {
@@ -73,21 +58,9 @@ func (c *Client) GetClosedIssues(repo, from, to string) ([]types.Issue, error) {
 		return nil, fmt.Errorf("invalid to date: %w", err)
 	}
 
-	for {
-		path := fmt.Sprintf("repos/%s/issues?state=closed&per_page=%d&page=%d&sort=updated&direction=desc", repo, perPage, page)
-		var response []map[string]interface{}
-
-		err := c.doWithRetry("GET", path, nil, &response)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get closed issues: %w", err)
-		}
-
-		if len(response) == 0 {
-			break
-		}
-
+	err = paginate(ctx, c, fmt.Sprintf("closed issues of %s", repo), path, func(page []map[string]interface{}) bool {
 		foundOlder := false
-		for _, item := range response {
+		for _, item := range page {
 			// Skip pull requests
 			if isPullRequest(item) {
 				continue
@@ -121,10 +94,10 @@ func (c *Client) GetClosedIssues(repo, from, to string) ([]types.Issue, error) {
 		}
 
 		// If we found issues older than our period, we can stop
-		if foundOlder || len(response) < perPage {
-			break
-		}
-		page++
+		return !foundOlder
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get closed issues: %w", err)
 	}
 
 	return issues, nil
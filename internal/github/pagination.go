@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultPerPage is the page size requested from list endpoints
+const defaultPerPage = 100
+
+// paginate fetches every page of a list endpoint by following the Link header.
+// visit is called for each page and may return false to stop early.
+// If the client has an item cap, pagination stops once the cap is reached and
+// the resource is recorded as truncated.
+func paginate[T any](ctx context.Context, c *Client, resource, path string, visit func(page []T) bool) error {
+	return paginateWrapped(ctx, c, resource, path, func(page []T) []T { return page }, visit)
+}
+
+// paginateWrapped works like paginate for list endpoints that wrap their items
+// in an object, e.g. {"total_count": 2, "workflow_runs": [...]}. items extracts
+// the items from a decoded response R.
+func paginateWrapped[R, T any](ctx context.Context, c *Client, resource, path string, items func(R) []T, visit func(page []T) bool) error {
+	fetched := 0
+	next := withPerPage(path)
+
+	for next != "" {
+		var response R
+		header, err := c.requestWithRetry(ctx, "GET", next, &response)
+		if err != nil {
+			return err
+		}
+		next = parseNextLink(header.Get("Link"))
+
+		page := items(response)
+
+		page, truncated := applyItemCap(c, fetched, page, next != "")
+		fetched += len(page)
+
+		if !visit(page) {
+			return nil
+		}
+
+		if truncated {
+			c.recordTruncated(resource)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// applyItemCap trims a page so that no more than the client's item cap is
+// collected in total. It reports whether the listing got truncated, in which
+// case no further pages should be requested.
+func applyItemCap[T any](c *Client, fetched int, page []T, hasNext bool) ([]T, bool) {
+	if c.maxItems <= 0 || fetched+len(page) < c.maxItems {
+		return page, false
+	}
+
+	truncated := fetched+len(page) > c.maxItems || hasNext
+	return page[:c.maxItems-fetched], truncated
+}
+
+// parseNextLink extracts the URL of the next page from a Link header
+func parseNextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		segments := strings.Split(link, ";")
+		if len(segments) < 2 {
+			continue
+		}
+
+		for _, param := range segments[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(segments[0]), "<>")
+			}
+		}
+	}
+
+	return ""
+}
+
+// withPerPage requests the maximum page size unless the path already sets one
+func withPerPage(path string) string {
+	if strings.Contains(path, "per_page=") {
+		return path
+	}
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	return fmt.Sprintf("%s%sper_page=%d", path, separator, defaultPerPage)
+}
+
+// recordTruncated remembers a resource whose listing was cut at the item cap
+func (c *Client) recordTruncated(resource string) {
+	c.truncatedMu.Lock()
+	defer c.truncatedMu.Unlock()
+
+	for _, r := range c.truncated {
+		if r == resource {
+			return
+		}
+	}
+	c.truncated = append(c.truncated, resource)
+}
+
+// TruncatedResources returns the listings that were cut at the item cap, sorted
+func (c *Client) TruncatedResources() []string {
+	c.truncatedMu.Lock()
+	defer c.truncatedMu.Unlock()
+
+	resources := make([]string, len(c.truncated))
+	copy(resources, c.truncated)
+	sort.Strings(resources)
+
+	return resources
+}
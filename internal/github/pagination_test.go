@@ -0,0 +1,213 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// pagedTransport serves a list endpoint of total items split into pages
+type pagedTransport struct {
+	total    int
+	requests int
+}
+
+func (t *pagedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+
+	query := req.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page == 0 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("per_page"))
+
+	var items []string
+	for i := (page-1)*perPage + 1; i <= page*perPage && i <= t.total; i++ {
+		items = append(items, strconv.Itoa(i))
+	}
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	if page*perPage < t.total {
+		next := *req.URL
+		query.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+		header.Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, next.String(), next.String()))
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader("[" + strings.Join(items, ",") + "]")),
+		Request:    req,
+	}, nil
+}
+
+func newTestClient(t *testing.T, transport http.RoundTripper, maxItems int) *Client {
+	t.Helper()
+
+	rest, err := api.NewRESTClient(api.ClientOptions{
+		AuthToken: "test-token",
+		Host:      "github.com",
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("failed to create REST client: %v", err)
+	}
+
+	return &Client{client: rest, maxItems: maxItems}
+}
+
+func TestParseNextLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{
+			name:   "Next and last",
+			header: `<https://api.github.com/repos/o/r/commits?page=2>; rel="next", <https://api.github.com/repos/o/r/commits?page=5>; rel="last"`,
+			want:   "https://api.github.com/repos/o/r/commits?page=2",
+		},
+		{
+			name:   "Last page",
+			header: `<https://api.github.com/repos/o/r/commits?page=1>; rel="first", <https://api.github.com/repos/o/r/commits?page=4>; rel="prev"`,
+			want:   "",
+		},
+		{
+			name:   "Next not first",
+			header: `<https://api.github.com/x?page=1>; rel="prev", <https://api.github.com/x?page=3>; rel="next"`,
+			want:   "https://api.github.com/x?page=3",
+		},
+		{
+			name:   "Empty header",
+			header: "",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseNextLink(tt.header); got != tt.want {
+				t.Errorf("parseNextLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPerPage(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"repos/o/r/branches", "repos/o/r/branches?per_page=100"},
+		{"repos/o/r/pulls?state=open", "repos/o/r/pulls?state=open&per_page=100"},
+		{"repos/o/r/issues?per_page=10", "repos/o/r/issues?per_page=10"},
+	}
+
+	for _, tt := range tests {
+		if got := withPerPage(tt.path); got != tt.want {
+			t.Errorf("withPerPage(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name          string
+		total         int
+		maxItems      int
+		wantItems     int
+		wantRequests  int
+		wantTruncated bool
+	}{
+		{
+			name:         "Single page",
+			total:        42,
+			wantItems:    42,
+			wantRequests: 1,
+		},
+		{
+			name:         "Multiple pages",
+			total:        250,
+			wantItems:    250,
+			wantRequests: 3,
+		},
+		{
+			name:          "Capped within page",
+			total:         250,
+			maxItems:      150,
+			wantItems:     150,
+			wantRequests:  2,
+			wantTruncated: true,
+		},
+		{
+			name:          "Capped at page boundary",
+			total:         250,
+			maxItems:      100,
+			wantItems:     100,
+			wantRequests:  1,
+			wantTruncated: true,
+		},
+		{
+			name:         "Cap equals total",
+			total:        100,
+			maxItems:     100,
+			wantItems:    100,
+			wantRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &pagedTransport{total: tt.total}
+			c := newTestClient(t, transport, tt.maxItems)
+
+			var items []int
+			err := paginate(context.Background(), c, "items", "repos/o/r/items", func(page []int) bool {
+				items = append(items, page...)
+				return true
+			})
+			if err != nil {
+				t.Fatalf("paginate() error = %v", err)
+			}
+
+			if len(items) != tt.wantItems {
+				t.Errorf("got %d items, want %d", len(items), tt.wantItems)
+			}
+			if transport.requests != tt.wantRequests {
+				t.Errorf("made %d requests, want %d", transport.requests, tt.wantRequests)
+			}
+			if truncated := len(c.TruncatedResources()) > 0; truncated != tt.wantTruncated {
+				t.Errorf("truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestPaginateStopsEarly(t *testing.T) {
+	transport := &pagedTransport{total: 500}
+	c := newTestClient(t, transport, 0)
+
+	pages := 0
+	err := paginate(context.Background(), c, "items", "repos/o/r/items", func(page []int) bool {
+		pages++
+		return pages < 2
+	})
+	if err != nil {
+		t.Fatalf("paginate() error = %v", err)
+	}
+
+	if transport.requests != 2 {
+		t.Errorf("made %d requests, want 2", transport.requests)
+	}
+	if len(c.TruncatedResources()) != 0 {
+		t.Errorf("early stop should not be reported as truncation, got %v", c.TruncatedResources())
+	}
+}
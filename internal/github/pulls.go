@@ -1,18 +1,25 @@
 package github
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
 )
 
 // GetOpenPullRequests retrieves all open pull requests for a repository
-func (c *Client) GetOpenPullRequests(repo string) ([]types.PullRequest, error) {
-	path := fmt.Sprintf("repos/%s/pulls", repo)
+func (c *Client) GetOpenPullRequests(ctx context.Context, repo string) ([]types.PullRequest, error) {
+	path := fmt.Sprintf("repos/%s/pulls?state=open", repo)
 
 	var response []map[string]interface{}
-	err := c.doWithRetry("GET", path+"?state=open&per_page=100", nil, &response)
+	err := paginate(ctx, c, fmt.Sprintf("open pull requests of %s", repo), path, func(page []map[string]interface{}) bool {
+		response = append(response, page...)
+		return true
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get open pull requests: %w", err)
 	}
@@ -36,14 +43,8 @@ func (c *Client) GetOpenPullRequests(repo string) ([]types.PullRequest, error) {
 }
 
 // GetUpdatedPullRequests retrieves pull requests updated during the specified period
-func (c *Client) GetUpdatedPullRequests(repo, from, to string) ([]types.PullRequest, error) {
-	path := fmt.Sprintf("repos/%s/pulls", repo)
-
-	var response []map[string]interface{}
-	err := c.doWithRetry("GET", path+"?state=all&sort=updated&direction=desc&per_page=100", nil, &response)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get updated pull requests: %w", err)
-	}
+func (c *Client) GetUpdatedPullRequests(ctx context.Context, repo, from, to string) ([]types.PullRequest, error) {
+	path := fmt.Sprintf("repos/%s/pulls?state=all&sort=updated&direction=desc", repo)
 
 	fromTime, err := time.Parse(time.RFC3339, from)
 	if err != nil {
@@ -55,6 +56,23 @@ func (c *Client) GetUpdatedPullRequests(repo, from, to string) ([]types.PullRequ
 		return nil, fmt.Errorf("invalid to date: %w", err)
 	}
 
+	// PRs are sorted by update time, so stop at the first page reaching past the period
+	var response []map[string]interface{}
+	err = paginate(ctx, c, fmt.Sprintf("updated pull requests of %s", repo), path, func(page []map[string]interface{}) bool {
+		response = append(response, page...)
+		for _, prData := range page {
+			if updatedAt, ok := prData["updated_at"].(string); ok {
+				if t, err := time.Parse(time.RFC3339, updatedAt); err == nil && t.Before(fromTime) {
+					return false
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated pull requests: %w", err)
+	}
+
 	var prs []types.PullRequest
 	for _, prData := range response {
 		pr, err := c.parsePullRequest(prData)
@@ -75,20 +93,149 @@ func (c *Client) GetUpdatedPullRequests(repo, from, to string) ([]types.PullRequ
 		prs = append(prs, pr)
 	}
 
+	// The list endpoint does not report who merged a pull request nor its commits
+	c.fillMergeDetails(ctx, repo, prs, fromTime, toTime)
+
 	return prs, nil
 }
 
+// GetMergedPullRequests retrieves the merged pull requests opened from a branch
+// of the repository itself, whenever they were merged
+func (c *Client) GetMergedPullRequests(ctx context.Context, repo, branch string) ([]types.PullRequest, error) {
+	owner, _, _ := strings.Cut(repo, "/")
+	path := fmt.Sprintf("repos/%s/pulls?state=closed&head=%s", repo, url.QueryEscape(owner+":"+branch))
+
+	var response []map[string]interface{}
+	err := paginate(ctx, c, fmt.Sprintf("pull requests from %s of %s", branch, repo), path, func(page []map[string]interface{}) bool {
+		response = append(response, page...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged pull requests: %w", err)
+	}
+
+	var prs []types.PullRequest
+	for _, prData := range response {
+		pr, err := c.parsePullRequest(prData)
+		if err != nil || pr.MergedAt == nil {
+			continue // Closed without merging
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, nil
+}
+
+// fillMergeDetails fetches the user who merged and the time of the first
+// commit of each pull request merged during the period. Pull requests whose
+// details cannot be fetched keep an empty MergedBy or FirstCommitAt.
+func (c *Client) fillMergeDetails(ctx context.Context, repo string, prs []types.PullRequest, from, to time.Time) {
+	var indexes []int
+	for i, pr := range prs {
+		if pr.MergedAt != nil && !pr.MergedAt.Before(from) && !pr.MergedAt.After(to) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	// Each worker writes only its own element of prs
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, c.concurrency(5), func(ctx context.Context, i int) error {
+		path := fmt.Sprintf("repos/%s/pulls/%d", repo, prs[i].Number)
+
+		var response map[string]interface{}
+		if err := c.doWithRetry(ctx, "GET", path, nil, &response); err == nil {
+			if mergedBy, ok := response["merged_by"].(map[string]interface{}); ok {
+				author := c.parseAuthor(mergedBy)
+				prs[i].MergedBy = &author
+			}
+		}
+
+		// Pull request commits are listed oldest first
+		var commits []map[string]interface{}
+		if err := c.doWithRetry(ctx, "GET", path+"/commits?per_page=1", nil, &commits); err == nil && len(commits) > 0 {
+			prs[i].FirstCommitAt = parseCommitAuthorDate(commits[0])
+		}
+		return nil
+	})
+}
+
+// GetConflictingPullRequests returns which of the given pull requests have
+// merge conflicts; the list endpoint does not report it, so each pull request
+// is fetched. GitHub computes mergeability in the background, so pull requests
+// whose state is not known yet, or whose details cannot be fetched, are not
+// reported.
+func (c *Client) GetConflictingPullRequests(ctx context.Context, repo string, numbers []int) ([]int, error) {
+	indexes := make([]int, len(numbers))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	// Each worker writes only its own element of conflicting
+	conflicting := make([]bool, len(numbers))
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, c.concurrency(5), func(ctx context.Context, i int) error {
+		path := fmt.Sprintf("repos/%s/pulls/%d", repo, numbers[i])
+
+		var response map[string]interface{}
+		if err := c.doWithRetry(ctx, "GET", path, nil, &response); err == nil {
+			conflicting[i] = hasConflicts(response)
+		}
+		return nil
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get pull request conflicts: %w", err)
+	}
+
+	var result []int
+	for i, number := range numbers {
+		if conflicting[i] {
+			result = append(result, number)
+		}
+	}
+
+	return result, nil
+}
+
+// hasConflicts reports whether a single pull request response has merge conflicts
+func hasConflicts(data map[string]interface{}) bool {
+	state, _ := data["mergeable_state"].(string)
+	return state == "dirty"
+}
+
+// parseCommitAuthorDate returns the author date of a commit listing entry
+func parseCommitAuthorDate(data map[string]interface{}) *time.Time {
+	commit, ok := data["commit"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	author, ok := commit["author"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	date, ok := author["date"].(string)
+	if !ok {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 // GetPullRequestComments retrieves the number of comments on a pull request
-func (c *Client) GetPullRequestComments(repo string, prNumber int) (int, error) {
+func (c *Client) GetPullRequestComments(ctx context.Context, repo string, prNumber int) (int, error) {
 	path := fmt.Sprintf("repos/%s/pulls/%d/comments", repo, prNumber)
 
-	var response []map[string]interface{}
-	err := c.doWithRetry("GET", path, nil, &response)
+	count := 0
+	err := paginate(ctx, c, fmt.Sprintf("comments of %s PR #%d", repo, prNumber), path, func(page []map[string]interface{}) bool {
+		count += len(page)
+		return true
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get PR comments: %w", err)
 	}
 
-	return len(response), nil
+	return count, nil
 }
 
 // parsePullRequest converts GitHub API response to types.PullRequest
@@ -139,6 +286,63 @@ func (c *Client) parsePullRequest(data map[string]interface{}) (types.PullReques
 		}
 	}
 
+	// Parse closed_at
+	if closedAt, ok := data["closed_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, closedAt); err == nil {
+			pr.ClosedAt = &t
+		}
+	}
+
+	// Parse merged_at; the REST API reports merged pull requests as closed
+	if mergedAt, ok := data["merged_at"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, mergedAt); err == nil {
+			pr.MergedAt = &t
+			pr.State = types.PRStateMerged
+		}
+	}
+
+	// Parse merged_by (only present in single pull request responses)
+	if mergedBy, ok := data["merged_by"].(map[string]interface{}); ok {
+		author := c.parseAuthor(mergedBy)
+		pr.MergedBy = &author
+	}
+
+	// Parse mergeable_state (only present in single pull request responses)
+	pr.HasConflicts = hasConflicts(data)
+
+	// Parse base and head branches
+	if base, ok := data["base"].(map[string]interface{}); ok {
+		if ref, ok := base["ref"].(string); ok {
+			pr.BaseRef = ref
+		}
+	}
+	if head, ok := data["head"].(map[string]interface{}); ok {
+		if ref, ok := head["ref"].(string); ok {
+			pr.HeadRef = ref
+		}
+		if repo, ok := head["repo"].(map[string]interface{}); ok {
+			if name, ok := repo["full_name"].(string); ok {
+				pr.HeadRepo = name
+			}
+		}
+	}
+
+	// Parse draft flag
+	if draft, ok := data["draft"].(bool); ok {
+		pr.Draft = draft
+	}
+
+	// Parse labels
+	if labels, ok := data["labels"].([]interface{}); ok {
+		for _, label := range labels {
+			if labelMap, ok := label.(map[string]interface{}); ok {
+				if name, ok := labelMap["name"].(string); ok {
+					pr.Labels = append(pr.Labels, name)
+				}
+			}
+		}
+	}
+
 	// Parse comments count
 	if comments, ok := data["comments"].(float64); ok {
 		pr.Comments = int(comments)
@@ -0,0 +1,207 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/logger"
+	"github.com/hazadus/gh-repomon/internal/utils"
+)
+
+const (
+	// maxRateLimitRetries limits how many times a single request is repeated after hitting a rate limit
+	maxRateLimitRetries = 5
+	// secondaryLimitWait is the pause after a secondary rate limit without Retry-After,
+	// as recommended by the GitHub documentation
+	secondaryLimitWait = time.Minute
+	// lowRemainingThreshold is the number of remaining requests below which
+	// parallel requests are reduced to a single worker
+	lowRemainingThreshold = 100
+)
+
+// RateLimiter schedules GitHub API requests according to the rate limit
+// headers of previous responses. It is an http.RoundTripper: before each
+// request it waits while the primary limit is exhausted or a secondary limit
+// pause is active, and requests rejected by a rate limit are repeated once
+// the limit resets instead of failing the whole report.
+type RateLimiter struct {
+	transport http.RoundTripper
+
+	mu             sync.Mutex
+	remaining      int
+	reset          time.Time
+	pausedUntil    time.Time
+	secondaryLimit bool
+
+	// now and sleep are replaced in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimiter creates a rate limiter sending requests through transport
+// (http.DefaultTransport if nil)
+func NewRateLimiter(transport http.RoundTripper) *RateLimiter {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &RateLimiter{
+		transport: transport,
+		remaining: -1, // unknown until the first response
+		now:       time.Now,
+		sleep:     utils.Sleep,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (l *RateLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := l.wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err := l.transport.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		l.update(resp.Header)
+
+		wait, secondary, limited := l.limitWait(resp)
+		if !limited || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+		resp.Body.Close()
+
+		kind := "Primary"
+		if secondary {
+			kind = "Secondary"
+		}
+		logger.Warningf("%s GitHub API rate limit reached, waiting %v before retry (attempt %d/%d)",
+			kind, wait.Round(time.Second), attempt+1, maxRateLimitRetries)
+		l.pause(wait, secondary)
+
+		// Requests with a body (GraphQL) need a fresh copy of it
+		if req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// Concurrency returns how many requests should run in parallel, at most max.
+// Requests are serialized once a secondary limit was hit (GitHub triggers
+// those on concurrent requests) or when few requests remain.
+func (l *RateLimiter) Concurrency(max int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.secondaryLimit || (l.remaining >= 0 && l.remaining < lowRemainingThreshold) {
+		return 1
+	}
+	return max
+}
+
+// wait blocks while the rate limit is exhausted
+func (l *RateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := l.now()
+	until := l.pausedUntil
+	if l.remaining == 0 && l.reset.After(until) {
+		until = l.reset
+	}
+	l.mu.Unlock()
+
+	if !until.After(now) {
+		return nil
+	}
+
+	return l.sleep(ctx, until.Sub(now))
+}
+
+// update records the rate limit state reported by a response
+func (l *RateLimiter) update(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.remaining = remaining
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		l.reset = time.Unix(reset, 0)
+	}
+}
+
+// exhaustedUntil returns when the rate limit resets if the last response
+// reported no remaining requests and the reset is still ahead, and the zero
+// time otherwise
+func (l *RateLimiter) exhaustedUntil() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.remaining != 0 || !l.reset.After(l.now()) {
+		return time.Time{}
+	}
+	return l.reset
+}
+
+// pause delays all further requests by d
+func (l *RateLimiter) pause(d time.Duration, secondary bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := l.now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	if secondary {
+		l.secondaryLimit = true
+	}
+}
+
+// limitWait checks whether a response was rejected by a rate limit and how
+// long to wait before repeating the request.
+//   - Retry-After header: secondary limit, wait the given number of seconds
+//   - X-RateLimit-Remaining: 0: primary limit, wait until X-RateLimit-Reset
+//   - "secondary rate limit" message: secondary limit, wait a minute
+func (l *RateLimiter) limitWait(resp *http.Response) (wait time.Duration, secondary bool, limited bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false, false
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second, true, true
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			// Small buffer for clock skew
+			wait := time.Unix(reset, 0).Sub(l.now()) + time.Second
+			if wait < time.Second {
+				wait = time.Second
+			}
+			return wait, false, true
+		}
+	}
+
+	// The body is needed to tell a secondary limit from a permission error
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err == nil && strings.Contains(strings.ToLower(string(body)), "secondary rate limit") {
+		return secondaryLimitWait, true, true
+	}
+
+	return 0, false, false
+}
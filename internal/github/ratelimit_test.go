@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stubResponse is a canned response of sequenceTransport
+type stubResponse struct {
+	status int
+	header map[string]string
+	body   string
+}
+
+// sequenceTransport answers requests with canned responses in order
+type sequenceTransport struct {
+	responses []stubResponse
+	requests  int
+}
+
+func (t *sequenceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := t.responses[t.requests]
+	if t.requests < len(t.responses)-1 {
+		t.requests++
+	}
+
+	header := http.Header{}
+	for k, v := range r.header {
+		header.Set(k, v)
+	}
+
+	return &http.Response{StatusCode: r.status, Header: header, Body: io.NopCloser(strings.NewReader(r.body)), Request: req}, nil
+}
+
+func newTestRateLimiter(transport http.RoundTripper, now time.Time) (*RateLimiter, *[]time.Duration) {
+	var slept []time.Duration
+	l := NewRateLimiter(transport)
+	l.now = func() time.Time { return now }
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	return l, &slept
+}
+
+func TestRateLimiterLimits(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	reset := strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)
+	ok := stubResponse{status: http.StatusOK, header: map[string]string{"X-RateLimit-Remaining": "4000"}, body: "[]"}
+
+	tests := []struct {
+		name            string
+		limited         stubResponse
+		wantWait        time.Duration
+		wantSerialized  bool
+		wantFinalStatus int
+	}{
+		{
+			name:            "Primary limit waits until reset",
+			limited:         stubResponse{status: http.StatusForbidden, header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset}},
+			wantWait:        31 * time.Second,
+			wantFinalStatus: http.StatusOK,
+		},
+		{
+			name:            "Secondary limit with Retry-After",
+			limited:         stubResponse{status: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "5"}},
+			wantWait:        5 * time.Second,
+			wantSerialized:  true,
+			wantFinalStatus: http.StatusOK,
+		},
+		{
+			name:            "Secondary limit from message",
+			limited:         stubResponse{status: http.StatusForbidden, body: `{"message":"You have exceeded a secondary rate limit."}`},
+			wantWait:        time.Minute,
+			wantSerialized:  true,
+			wantFinalStatus: http.StatusOK,
+		},
+		{
+			name:            "Permission error is not retried",
+			limited:         stubResponse{status: http.StatusForbidden, header: map[string]string{"X-RateLimit-Remaining": "4000"}, body: `{"message":"Resource not accessible"}`},
+			wantFinalStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &sequenceTransport{responses: []stubResponse{tt.limited, ok}}
+			l, slept := newTestRateLimiter(transport, now)
+
+			req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r/commits", nil)
+			resp, err := l.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+
+			if resp.StatusCode != tt.wantFinalStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantFinalStatus)
+			}
+
+			if tt.wantWait == 0 {
+				if len(*slept) != 0 {
+					t.Errorf("unexpected waits %v", *slept)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				if !strings.Contains(string(body), "Resource not accessible") {
+					t.Errorf("response body should stay readable, got %q", body)
+				}
+			} else if len(*slept) != 1 || (*slept)[0] != tt.wantWait {
+				t.Errorf("waits = %v, want [%v]", *slept, tt.wantWait)
+			}
+
+			if serialized := l.Concurrency(10) == 1; serialized != tt.wantSerialized {
+				t.Errorf("Concurrency(10) serialized = %v, want %v", serialized, tt.wantSerialized)
+			}
+		})
+	}
+}
+
+func TestRateLimiterWaitsForExhaustedLimit(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	reset := strconv.FormatInt(now.Add(10*time.Minute).Unix(), 10)
+	transport := &sequenceTransport{responses: []stubResponse{
+		{status: http.StatusOK, header: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset}, body: "[]"},
+	}}
+	l, slept := newTestRateLimiter(transport, now)
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r/branches", nil)
+		if _, err := l.RoundTrip(req); err != nil {
+			t.Fatalf("RoundTrip() error = %v", err)
+		}
+	}
+
+	// The second request must wait for the reset announced by the first response
+	if len(*slept) != 1 || (*slept)[0] != 10*time.Minute {
+		t.Errorf("waits = %v, want [10m0s]", *slept)
+	}
+	if l.Concurrency(10) != 1 {
+		t.Errorf("Concurrency(10) = %d, want 1 when no requests remain", l.Concurrency(10))
+	}
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	l := NewRateLimiter(nil)
+	if got := l.Concurrency(10); got != 10 {
+		t.Errorf("Concurrency(10) with unknown limit = %d, want 10", got)
+	}
+
+	l.update(http.Header{"X-Ratelimit-Remaining": []string{"4999"}})
+	if got := l.Concurrency(10); got != 10 {
+		t.Errorf("Concurrency(10) with plenty of requests left = %d, want 10", got)
+	}
+
+	l.update(http.Header{"X-Ratelimit-Remaining": []string{"42"}})
+	if got := l.Concurrency(10); got != 1 {
+		t.Errorf("Concurrency(10) with few requests left = %d, want 1", got)
+	}
+}
@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
+)
+
+// tagLookupLimit caps the number of tags inspected for tags without a release
+const tagLookupLimit = 100
+
+// releaseResponse represents the GitHub API response for a release
+type releaseResponse struct {
+	TagName     string     `json:"tag_name"`
+	Name        string     `json:"name"`
+	Body        string     `json:"body"`
+	Draft       bool       `json:"draft"`
+	Prerelease  bool       `json:"prerelease"`
+	PublishedAt *time.Time `json:"published_at"`
+	HTMLURL     string     `json:"html_url"`
+	Author      struct {
+		Login   string `json:"login"`
+		HTMLURL string `json:"html_url"`
+	} `json:"author"`
+}
+
+// tagResponse represents the GitHub API response for a tag
+type tagResponse struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// GetReleases retrieves releases and tags without a release published during
+// the period, newest first. Each one carries the commits included since the
+// previous release or tag; tags are dated by their commit. A release whose
+// commits cannot be compared is kept without them and marked as such.
+func (c *Client) GetReleases(ctx context.Context, repo string, from, to time.Time) ([]types.Release, error) {
+	releases, err := c.listReleases(ctx, repo, from)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := c.listTagsWithoutRelease(ctx, repo, releases)
+	if err != nil {
+		return nil, err
+	}
+
+	// All releases and tags, newest first, so the previous one of each is the next entry
+	all := append(releases, tags...)
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].PublishedAt.After(all[j].PublishedAt)
+	})
+
+	var result []types.Release
+	for i, release := range all {
+		if release.PublishedAt.Before(from) || release.PublishedAt.After(to) {
+			continue
+		}
+
+		if i+1 < len(all) {
+			release.PreviousTag = all[i+1].TagName
+			compared, err := c.compare(ctx, repo, release.PreviousTag, release.TagName, true)
+			switch {
+			case ctx.Err() != nil:
+				return nil, fmt.Errorf("failed to get commits of release %s: %w", release.TagName, ctx.Err())
+			case err != nil:
+				release.CommitsUnavailable = true
+			default:
+				release.Commits = c.toCommits(compared.Commits)
+				release.CommitsTruncated = compared.Truncated
+			}
+		}
+
+		result = append(result, release)
+	}
+
+	return result, nil
+}
+
+// listReleases lists published releases down to the first one before from,
+// which is needed as the previous release of the oldest release in the period
+func (c *Client) listReleases(ctx context.Context, repo string, from time.Time) ([]types.Release, error) {
+	path := fmt.Sprintf("repos/%s/releases", repo)
+
+	// Releases are listed newest first
+	var releases []types.Release
+	err := paginate(ctx, c, fmt.Sprintf("releases of %s", repo), path, func(page []releaseResponse) bool {
+		more := true
+		for _, r := range page {
+			if r.Draft || r.PublishedAt == nil {
+				continue
+			}
+
+			releases = append(releases, types.Release{
+				TagName:     r.TagName,
+				Name:        r.Name,
+				Body:        r.Body,
+				Author:      types.Author{Login: r.Author.Login, ProfileURL: r.Author.HTMLURL, IsBot: c.isBot(r.Author.Login)},
+				PublishedAt: *r.PublishedAt,
+				Prerelease:  r.Prerelease,
+				URL:         r.HTMLURL,
+			})
+
+			if r.PublishedAt.Before(from) {
+				more = false
+			}
+		}
+		return more
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get releases: %w", err)
+	}
+
+	return releases, nil
+}
+
+// listTagsWithoutRelease lists the most recent tags that have no release,
+// dated by the author date of their commit
+func (c *Client) listTagsWithoutRelease(ctx context.Context, repo string, releases []types.Release) ([]types.Release, error) {
+	released := make(map[string]bool, len(releases))
+	for _, release := range releases {
+		released[release.TagName] = true
+	}
+
+	path := fmt.Sprintf("repos/%s/tags", repo)
+
+	var tags []tagResponse
+	err := paginate(ctx, c, fmt.Sprintf("tags of %s", repo), path, func(page []tagResponse) bool {
+		tags = append(tags, page...)
+		return len(tags) < tagLookupLimit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	if len(tags) > tagLookupLimit {
+		tags = tags[:tagLookupLimit]
+	}
+
+	var unreleased []tagResponse
+	for _, tag := range tags {
+		if !released[tag.Name] {
+			unreleased = append(unreleased, tag)
+		}
+	}
+
+	// Each worker writes only its own element of dated; commits are immutable and cached.
+	// Tags whose commit cannot be fetched are skipped.
+	dated := make([]types.Release, len(unreleased))
+	indexes := make([]int, len(unreleased))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, c.concurrency(5), func(ctx context.Context, i int) error {
+		tag := unreleased[i]
+
+		var commit commitResponse
+		if err := c.doWithRetry(ctx, "GET", fmt.Sprintf("repos/%s/commits/%s", repo, tag.Commit.SHA), nil, &commit); err != nil {
+			return nil
+		}
+
+		dated[i] = types.Release{
+			TagName:     tag.Name,
+			PublishedAt: commit.Commit.Author.Date,
+			TagOnly:     true,
+			URL:         fmt.Sprintf("https://github.com/%s/releases/tag/%s", repo, url.PathEscape(tag.Name)),
+		}
+		return nil
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	var result []types.Release
+	for _, tag := range dated {
+		if !tag.PublishedAt.IsZero() {
+			result = append(result, tag)
+		}
+	}
+
+	return result, nil
+}
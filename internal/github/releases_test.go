@@ -0,0 +1,133 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// routedTransport answers REST requests with canned responses by URL path
+type routedTransport struct {
+	mu       sync.Mutex
+	routes   map[string]string
+	requests []string
+	// heads records the head query parameter of each request
+	heads []string
+}
+
+func (t *routedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req.URL.Path)
+	t.heads = append(t.heads, req.URL.Query().Get("head"))
+	t.mu.Unlock()
+
+	status := http.StatusOK
+	body, ok := t.routes[req.URL.Path]
+	if !ok {
+		status = http.StatusNotFound
+		body = `{"message":"Not Found"}`
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestGetReleases(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/releases": `[
+			{"tag_name":"v1.2.0","name":"Spring release","body":"Notes","draft":false,"prerelease":false,
+				"published_at":"2025-01-10T12:00:00Z","html_url":"https://github.com/o/r/releases/tag/v1.2.0",
+				"author":{"login":"alice","html_url":"https://github.com/alice"}},
+			{"tag_name":"v1.3.0","name":"","body":"","draft":true,"prerelease":false,"published_at":null},
+			{"tag_name":"v1.0.0","name":"v1.0.0","body":"","draft":false,"prerelease":false,
+				"published_at":"2024-12-01T12:00:00Z","html_url":"https://github.com/o/r/releases/tag/v1.0.0",
+				"author":{"login":"alice","html_url":"https://github.com/alice"}}
+		]`,
+		"/repos/o/r/tags": `[
+			{"name":"v1.2.0","commit":{"sha":"ccc"}},
+			{"name":"v1.1.0","commit":{"sha":"bbb"}},
+			{"name":"v1.0.0","commit":{"sha":"aaa"}}
+		]`,
+		"/repos/o/r/commits/bbb": `{"sha":"bbb","commit":{"author":{"name":"Bob","date":"2025-01-05T09:00:00Z"},"message":"Bump version"}}`,
+		"/repos/o/r/compare/v1.1.0...v1.2.0": `{"commits":[
+			{"sha":"c1","commit":{"author":{"name":"Alice","date":"2025-01-08T09:00:00Z"},"message":"Add search (#12)"},
+				"author":{"login":"alice","html_url":"https://github.com/alice"},"html_url":"https://github.com/o/r/commit/c1"},
+			{"sha":"c2","commit":{"author":{"name":"Renovate","date":"2025-01-09T09:00:00Z"},"message":"Update deps"},
+				"author":{"login":"renovate[bot]","html_url":"https://github.com/apps/renovate"},"html_url":"https://github.com/o/r/commit/c2"}
+		]}`,
+		"/repos/o/r/compare/v1.0.0...v1.1.0": `{"commits":[
+			{"sha":"b1","commit":{"author":{"name":"Bob","date":"2025-01-04T09:00:00Z"},"message":"Fix crash"},
+				"author":{"login":"bob","html_url":"https://github.com/bob"},"html_url":"https://github.com/o/r/commit/b1"}
+		]}`,
+	}}
+	c := newTestClient(t, transport, 0)
+	c.excludeBots = true
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	releases, err := c.GetReleases(context.Background(), "o/r", from, to)
+	if err != nil {
+		t.Fatalf("GetReleases() error = %v", err)
+	}
+
+	if len(releases) != 2 {
+		t.Fatalf("got %d releases, want 2 (draft and releases before the period excluded)", len(releases))
+	}
+
+	release := releases[0]
+	if release.TagName != "v1.2.0" || release.TagOnly || release.Author.Login != "alice" || release.PreviousTag != "v1.1.0" {
+		t.Errorf("got first release %+v, want v1.2.0 by alice after v1.1.0", release)
+	}
+	if len(release.Commits) != 1 || release.Commits[0].SHA != "c1" {
+		t.Errorf("got commits %+v, want only c1 (bot commit excluded)", release.Commits)
+	}
+
+	tag := releases[1]
+	if tag.TagName != "v1.1.0" || !tag.TagOnly || tag.PreviousTag != "v1.0.0" {
+		t.Errorf("got second release %+v, want tag v1.1.0 without release after v1.0.0", tag)
+	}
+	if want := time.Date(2025, 1, 5, 9, 0, 0, 0, time.UTC); !tag.PublishedAt.Equal(want) {
+		t.Errorf("got tag date %v, want the commit date %v", tag.PublishedAt, want)
+	}
+	if tag.URL != "https://github.com/o/r/releases/tag/v1.1.0" {
+		t.Errorf("got tag URL %q", tag.URL)
+	}
+}
+
+func TestGetReleasesCompareFailure(t *testing.T) {
+	transport := &routedTransport{routes: map[string]string{
+		"/repos/o/r/releases": `[
+			{"tag_name":"v1.1.0","name":"v1.1.0","body":"","draft":false,"prerelease":false,
+				"published_at":"2025-01-10T12:00:00Z","html_url":"https://github.com/o/r/releases/tag/v1.1.0",
+				"author":{"login":"alice","html_url":"https://github.com/alice"}},
+			{"tag_name":"v1.0.0","name":"v1.0.0","body":"","draft":false,"prerelease":false,
+				"published_at":"2024-12-01T12:00:00Z","html_url":"https://github.com/o/r/releases/tag/v1.0.0",
+				"author":{"login":"alice","html_url":"https://github.com/alice"}}
+		]`,
+		"/repos/o/r/tags": `[]`,
+		// No route for the comparison: it fails with 404
+	}}
+	c := newTestClient(t, transport, 0)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	releases, err := c.GetReleases(context.Background(), "o/r", from, to)
+	if err != nil {
+		t.Fatalf("GetReleases() error = %v, want the release kept without commits", err)
+	}
+
+	if len(releases) != 1 || releases[0].TagName != "v1.1.0" {
+		t.Fatalf("got releases %+v, want v1.1.0", releases)
+	}
+	if !releases[0].CommitsUnavailable || len(releases[0].Commits) != 0 {
+		t.Errorf("got release %+v, want it marked as without commits", releases[0])
+	}
+}
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// GetOrganizationRepositories retrieves all repositories of an organization
+func (c *Client) GetOrganizationRepositories(ctx context.Context, org string) ([]types.Repository, error) {
+	apiPath := fmt.Sprintf("orgs/%s/repos?type=all&sort=full_name", org)
+
+	repos := make([]types.Repository, 0)
+	err := paginate(ctx, c, fmt.Sprintf("repositories of %s", org), apiPath, func(page []types.Repository) bool {
+		repos = append(repos, page...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repositories of %s: %w", org, err)
+	}
+
+	return repos, nil
+}
+
+// GetDefaultBranch retrieves the name of the repository default branch
+func (c *Client) GetDefaultBranch(ctx context.Context, repo string) (string, error) {
+	var response struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := c.doWithRetry(ctx, "GET", fmt.Sprintf("repos/%s", repo), nil, &response); err != nil {
+		return "", fmt.Errorf("failed to get repository %s: %w", repo, err)
+	}
+
+	return response.DefaultBranch, nil
+}
+
+// FilterRepositories selects the names of repositories that carry at least one
+// of the topics (any topic when empty) and whose name without the owner matches
+// the glob pattern (any name when empty). Archived repositories are skipped.
+func FilterRepositories(repos []types.Repository, topics []string, pattern string) ([]string, error) {
+	if pattern != "" {
+		// Validate the pattern once instead of silently matching nothing
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid name pattern %q: %w", pattern, err)
+		}
+	}
+
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		if repo.Archived {
+			continue
+		}
+
+		if len(topics) > 0 && !hasAnyTopic(repo.Topics, topics) {
+			continue
+		}
+
+		if pattern != "" {
+			name := repo.FullName[strings.LastIndex(repo.FullName, "/")+1:]
+			if matched, _ := path.Match(pattern, name); !matched {
+				continue
+			}
+		}
+
+		names = append(names, repo.FullName)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// hasAnyTopic reports whether repoTopics contains one of the wanted topics
+func hasAnyTopic(repoTopics, wanted []string) bool {
+	for _, topic := range repoTopics {
+		for _, w := range wanted {
+			if strings.EqualFold(topic, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
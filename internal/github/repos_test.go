@@ -0,0 +1,72 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestFilterRepositories(t *testing.T) {
+	repos := []types.Repository{
+		{FullName: "org/api-server", Topics: []string{"backend", "go"}},
+		{FullName: "org/web-client", Topics: []string{"frontend"}},
+		{FullName: "org/api-gateway", Topics: []string{"Backend"}},
+		{FullName: "org/api-legacy", Topics: []string{"backend"}, Archived: true},
+		{FullName: "org/docs"},
+	}
+
+	tests := []struct {
+		name    string
+		topics  []string
+		pattern string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "No filters skips archived",
+			want: []string{"org/api-gateway", "org/api-server", "org/docs", "org/web-client"},
+		},
+		{
+			name:   "Topic is case-insensitive",
+			topics: []string{"backend"},
+			want:   []string{"org/api-gateway", "org/api-server"},
+		},
+		{
+			name:   "Any of several topics",
+			topics: []string{"go", "frontend"},
+			want:   []string{"org/api-server", "org/web-client"},
+		},
+		{
+			name:    "Name pattern",
+			pattern: "api-*",
+			want:    []string{"org/api-gateway", "org/api-server"},
+		},
+		{
+			name:    "Topic and pattern",
+			topics:  []string{"backend"},
+			pattern: "*-server",
+			want:    []string{"org/api-server"},
+		},
+		{
+			name:    "Invalid pattern",
+			pattern: "api-[",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterRepositories(repos, tt.topics, tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FilterRepositories() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FilterRepositories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
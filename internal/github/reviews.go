@@ -1,8 +1,10 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/hazadus/gh-repomon/internal/types"
 )
@@ -10,18 +12,23 @@ import (
 // Review represents a simplified code review
 type Review struct {
 	User struct {
-		Login string `json:"login"`
+		Login   string `json:"login"`
+		HTMLURL string `json:"html_url"`
 	} `json:"user"`
 	State       string `json:"state"`
 	SubmittedAt string `json:"submitted_at"`
+	HTMLURL     string `json:"html_url"`
 }
 
 // GetReviews retrieves all reviews for a specific pull request
-func (c *Client) GetReviews(repo string, prNumber int) ([]Review, error) {
+func (c *Client) GetReviews(ctx context.Context, repo string, prNumber int) ([]Review, error) {
 	path := fmt.Sprintf("repos/%s/pulls/%d/reviews", repo, prNumber)
 
 	var reviews []Review
-	err := c.doWithRetry("GET", path, nil, &reviews)
+	err := paginate(ctx, c, fmt.Sprintf("reviews of %s PR #%d", repo, prNumber), path, func(page []Review) bool {
+		reviews = append(reviews, page...)
+		return true
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get reviews for PR #%d: %w", prNumber, err)
 	}
@@ -40,12 +47,56 @@ func (c *Client) GetReviews(repo string, prNumber int) ([]Review, error) {
 	return reviews, nil
 }
 
+// GetPullRequestReviews retrieves all reviews for a pull request converted to types.Review
+func (c *Client) GetPullRequestReviews(ctx context.Context, repo string, prNumber int) ([]types.Review, error) {
+	reviews, err := c.GetReviews(ctx, repo, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Review, 0, len(reviews))
+	for _, review := range reviews {
+		// Pending reviews are drafts visible only to their author
+		if review.State == "PENDING" {
+			continue
+		}
+		result = append(result, c.parseReview(review))
+	}
+
+	return result, nil
+}
+
+// parseReview converts a GitHub API review to types.Review
+func (c *Client) parseReview(review Review) types.Review {
+	result := types.Review{
+		Author: types.Author{
+			Login:      review.User.Login,
+			ProfileURL: review.User.HTMLURL,
+			IsBot:      c.isBot(review.User.Login),
+		},
+		State: review.State,
+		URL:   review.HTMLURL,
+	}
+
+	if result.Author.ProfileURL == "" && result.Author.Login != "" {
+		result.Author.ProfileURL = fmt.Sprintf("https://github.com/%s", result.Author.Login)
+	}
+
+	if review.SubmittedAt != "" {
+		if t, err := time.Parse(time.RFC3339, review.SubmittedAt); err == nil {
+			result.SubmittedAt = t
+		}
+	}
+
+	return result
+}
+
 // GetAllReviews counts the total number of reviews across all provided PRs
-func (c *Client) GetAllReviews(repo string, prs []types.PullRequest) (int, error) {
+func (c *Client) GetAllReviews(ctx context.Context, repo string, prs []types.PullRequest) (int, error) {
 	totalReviews := 0
 
 	for _, pr := range prs {
-		reviews, err := c.GetReviews(repo, pr.Number)
+		reviews, err := c.GetReviews(ctx, repo, pr.Number)
 		if err != nil {
 			// Log error but continue counting other PRs
 			fmt.Fprintf(os.Stderr, "Warning: failed to get reviews for PR #%d: %v\n", pr.Number, err)
@@ -58,11 +109,11 @@ func (c *Client) GetAllReviews(repo string, prs []types.PullRequest) (int, error
 }
 
 // GetReviewsByAuthor groups reviews by reviewer login across all provided PRs
-func (c *Client) GetReviewsByAuthor(repo string, prs []types.PullRequest) (map[string]int, error) {
+func (c *Client) GetReviewsByAuthor(ctx context.Context, repo string, prs []types.PullRequest) (map[string]int, error) {
 	reviewsByAuthor := make(map[string]int)
 
 	for _, pr := range prs {
-		reviews, err := c.GetReviews(repo, pr.Number)
+		reviews, err := c.GetReviews(ctx, repo, pr.Number)
 		if err != nil {
 			// Log error but continue with other PRs
 			fmt.Fprintf(os.Stderr, "Warning: failed to get reviews for PR #%d: %v\n", pr.Number, err)
@@ -77,29 +128,14 @@ func (c *Client) GetReviewsByAuthor(repo string, prs []types.PullRequest) (map[s
 	return reviewsByAuthor, nil
 }
 
-// GetReviewsForPR retrieves reviews for a PR and returns the count
-// This is a helper method that also stores review info in the PR object
-func (c *Client) GetReviewsForPR(repo string, pr *types.PullRequest) error {
-	path := fmt.Sprintf("repos/%s/pulls/%d/reviews", repo, pr.Number)
-
-	var reviewsData []map[string]interface{}
-	err := c.doWithRetry("GET", path, nil, &reviewsData)
+// GetReviewsForPR retrieves reviews for a PR and stores them in the PR object
+func (c *Client) GetReviewsForPR(ctx context.Context, repo string, pr *types.PullRequest) error {
+	reviews, err := c.GetPullRequestReviews(ctx, repo, pr.Number)
 	if err != nil {
-		return fmt.Errorf("failed to get reviews for PR #%d: %w", pr.Number, err)
-	}
-
-	// Count non-bot reviews
-	reviewCount := 0
-	for _, reviewData := range reviewsData {
-		if user, ok := reviewData["user"].(map[string]interface{}); ok {
-			if login, ok := user["login"].(string); ok {
-				if !c.excludeBots || !c.isBot(login) {
-					reviewCount++
-				}
-			}
-		}
+		return err
 	}
 
-	pr.Reviews = reviewCount
+	pr.ReviewDetails = reviews
+	pr.Reviews = len(reviews)
 	return nil
 }
@@ -0,0 +1,150 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sparkBlocks are the characters of a sparkline, from lowest to highest value
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Report is the history of one repository
+type Report struct {
+	// Repository is the repository name (owner/repo)
+	Repository string `json:"repository"`
+	// User is the login the snapshots are focused on (empty for full-repository reports)
+	User string `json:"user,omitempty"`
+	// Interval is the grouping of the points (snapshot, week or month)
+	Interval string `json:"interval"`
+	// Skipped is the number of snapshots left out because their period overlaps another one
+	Skipped int `json:"skipped_snapshots"`
+	// Points is the time series, oldest first
+	Points []Point `json:"points"`
+}
+
+// RenderMarkdown renders the history as Markdown
+func RenderMarkdown(report *Report) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Activity History: %s\n\n", report.Repository))
+	if report.User != "" {
+		sb.WriteString(fmt.Sprintf("**User:** @%s\n\n", report.User))
+	}
+
+	if len(report.Points) == 0 {
+		sb.WriteString("*No snapshots stored for this repository yet.*\n")
+		return sb.String()
+	}
+
+	first, last := report.Points[0], report.Points[len(report.Points)-1]
+	sb.WriteString(fmt.Sprintf("**Period:** %s to %s\n\n",
+		first.Period.From.Format("2006-01-02"), last.Period.To.Format("2006-01-02")))
+
+	sb.WriteString(generateTrendSection(report.Points))
+	sb.WriteString(generateSeriesTable(report.Points))
+
+	if report.Skipped > 0 {
+		sb.WriteString(fmt.Sprintf("*%d snapshot(s) overlapping other periods were left out to avoid counting activity twice.*\n\n", report.Skipped))
+	}
+
+	sb.WriteString("---\n\n")
+	sb.WriteString(fmt.Sprintf("*Generated from %d stored snapshot(s) at %s*\n",
+		countSnapshots(report.Points), time.Now().UTC().Format("2006-01-02 15:04:05 UTC")))
+
+	return sb.String()
+}
+
+// RenderJSON renders the history as JSON
+func RenderJSON(report *Report) (string, error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode history: %w", err)
+	}
+	return string(data), nil
+}
+
+// generateTrendSection creates the sparkline overview of all series
+func generateTrendSection(points []Point) string {
+	var sb strings.Builder
+
+	series := []struct {
+		name  string
+		value func(Point) int
+	}{
+		{"Commits", func(p Point) int { return p.Commits }},
+		{"Merged PRs", func(p Point) int { return p.MergedPRs }},
+		{"Issues Closed", func(p Point) int { return p.IssuesClosed }},
+		{"Contributors", func(p Point) int { return p.Contributors }},
+	}
+
+	sb.WriteString("## 📈 Trends\n\n")
+	sb.WriteString("| Metric | Trend | Min | Max | Latest |\n")
+	sb.WriteString("|--------|-------|-----|-----|--------|\n")
+	for _, s := range series {
+		values := make([]int, len(points))
+		for i, point := range points {
+			values[i] = s.value(point)
+		}
+		low, high := minMax(values)
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %d |\n", s.name, sparkline(values), low, high, values[len(values)-1]))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// generateSeriesTable creates the table with one row per point
+func generateSeriesTable(points []Point) string {
+	var sb strings.Builder
+
+	sb.WriteString("## 📅 Time Series\n\n")
+	sb.WriteString("| Period | Commits | Merged PRs | Issues Closed | Contributors |\n")
+	sb.WriteString("|--------|---------|------------|---------------|--------------|\n")
+	for _, point := range points {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d |\n",
+			point.Label, point.Commits, point.MergedPRs, point.IssuesClosed, point.Contributors))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// sparkline draws values as a line of block characters scaled to the largest value
+func sparkline(values []int) string {
+	_, high := minMax(values)
+
+	var sb strings.Builder
+	for _, value := range values {
+		level := 0
+		if high > 0 {
+			level = value * (len(sparkBlocks) - 1) / high
+		}
+		sb.WriteRune(sparkBlocks[level])
+	}
+
+	return sb.String()
+}
+
+// minMax returns the smallest and largest of values
+func minMax(values []int) (low, high int) {
+	for i, value := range values {
+		if i == 0 || value < low {
+			low = value
+		}
+		if i == 0 || value > high {
+			high = value
+		}
+	}
+	return low, high
+}
+
+// countSnapshots returns the number of snapshots behind points
+func countSnapshots(points []Point) int {
+	total := 0
+	for _, point := range points {
+		total += point.Snapshots
+	}
+	return total
+}
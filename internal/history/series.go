@@ -0,0 +1,140 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// Supported time series intervals
+const (
+	// IntervalSnapshot uses one point per stored snapshot (default)
+	IntervalSnapshot = "snapshot"
+	// IntervalWeek groups snapshots by the ISO week their period starts in
+	IntervalWeek = "week"
+	// IntervalMonth groups snapshots by the calendar month their period starts in
+	IntervalMonth = "month"
+)
+
+// Point is one entry of an activity time series
+type Point struct {
+	// Label identifies the point (period, week or month)
+	Label string `json:"label"`
+	// Period is the time covered by the snapshots of the point
+	Period types.Period `json:"period"`
+	// Snapshots is the number of snapshots combined into the point
+	Snapshots int `json:"snapshots"`
+	// Commits is the number of commits
+	Commits int `json:"commits"`
+	// MergedPRs is the number of merged pull requests
+	MergedPRs int `json:"merged_prs"`
+	// IssuesClosed is the number of closed issues (issue throughput)
+	IssuesClosed int `json:"issues_closed"`
+	// Contributors is the number of unique commit authors
+	Contributors int `json:"contributors"`
+}
+
+// FilterPeriod returns the snapshots whose period lies within from and to.
+// A zero from or to leaves that side open.
+func FilterPeriod(snapshots []types.Snapshot, from, to time.Time) []types.Snapshot {
+	var result []types.Snapshot
+	for _, snapshot := range snapshots {
+		if !from.IsZero() && snapshot.Period.From.Before(from) {
+			continue
+		}
+		if !to.IsZero() && snapshot.Period.To.After(to) {
+			continue
+		}
+		result = append(result, snapshot)
+	}
+	return result
+}
+
+// SelectNonOverlapping drops snapshots whose period overlaps another one, so
+// activity is not counted twice when reports of different lengths were stored
+// (e.g. daily and weekly). Shorter periods are preferred, which keeps the
+// series as fine-grained as possible. The result is ordered by period start.
+func SelectNonOverlapping(snapshots []types.Snapshot) (selected []types.Snapshot, skipped int) {
+	sorted := make([]types.Snapshot, len(snapshots))
+	copy(sorted, snapshots)
+
+	// Picking by earliest end keeps the largest number of periods
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Period.To.Equal(sorted[j].Period.To) {
+			return sorted[i].Period.To.Before(sorted[j].Period.To)
+		}
+		return sorted[i].Period.From.After(sorted[j].Period.From)
+	})
+
+	var lastEnd time.Time
+	for i, snapshot := range sorted {
+		if i > 0 && snapshot.Period.From.Before(lastEnd) {
+			skipped++
+			continue
+		}
+		selected = append(selected, snapshot)
+		lastEnd = snapshot.Period.To
+	}
+
+	return selected, skipped
+}
+
+// BuildSeries turns snapshots ordered by period start into a time series
+// with one point per snapshot, week or month
+func BuildSeries(snapshots []types.Snapshot, interval string) ([]Point, error) {
+	var labelFunc func(types.Period) string
+	switch interval {
+	case "", IntervalSnapshot:
+		labelFunc = func(period types.Period) string {
+			return fmt.Sprintf("%s to %s", period.From.Format("2006-01-02"), period.To.Format("2006-01-02"))
+		}
+	case IntervalWeek:
+		labelFunc = func(period types.Period) string {
+			year, week := period.From.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}
+	case IntervalMonth:
+		labelFunc = func(period types.Period) string {
+			return period.From.Format("2006-01")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported interval %q (expected snapshot, week or month)", interval)
+	}
+
+	var points []Point
+	var contributors []map[string]bool
+	index := make(map[string]int)
+
+	for _, snapshot := range snapshots {
+		label := labelFunc(snapshot.Period)
+
+		i, exists := index[label]
+		if !exists {
+			i = len(points)
+			index[label] = i
+			points = append(points, Point{Label: label, Period: snapshot.Period})
+			contributors = append(contributors, make(map[string]bool))
+		}
+
+		point := &points[i]
+		point.Snapshots++
+		point.Commits += snapshot.Stats.Commits
+		point.MergedPRs += snapshot.Stats.PRsMerged
+		point.IssuesClosed += snapshot.Stats.IssuesClosed
+		if snapshot.Period.From.Before(point.Period.From) {
+			point.Period.From = snapshot.Period.From
+		}
+		if snapshot.Period.To.After(point.Period.To) {
+			point.Period.To = snapshot.Period.To
+		}
+
+		for _, login := range snapshot.Contributors {
+			contributors[i][login] = true
+		}
+		point.Contributors = len(contributors[i])
+	}
+
+	return points, nil
+}
@@ -0,0 +1,141 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestSelectNonOverlapping(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	snapshots := []types.Snapshot{
+		*testSnapshot("owner/repo", "", day(1), 7, 10), // week overlapping the daily snapshots
+		*testSnapshot("owner/repo", "", day(1), 1, 1),
+		*testSnapshot("owner/repo", "", day(2), 1, 2),
+		*testSnapshot("owner/repo", "", day(8), 7, 5),
+	}
+
+	got, skipped := SelectNonOverlapping(snapshots)
+
+	if skipped != 1 {
+		t.Errorf("SelectNonOverlapping() skipped = %d, want 1", skipped)
+	}
+	if len(got) != 3 {
+		t.Fatalf("SelectNonOverlapping() kept %d snapshots, want 3", len(got))
+	}
+	for i, wantCommits := range []int{1, 2, 5} {
+		if got[i].Stats.Commits != wantCommits {
+			t.Errorf("snapshot %d has %d commits, want %d", i, got[i].Stats.Commits, wantCommits)
+		}
+	}
+}
+
+func TestBuildSeries(t *testing.T) {
+	jan1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan15 := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb3 := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	snapshots := []types.Snapshot{
+		{Period: types.Period{From: jan1, To: jan1.AddDate(0, 0, 7)}, Stats: types.PeriodStats{Commits: 3, PRsMerged: 1, IssuesClosed: 2}, Contributors: []string{"alice", "bob"}},
+		{Period: types.Period{From: jan15, To: jan15.AddDate(0, 0, 7)}, Stats: types.PeriodStats{Commits: 4, PRsMerged: 2}, Contributors: []string{"alice"}},
+		{Period: types.Period{From: feb3, To: feb3.AddDate(0, 0, 7)}, Stats: types.PeriodStats{Commits: 1}, Contributors: []string{"carol"}},
+	}
+
+	tests := []struct {
+		name     string
+		interval string
+		want     []Point
+	}{
+		{
+			name:     "per snapshot",
+			interval: IntervalSnapshot,
+			want: []Point{
+				{Label: "2025-01-01 to 2025-01-08", Snapshots: 1, Commits: 3, MergedPRs: 1, IssuesClosed: 2, Contributors: 2},
+				{Label: "2025-01-15 to 2025-01-22", Snapshots: 1, Commits: 4, MergedPRs: 2, Contributors: 1},
+				{Label: "2025-02-03 to 2025-02-10", Snapshots: 1, Commits: 1, Contributors: 1},
+			},
+		},
+		{
+			name:     "per month",
+			interval: IntervalMonth,
+			want: []Point{
+				{Label: "2025-01", Snapshots: 2, Commits: 7, MergedPRs: 3, IssuesClosed: 2, Contributors: 2},
+				{Label: "2025-02", Snapshots: 1, Commits: 1, Contributors: 1},
+			},
+		},
+		{
+			name:     "per week",
+			interval: IntervalWeek,
+			want: []Point{
+				{Label: "2025-W01", Snapshots: 1, Commits: 3, MergedPRs: 1, IssuesClosed: 2, Contributors: 2},
+				{Label: "2025-W03", Snapshots: 1, Commits: 4, MergedPRs: 2, Contributors: 1},
+				{Label: "2025-W06", Snapshots: 1, Commits: 1, Contributors: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildSeries(snapshots, tt.interval)
+			if err != nil {
+				t.Fatalf("BuildSeries() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("BuildSeries() returned %d points, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				got[i].Period = types.Period{}
+				if got[i] != tt.want[i] {
+					t.Errorf("point %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := BuildSeries(snapshots, "year"); err == nil {
+		t.Error("BuildSeries() with unknown interval error = nil, want error")
+	}
+}
+
+func TestFilterPeriod(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := []types.Snapshot{*testSnapshot("owner/repo", "", jan, 7, 1), *testSnapshot("owner/repo", "", feb, 7, 2)}
+
+	if got := FilterPeriod(snapshots, feb, time.Time{}); len(got) != 1 || got[0].Stats.Commits != 2 {
+		t.Errorf("FilterPeriod(from February) = %+v, want the February snapshot", got)
+	}
+	if got := FilterPeriod(snapshots, time.Time{}, feb); len(got) != 1 || got[0].Stats.Commits != 1 {
+		t.Errorf("FilterPeriod(to February) = %+v, want the January snapshot", got)
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	got := RenderMarkdown(&Report{
+		Repository: "owner/repo",
+		Skipped:    1,
+		Points: []Point{
+			{Label: "2025-01", Snapshots: 2, Commits: 0, MergedPRs: 1, Contributors: 1},
+			{Label: "2025-02", Snapshots: 1, Commits: 14, MergedPRs: 2, Contributors: 3},
+		},
+	})
+
+	for _, want := range []string{
+		"# Activity History: owner/repo",
+		"| Commits | ▁█ | 0 | 14 | 14 |",
+		"| 2025-02 | 14 | 2 | 0 | 3 |",
+		"1 snapshot(s) overlapping other periods were left out",
+		"Generated from 3 stored snapshot(s)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderMarkdown() missing %q\n%s", want, got)
+		}
+	}
+
+	if got := RenderMarkdown(&Report{Repository: "owner/repo"}); !strings.Contains(got, "No snapshots stored") {
+		t.Errorf("RenderMarkdown() without points = %q, want a notice", got)
+	}
+}
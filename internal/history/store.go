@@ -0,0 +1,156 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// namePattern matches repository owners, repository names and user logins
+// that are safe to use as file and directory names
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// Store keeps report snapshots on disk as JSON files, one per repository,
+// period and user: <dir>/<owner>/<repo>/<from>_<to>[_<user>].json.
+// Saving a report for the same key again replaces the previous snapshot.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a snapshot store in dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the default location of the snapshot store
+func DefaultDir() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "gh-repomon", "history")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "gh-repomon", "history")
+	}
+	return filepath.Join(home, ".local", "share", "gh-repomon", "history")
+}
+
+// Save stores a snapshot, replacing an existing one with the same key
+func (s *Store) Save(snapshot *types.Snapshot) error {
+	dir, err := s.repoDir(snapshot.Repository)
+	if err != nil {
+		return err
+	}
+	name, err := snapshotFileName(snapshot.Period, snapshot.User)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	// Write atomically so a concurrent reader never sees a partial file
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(dir, name))
+}
+
+// List returns the snapshots of repo for user (empty for full-repository
+// reports), ordered by the start of their period. Unreadable files are skipped.
+func (s *Store) List(repo, user string) ([]types.Snapshot, error) {
+	dir, err := s.repoDir(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	var snapshots []types.Snapshot
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		var snapshot types.Snapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			continue
+		}
+		if !strings.EqualFold(snapshot.User, user) {
+			continue
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		if !snapshots[i].Period.From.Equal(snapshots[j].Period.From) {
+			return snapshots[i].Period.From.Before(snapshots[j].Period.From)
+		}
+		return snapshots[i].Period.To.Before(snapshots[j].Period.To)
+	})
+
+	return snapshots, nil
+}
+
+// repoDir returns the directory holding the snapshots of repo
+func (s *Store) repoDir(repo string) (string, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 || !isSafeName(parts[0]) || !isSafeName(parts[1]) {
+		return "", fmt.Errorf("invalid repository name %q (expected owner/repo)", repo)
+	}
+
+	return filepath.Join(s.dir, strings.ToLower(parts[0]), strings.ToLower(parts[1])), nil
+}
+
+// snapshotFileName returns the file name of the snapshot of period and user
+func snapshotFileName(period types.Period, user string) (string, error) {
+	name := period.From.UTC().Format("2006-01-02") + "_" + period.To.UTC().Format("2006-01-02")
+	if user != "" {
+		if !isSafeName(user) {
+			return "", fmt.Errorf("invalid user login %q", user)
+		}
+		name += "_" + strings.ToLower(user)
+	}
+
+	return name + ".json", nil
+}
+
+// isSafeName reports whether name can be used as a path element
+func isSafeName(name string) bool {
+	return namePattern.MatchString(name) && name != "." && name != ".."
+}
@@ -0,0 +1,112 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func testSnapshot(repo, user string, from time.Time, days, commits int) *types.Snapshot {
+	period := types.Period{From: from, To: from.AddDate(0, 0, days)}
+	return &types.Snapshot{
+		Repository: repo,
+		Period:     period,
+		User:       user,
+		Stats:      types.PeriodStats{Commits: commits},
+		Report:     &types.ReportData{Repository: repo, Period: period, User: user},
+	}
+}
+
+func TestStoreSaveAndList(t *testing.T) {
+	store := NewStore(t.TempDir())
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, snapshot := range []*types.Snapshot{
+		testSnapshot("Owner/Repo", "", feb, 7, 5),
+		testSnapshot("owner/repo", "", jan, 7, 3),
+		testSnapshot("owner/repo", "alice", jan, 7, 1),
+		testSnapshot("owner/other", "", jan, 7, 9),
+	} {
+		if err := store.Save(snapshot); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	got, err := store.List("owner/repo", "")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("List() returned %d snapshots, want 2", len(got))
+	}
+	if !got[0].Period.From.Equal(jan) || !got[1].Period.From.Equal(feb) {
+		t.Errorf("List() order = %v, %v, want January before February", got[0].Period.From, got[1].Period.From)
+	}
+	if got[0].Report == nil || got[0].Report.Repository != "owner/repo" {
+		t.Errorf("List() did not restore the report data: %+v", got[0].Report)
+	}
+
+	userSnapshots, err := store.List("owner/repo", "Alice")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(userSnapshots) != 1 || userSnapshots[0].Stats.Commits != 1 {
+		t.Errorf("List() for alice = %+v, want the single user snapshot", userSnapshots)
+	}
+}
+
+func TestStoreSaveReplacesSamePeriod(t *testing.T) {
+	store := NewStore(t.TempDir())
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := store.Save(testSnapshot("owner/repo", "", jan, 7, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(testSnapshot("owner/repo", "", jan, 7, 4)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.List("owner/repo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Stats.Commits != 4 {
+		t.Errorf("List() = %+v, want one snapshot with the latest data", got)
+	}
+}
+
+func TestStoreListEmpty(t *testing.T) {
+	got, err := NewStore(t.TempDir()).List("owner/repo", "")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("List() returned %d snapshots, want 0", len(got))
+	}
+}
+
+func TestStoreRejectsUnsafeNames(t *testing.T) {
+	store := NewStore(t.TempDir())
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		repo string
+		user string
+	}{
+		{name: "missing owner", repo: "repo"},
+		{name: "parent directory", repo: "../repo"},
+		{name: "nested path", repo: "owner/repo/extra"},
+		{name: "user with separator", repo: "owner/repo", user: "a/b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := store.Save(testSnapshot(tt.repo, tt.user, jan, 7, 1)); err == nil {
+				t.Errorf("Save(%q, %q) error = nil, want error", tt.repo, tt.user)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/hazadus/gh-repomon/internal/errors"
 	"github.com/hazadus/gh-repomon/internal/logger"
+	"github.com/hazadus/gh-repomon/internal/utils"
 )
 
 const GitHubModelsEndpoint = "https://models.inference.ai.azure.com"
@@ -86,12 +87,13 @@ func NewClient() (*Client, error) {
 
 // Complete sends a chat completion request and returns the response text
 // Automatically retries on rate limit errors with exponential backoff
-func (c *Client) Complete(request ChatCompletionRequest) (string, error) {
+// Waiting between retries stops as soon as ctx is done
+func (c *Client) Complete(ctx context.Context, request ChatCompletionRequest) (string, error) {
 	var lastErr error
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Create context with 30 second timeout for each attempt
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		attemptCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 
 		// Prepare request body
 		requestBody, err := json.Marshal(request)
@@ -102,7 +104,7 @@ func (c *Client) Complete(request ChatCompletionRequest) (string, error) {
 
 		// Create HTTP request with context
 		url := c.endpoint + "/chat/completions"
-		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
+		req, err := http.NewRequestWithContext(attemptCtx, "POST", url, bytes.NewBuffer(requestBody))
 		if err != nil {
 			cancel()
 			return "", errors.NewLLMAPIError("failed to create request", 0, err)
@@ -117,8 +119,12 @@ func (c *Client) Complete(request ChatCompletionRequest) (string, error) {
 		resp, err := client.Do(req)
 		if err != nil {
 			cancel()
+			// The whole run was cancelled or timed out
+			if ctx.Err() != nil {
+				return "", errors.NewLLMAPIError("request cancelled", 0, ctx.Err())
+			}
 			// Check if it's a timeout error
-			if ctx.Err() == context.DeadlineExceeded {
+			if attemptCtx.Err() == context.DeadlineExceeded {
 				return "", errors.NewLLMAPIError("request timeout", 0, err)
 			}
 			return "", errors.NewLLMAPIError("failed to send request", 0, err)
@@ -143,7 +149,9 @@ func (c *Client) Complete(request ChatCompletionRequest) (string, error) {
 
 					if attempt < maxRetries {
 						logger.Warningf("Rate limit reached, waiting %v before retry (attempt %d/%d)", waitTime, attempt+1, maxRetries)
-						time.Sleep(waitTime)
+						if err := utils.Sleep(ctx, waitTime); err != nil {
+							return "", errors.NewLLMAPIError("request cancelled", 0, err)
+						}
 						continue
 					}
 				}
@@ -156,7 +164,9 @@ func (c *Client) Complete(request ChatCompletionRequest) (string, error) {
 			if resp.StatusCode >= 500 && attempt < maxRetries {
 				delay := baseDelay * time.Duration(1<<uint(attempt))
 				logger.Warningf("Server error (status %d), retrying in %v (attempt %d/%d)", resp.StatusCode, delay, attempt+1, maxRetries)
-				time.Sleep(delay)
+				if err := utils.Sleep(ctx, delay); err != nil {
+					return "", errors.NewLLMAPIError("request cancelled", 0, err)
+				}
 				continue
 			}
 
@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -8,7 +9,7 @@ import (
 )
 
 // GenerateOverallSummary generates an AI summary of overall repository activity
-func (c *Client) GenerateOverallSummary(data *types.ReportData, language, model string) (string, error) {
+func (c *Client) GenerateOverallSummary(ctx context.Context, data *types.ReportData, language, model string) (string, error) {
 	// Load prompt
 	config, err := LoadPrompt("overall_summary")
 	if err != nil {
@@ -25,6 +26,7 @@ func (c *Client) GenerateOverallSummary(data *types.ReportData, language, model
 		"branches":      formatBranchesForPrompt(data.Branches),
 		"prs":           formatPRsForPrompt(data.OpenPRs, data.UpdatedPRs),
 		"issues":        formatIssuesForPrompt(data.OpenIssues, data.ClosedIssues),
+		"trends":        formatTrendsForPrompt(data.Comparison),
 	}
 
 	// Render prompt
@@ -47,7 +49,7 @@ func (c *Client) GenerateOverallSummary(data *types.ReportData, language, model
 	}
 
 	// Send request
-	response, err := c.Complete(request)
+	response, err := c.Complete(ctx, request)
 	if err != nil {
 		return "Summary generation failed. Please check the activity details below.", fmt.Errorf("failed to complete request: %w", err)
 	}
@@ -55,6 +57,26 @@ func (c *Client) GenerateOverallSummary(data *types.ReportData, language, model
 	return response, nil
 }
 
+// formatTrendsForPrompt formats the comparison with the previous period for the prompt
+func formatTrendsForPrompt(comparison *types.PeriodComparison) string {
+	if comparison == nil {
+		return "No comparison with the previous period available."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Previous period: %s\n", formatPeriod(comparison.PreviousPeriod)))
+	for _, metric := range comparison.Metrics() {
+		diff, percent, ok := metric.Change()
+		if ok {
+			sb.WriteString(fmt.Sprintf("- %s: %d (previous %d, %+d, %+.0f%%)\n", metric.Name, metric.Current, metric.Previous, diff, percent))
+		} else {
+			sb.WriteString(fmt.Sprintf("- %s: %d (previous %d, %+d)\n", metric.Name, metric.Current, metric.Previous, diff))
+		}
+	}
+
+	return sb.String()
+}
+
 // formatPeriod formats a period for display
 func formatPeriod(period types.Period) string {
 	return fmt.Sprintf("%s to %s", period.From.Format("2006-01-02"), period.To.Format("2006-01-02"))
@@ -132,7 +154,7 @@ func formatIssuesForPrompt(openIssues, closedIssues []types.Issue) string {
 }
 
 // GenerateBranchSummary generates an AI summary for a single branch
-func (c *Client) GenerateBranchSummary(branch *types.Branch, language, model string) (string, error) {
+func (c *Client) GenerateBranchSummary(ctx context.Context, branch *types.Branch, language, model string) (string, error) {
 	// Load prompt
 	config, err := LoadPrompt("branch_summary")
 	if err != nil {
@@ -168,7 +190,7 @@ func (c *Client) GenerateBranchSummary(branch *types.Branch, language, model str
 	}
 
 	// Send request
-	response, err := c.Complete(request)
+	response, err := c.Complete(ctx, request)
 	if err != nil {
 		return fmt.Sprintf("Development activity in branch %s", branch.Name), fmt.Errorf("failed to complete request: %w", err)
 	}
@@ -207,7 +229,7 @@ func formatCommitMessagesForPrompt(commits []types.Commit) string {
 }
 
 // GeneratePRSummary generates an AI summary for a single pull request
-func (c *Client) GeneratePRSummary(pr *types.PullRequest, language, model string) (string, error) {
+func (c *Client) GeneratePRSummary(ctx context.Context, pr *types.PullRequest, language, model string) (string, error) {
 	// Load prompt
 	config, err := LoadPrompt("pr_summary")
 	if err != nil {
@@ -251,10 +273,91 @@ func (c *Client) GeneratePRSummary(pr *types.PullRequest, language, model string
 	}
 
 	// Send request
-	response, err := c.Complete(request)
+	response, err := c.Complete(ctx, request)
 	if err != nil {
 		return fmt.Sprintf("Pull request: %s", pr.Title), fmt.Errorf("failed to complete request: %w", err)
 	}
 
 	return response, nil
 }
+
+// GenerateReleaseSummary generates an AI summary of what a release ships
+func (c *Client) GenerateReleaseSummary(ctx context.Context, release *types.Release, language, model string) (string, error) {
+	fallback := fmt.Sprintf("Release %s", release.TagName)
+
+	// Load prompt
+	config, err := LoadPrompt("release_summary")
+	if err != nil {
+		return fallback, fmt.Errorf("failed to load prompt: %w", err)
+	}
+
+	// Prepare release notes (limit to 1000 characters if too long)
+	notes := release.Body
+	if len(notes) > 1000 {
+		notes = notes[:997] + "..."
+	}
+	if notes == "" {
+		notes = "(no release notes provided)"
+	}
+
+	name := release.TagName
+	if release.Name != "" && release.Name != release.TagName {
+		name += " (" + release.Name + ")"
+	}
+
+	previousTag := release.PreviousTag
+	if previousTag == "" {
+		previousTag = "(none)"
+	}
+
+	// Prepare variables
+	vars := map[string]string{
+		"language":        language,
+		"release_name":    name,
+		"previous_tag":    previousTag,
+		"release_notes":   notes,
+		"pull_requests":   formatReleasePRsForPrompt(release.PullRequests),
+		"commit_messages": formatCommitMessagesForPrompt(release.Commits),
+	}
+
+	// Render prompt
+	rendered, err := RenderPrompt(config, vars)
+	if err != nil {
+		return fallback, fmt.Errorf("failed to render prompt: %w", err)
+	}
+
+	// Convert prompt messages to chat messages
+	messages := make([]Message, len(rendered.Messages))
+	for i, msg := range rendered.Messages {
+		messages[i] = Message(msg)
+	}
+
+	// Create request
+	request := ChatCompletionRequest{
+		Model:       model,
+		Messages:    messages,
+		Temperature: rendered.ModelParameters.Temperature,
+	}
+
+	// Send request
+	response, err := c.Complete(ctx, request)
+	if err != nil {
+		return fallback, fmt.Errorf("failed to complete request: %w", err)
+	}
+
+	return response, nil
+}
+
+// formatReleasePRsForPrompt formats the pull requests of a release for inclusion in prompt
+func formatReleasePRsForPrompt(prs []types.PullRequest) string {
+	if len(prs) == 0 {
+		return "No pull requests"
+	}
+
+	var parts []string
+	for _, pr := range prs {
+		parts = append(parts, fmt.Sprintf("- #%d: %s (by %s)", pr.Number, pr.Title, pr.Author.Login))
+	}
+
+	return strings.Join(parts, "\n")
+}
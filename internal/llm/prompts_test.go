@@ -267,7 +267,7 @@ func TestRenderPrompt_PreservesStructure(t *testing.T) {
 
 func TestLoadPrompt_FromEmbedded(t *testing.T) {
 	// Test loading real prompt files that should be embedded
-	promptNames := []string{"overall_summary", "branch_summary", "pr_summary"}
+	promptNames := []string{"overall_summary", "branch_summary", "pr_summary", "release_summary"}
 
 	for _, name := range promptNames {
 		t.Run(name, func(t *testing.T) {
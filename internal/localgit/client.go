@@ -0,0 +1,443 @@
+package localgit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/errors"
+	"github.com/hazadus/gh-repomon/internal/github"
+	"github.com/hazadus/gh-repomon/internal/report"
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// Client collects branches and commits from a local git working copy.
+// Pull requests, reviews and issues are delegated to the embedded GitHub
+// API client, so commit collection needs no API calls at all.
+type Client struct {
+	report.GitHubClient
+	path        string
+	excludeBots bool
+}
+
+// NewClient creates a collector for the git repository at path.
+// api is used for everything that is not stored in git.
+func NewClient(path string, api report.GitHubClient, excludeBots bool) (*Client, error) {
+	c := &Client{
+		GitHubClient: api,
+		path:         path,
+		excludeBots:  excludeBots,
+	}
+
+	if _, err := c.git(context.Background(), "rev-parse", "--git-dir"); err != nil {
+		return nil, errors.NewInvalidParamsError("local-path", fmt.Sprintf("%s is not a git repository: %v", path, err))
+	}
+
+	return c, nil
+}
+
+// Field and record separators used in git log output
+const (
+	fieldSeparator  = "\x1f"
+	recordSeparator = "\x1e"
+)
+
+// logFormat prints hash, author name, author email, author date and the
+// full message of each commit; numstat lines follow the last separator
+const logFormat = "--format=" + recordSeparator + "%H" + fieldSeparator + "%an" + fieldSeparator + "%ae" + fieldSeparator + "%aI" + fieldSeparator + "%B" + fieldSeparator
+
+// headFormat prints ref name, commit hash, author name, author email and
+// author date of the commit each branch points to
+const headFormat = "--format=%(refname)" + fieldSeparator + "%(objectname)" + fieldSeparator + "%(authorname)" + fieldSeparator + "%(authoremail)" + fieldSeparator + "%(authordate:iso-strict)"
+
+// GetActiveBranches retrieves branches that have commits during the specified period
+func (c *Client) GetActiveBranches(ctx context.Context, repo string, from, to time.Time) ([]types.Branch, error) {
+	refs, err := c.branchRefs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	activeBranches := make([]types.Branch, 0)
+
+	for _, ref := range refs {
+		commits, err := c.GetCommits(ctx, repo, ref.ref, from, to)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, fmt.Errorf("failed to get active branches: %w", ctxErr)
+			}
+			// If we can't get commits for a branch, skip it but don't fail
+			continue
+		}
+
+		// Skip branches with no activity
+		if len(commits) == 0 {
+			continue
+		}
+
+		activeBranches = append(activeBranches, github.NewBranch(ref.name, commits))
+	}
+
+	return activeBranches, nil
+}
+
+// GetCommits retrieves commits reachable from ref during the specified period
+func (c *Client) GetCommits(ctx context.Context, repo, ref string, from, to time.Time) ([]types.Commit, error) {
+	out, err := c.git(ctx, "log", ref,
+		"--since="+from.Format(time.RFC3339),
+		"--until="+to.Format(time.RFC3339),
+		"--numstat",
+		logFormat,
+		"--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	commits := make([]types.Commit, 0)
+	for _, commit := range parseLog(out) {
+		// Check if we should filter out bots
+		if c.excludeBots && commit.Author.IsBot {
+			continue
+		}
+
+		if repo != "" {
+			commit.URL = fmt.Sprintf("https://github.com/%s/commit/%s", repo, commit.SHA)
+		}
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
+
+// GetBranchHeads retrieves the latest commit of every branch
+func (c *Client) GetBranchHeads(ctx context.Context, repo string) ([]types.BranchHead, error) {
+	refs, err := c.branchRefs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	out, err := c.git(ctx, "for-each-ref", headFormat, "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch heads: %w", err)
+	}
+
+	fieldsByRef := make(map[string][]string)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, fieldSeparator)
+		if len(fields) == 5 {
+			fieldsByRef[fields[0]] = fields
+		}
+	}
+
+	heads := make([]types.BranchHead, 0, len(refs))
+	for _, ref := range refs {
+		fields, ok := fieldsByRef[ref.ref]
+		if !ok {
+			continue
+		}
+
+		author := commitAuthor(fields[2], strings.Trim(fields[3], "<>"))
+		if c.excludeBots && author.IsBot {
+			continue
+		}
+
+		date, _ := time.Parse(time.RFC3339, fields[4])
+		heads = append(heads, types.BranchHead{
+			Name:        ref.name,
+			SHA:         fields[1],
+			Author:      author,
+			CommittedAt: date,
+		})
+	}
+
+	return heads, nil
+}
+
+// CompareBranches compares head with base in the local repository and lists
+// the commits of head that are not on base
+func (c *Client) CompareBranches(ctx context.Context, repo, base, head string) (*types.BranchComparison, error) {
+	refs, err := c.branchRefs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %w", err)
+	}
+
+	byName := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		byName[ref.name] = ref.ref
+	}
+	baseRef, headRef := byName[base], byName[head]
+	if baseRef == "" || headRef == "" {
+		return nil, fmt.Errorf("failed to compare %s with %s: branch not found", head, base)
+	}
+
+	// Counts are printed as "<only on base>\t<only on head>"
+	out, err := c.git(ctx, "rev-list", "--left-right", "--count", baseRef+"..."+headRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", head, base, err)
+	}
+	counts := strings.Fields(out)
+	if len(counts) != 2 {
+		return nil, fmt.Errorf("failed to compare %s with %s: unexpected output %q", head, base, out)
+	}
+
+	comparison := &types.BranchComparison{}
+	comparison.Behind, _ = strconv.Atoi(counts[0])
+	comparison.Ahead, _ = strconv.Atoi(counts[1])
+
+	out, err = c.git(ctx, "rev-list", baseRef+".."+headRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with %s: %w", head, base, err)
+	}
+	comparison.Commits = strings.Fields(out)
+
+	return comparison, nil
+}
+
+// CountBranchCommits compares head with base in the local repository; listing
+// the commits costs no API requests, so it is the same as CompareBranches
+func (c *Client) CountBranchCommits(ctx context.Context, repo, base, head string) (*types.BranchComparison, error) {
+	return c.CompareBranches(ctx, repo, base, head)
+}
+
+// TruncatedResources reports listings cut by the API client's item cap
+func (c *Client) TruncatedResources() []string {
+	if reporter, ok := c.GitHubClient.(interface{ TruncatedResources() []string }); ok {
+		return reporter.TruncatedResources()
+	}
+	return nil
+}
+
+// GetReleases retrieves releases and tags through the API client, if it supports them
+func (c *Client) GetReleases(ctx context.Context, repo string, from, to time.Time) ([]types.Release, error) {
+	if collector, ok := c.GitHubClient.(interface {
+		GetReleases(ctx context.Context, repo string, from, to time.Time) ([]types.Release, error)
+	}); ok {
+		return collector.GetReleases(ctx, repo, from, to)
+	}
+	return nil, nil
+}
+
+// GetDeployments retrieves deployments through the API client, if it supports them
+func (c *Client) GetDeployments(ctx context.Context, repo string, from, to time.Time) ([]types.Deployment, error) {
+	if collector, ok := c.GitHubClient.(interface {
+		GetDeployments(ctx context.Context, repo string, from, to time.Time) ([]types.Deployment, error)
+	}); ok {
+		return collector.GetDeployments(ctx, repo, from, to)
+	}
+	return nil, nil
+}
+
+// GetWorkflowRuns retrieves workflow runs through the API client, if it supports them
+func (c *Client) GetWorkflowRuns(ctx context.Context, repo string, from, to time.Time) ([]types.WorkflowRun, error) {
+	if collector, ok := c.GitHubClient.(interface {
+		GetWorkflowRuns(ctx context.Context, repo string, from, to time.Time) ([]types.WorkflowRun, error)
+	}); ok {
+		return collector.GetWorkflowRuns(ctx, repo, from, to)
+	}
+	return nil, nil
+}
+
+// GetConflictingPullRequests looks up merge conflicts through the API client, if it supports it
+func (c *Client) GetConflictingPullRequests(ctx context.Context, repo string, numbers []int) ([]int, error) {
+	if checker, ok := c.GitHubClient.(interface {
+		GetConflictingPullRequests(ctx context.Context, repo string, numbers []int) ([]int, error)
+	}); ok {
+		return checker.GetConflictingPullRequests(ctx, repo, numbers)
+	}
+	return nil, nil
+}
+
+// GetMergedPullRequests looks up the merged pull requests of a branch through the API client, if it supports it
+func (c *Client) GetMergedPullRequests(ctx context.Context, repo, branch string) ([]types.PullRequest, error) {
+	if finder, ok := c.GitHubClient.(interface {
+		GetMergedPullRequests(ctx context.Context, repo, branch string) ([]types.PullRequest, error)
+	}); ok {
+		return finder.GetMergedPullRequests(ctx, repo, branch)
+	}
+	return nil, nil
+}
+
+// GetDefaultBranch retrieves the default branch through the API client, if it supports it
+func (c *Client) GetDefaultBranch(ctx context.Context, repo string) (string, error) {
+	if getter, ok := c.GitHubClient.(interface {
+		GetDefaultBranch(ctx context.Context, repo string) (string, error)
+	}); ok {
+		return getter.GetDefaultBranch(ctx, repo)
+	}
+	return "", nil
+}
+
+// branchRef is a branch name together with the ref it is read from
+type branchRef struct {
+	name string
+	ref  string
+}
+
+// branchRefs lists local branches and remote-tracking branches that have no
+// local counterpart, so both mirrors and regular clones are covered
+func (c *Client) branchRefs(ctx context.Context) ([]branchRef, error) {
+	out, err := c.git(ctx, "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var local, remote []branchRef
+
+	for _, refname := range strings.Split(strings.TrimSpace(out), "\n") {
+		switch {
+		case strings.HasPrefix(refname, "refs/heads/"):
+			name := strings.TrimPrefix(refname, "refs/heads/")
+			seen[name] = true
+			local = append(local, branchRef{name: name, ref: refname})
+		case strings.HasPrefix(refname, "refs/remotes/"):
+			// refs/remotes/<remote>/<branch>
+			parts := strings.SplitN(strings.TrimPrefix(refname, "refs/remotes/"), "/", 2)
+			if len(parts) != 2 || parts[1] == "HEAD" {
+				continue
+			}
+			remote = append(remote, branchRef{name: parts[1], ref: refname})
+		}
+	}
+
+	refs := local
+	for _, ref := range remote {
+		if seen[ref.name] {
+			continue
+		}
+		seen[ref.name] = true
+		refs = append(refs, ref)
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].name < refs[j].name
+	})
+
+	return refs, nil
+}
+
+// git runs a git command in the repository and returns its standard output
+func (c *Client) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", c.path}, args...)...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+
+	return stdout.String(), nil
+}
+
+// parseLog parses git log output produced with logFormat and --numstat
+func parseLog(out string) []types.Commit {
+	var commits []types.Commit
+
+	for _, record := range strings.Split(out, recordSeparator) {
+		fields := strings.Split(record, fieldSeparator)
+		if len(fields) < 6 {
+			continue
+		}
+
+		name, email := fields[1], fields[2]
+		date, _ := time.Parse(time.RFC3339, fields[3])
+		additions, deletions, files := parseNumstat(fields[5])
+
+		commits = append(commits, types.Commit{
+			SHA:       fields[0],
+			Message:   strings.TrimSpace(fields[4]),
+			Author:    commitAuthor(name, email),
+			Date:      date,
+			Additions: additions,
+			Deletions: deletions,
+			Files:     files,
+		})
+	}
+
+	return commits
+}
+
+// parseNumstat sums added and deleted lines from git --numstat output and
+// lists the changed files. Binary files are reported as "-" and count as zero.
+func parseNumstat(numstat string) (additions, deletions int, files []types.FileChange) {
+	for _, line := range strings.Split(numstat, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		added, _ := strconv.Atoi(parts[0])
+		deleted, _ := strconv.Atoi(parts[1])
+		additions += added
+		deletions += deleted
+		files = append(files, types.FileChange{
+			Path:      renamedPath(parts[2]),
+			Additions: added,
+			Deletions: deleted,
+		})
+	}
+
+	return additions, deletions, files
+}
+
+// renamedPath returns the new path of a numstat entry, which git prints as
+// "old => new" or "dir/{old => new}/file" for renamed files
+func renamedPath(path string) string {
+	before, after, found := strings.Cut(path, " => ")
+	if !found {
+		return path
+	}
+
+	open := strings.LastIndex(before, "{")
+	end := strings.Index(after, "}")
+	if open < 0 || end < 0 {
+		return after
+	}
+
+	// An empty side of the braces leaves a double slash, e.g. "a/{ => b}/c"
+	return strings.ReplaceAll(before[:open]+after[:end]+after[end+1:], "//", "/")
+}
+
+// commitAuthor builds the author of a commit. The GitHub login is recovered
+// from noreply addresses; otherwise the author name is used, matching how
+// the API client handles commits without a linked account.
+func commitAuthor(name, email string) types.Author {
+	author := types.Author{
+		Login: name,
+		Name:  name,
+	}
+
+	if login := loginFromEmail(email); login != "" {
+		author.Login = login
+		author.ProfileURL = fmt.Sprintf("https://github.com/%s", login)
+	}
+	author.IsBot = github.IsBot(author.Login)
+
+	return author
+}
+
+// loginFromEmail extracts the login from a GitHub noreply address
+// (login@users.noreply.github.com or 12345+login@users.noreply.github.com)
+func loginFromEmail(email string) string {
+	local, found := strings.CutSuffix(strings.ToLower(email), "@users.noreply.github.com")
+	if !found {
+		return ""
+	}
+
+	// Keep the original case of the login
+	login := email[:len(local)]
+	if i := strings.Index(login, "+"); i >= 0 {
+		login = login[i+1:]
+	}
+
+	return login
+}
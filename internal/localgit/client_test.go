@@ -0,0 +1,258 @@
+package localgit
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoginFromEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{
+			name:  "Noreply with ID",
+			email: "12345+Octocat@users.noreply.github.com",
+			want:  "Octocat",
+		},
+		{
+			name:  "Legacy noreply",
+			email: "octocat@users.noreply.github.com",
+			want:  "octocat",
+		},
+		{
+			name:  "Bot noreply",
+			email: "49699333+dependabot[bot]@users.noreply.github.com",
+			want:  "dependabot[bot]",
+		},
+		{
+			name:  "Regular email",
+			email: "dev@example.com",
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loginFromEmail(tt.email); got != tt.want {
+				t.Errorf("loginFromEmail(%q) = %q, want %q", tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLog(t *testing.T) {
+	out := "\x1eaaa\x1fDev One\x1f1+dev1@users.noreply.github.com\x1f2024-01-02T10:00:00+00:00\x1fAdd feature\n\nDetails\n\x1f\n\n10\t2\tmain.go\n-\t-\tlogo.png\n3\t0\tREADME.md\n" +
+		"\x1ebbb\x1fFormer Dev\x1fformer@example.com\x1f2024-01-01T09:00:00+00:00\x1fInitial commit\n\x1f\n"
+
+	commits := parseLog(out)
+	if len(commits) != 2 {
+		t.Fatalf("parseLog() returned %d commits, want 2", len(commits))
+	}
+
+	first := commits[0]
+	if first.SHA != "aaa" || first.Message != "Add feature\n\nDetails" {
+		t.Errorf("first commit = %q %q", first.SHA, first.Message)
+	}
+	if first.Additions != 13 || first.Deletions != 2 {
+		t.Errorf("first commit stats = +%d/-%d, want +13/-2", first.Additions, first.Deletions)
+	}
+	if len(first.Files) != 3 || first.Files[0].Path != "main.go" || first.Files[0].Additions != 10 || first.Files[1].Path != "logo.png" {
+		t.Errorf("first commit files = %+v, want main.go, logo.png and README.md", first.Files)
+	}
+	if first.Author.Login != "dev1" || first.Author.ProfileURL != "https://github.com/dev1" {
+		t.Errorf("first commit author = %+v", first.Author)
+	}
+	if !first.Date.Equal(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("first commit date = %v", first.Date)
+	}
+
+	second := commits[1]
+	if second.Author.Login != "Former Dev" || second.Additions != 0 {
+		t.Errorf("second commit = %+v", second)
+	}
+}
+
+func TestRenamedPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"main.go", "main.go"},
+		{"old.go => new.go", "new.go"},
+		{"internal/{old => new}/file.go", "internal/new/file.go"},
+		{"internal/{ => sub}/file.go", "internal/sub/file.go"},
+		{"internal/{sub => }/file.go", "internal/file.go"},
+	}
+
+	for _, tt := range tests {
+		if got := renamedPath(tt.path); got != tt.want {
+			t.Errorf("renamedPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestGetActiveBranches(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Dev One", "GIT_AUTHOR_EMAIL=1+dev1@users.noreply.github.com",
+			"GIT_COMMITTER_NAME=Dev One", "GIT_COMMITTER_EMAIL=1+dev1@users.noreply.github.com",
+			"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("2023-12-01T10:00:00Z", "init", "-q", "-b", "main")
+	write("a.txt", "one\n")
+	run("2023-12-01T10:00:00Z", "add", ".")
+	run("2023-12-01T10:00:00Z", "commit", "-q", "-m", "Old commit")
+	run("2023-12-01T10:00:00Z", "branch", "stale")
+
+	run("2024-01-02T10:00:00Z", "checkout", "-q", "-b", "feature")
+	write("a.txt", "one\ntwo\nthree\n")
+	run("2024-01-02T10:00:00Z", "commit", "-q", "-am", "Extend file")
+
+	c, err := NewClient(dir, nil, false)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)
+	branches, err := c.GetActiveBranches(context.Background(), "owner/repo", from, to)
+	if err != nil {
+		t.Fatalf("GetActiveBranches() error = %v", err)
+	}
+
+	if len(branches) != 1 || branches[0].Name != "feature" {
+		t.Fatalf("got branches %+v, want only feature", branches)
+	}
+
+	commit := branches[0].Commits[0]
+	if commit.Additions != 2 || commit.Deletions != 0 {
+		t.Errorf("commit stats = +%d/-%d, want +2/-0", commit.Additions, commit.Deletions)
+	}
+	if commit.Author.Login != "dev1" {
+		t.Errorf("commit author = %q, want dev1", commit.Author.Login)
+	}
+	if commit.URL != "https://github.com/owner/repo/commit/"+commit.SHA {
+		t.Errorf("commit URL = %q", commit.URL)
+	}
+}
+
+func TestCompareBranches(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Dev One", "GIT_AUTHOR_EMAIL=dev1@example.com",
+			"GIT_COMMITTER_NAME=Dev One", "GIT_COMMITTER_EMAIL=dev1@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	run("checkout", "-q", "-b", "feature")
+	run("commit", "-q", "--allow-empty", "-m", "Feature one")
+	run("commit", "-q", "--allow-empty", "-m", "Feature two")
+	run("checkout", "-q", "main")
+	run("commit", "-q", "--allow-empty", "-m", "Main moves on")
+
+	c, err := NewClient(dir, nil, false)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	comparison, err := c.CompareBranches(context.Background(), "owner/repo", "main", "feature")
+	if err != nil {
+		t.Fatalf("CompareBranches() error = %v", err)
+	}
+
+	if comparison.Ahead != 2 || comparison.Behind != 1 || len(comparison.Commits) != 2 {
+		t.Errorf("got comparison %+v, want 2 ahead with their SHAs, 1 behind", comparison)
+	}
+
+	if _, err := c.CompareBranches(context.Background(), "owner/repo", "main", "missing"); err == nil {
+		t.Error("CompareBranches() expected error for a missing branch")
+	}
+}
+
+func TestGetBranchHeads(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	run := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Dev One", "GIT_AUTHOR_EMAIL=1+dev1@users.noreply.github.com",
+			"GIT_COMMITTER_NAME=Dev One", "GIT_COMMITTER_EMAIL=1+dev1@users.noreply.github.com",
+			"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run("2023-12-01T10:00:00Z", "init", "-q", "-b", "main")
+	run("2023-12-01T10:00:00Z", "commit", "-q", "--allow-empty", "-m", "Old commit")
+	run("2023-12-01T10:00:00Z", "branch", "stale")
+	run("2024-01-02T10:00:00Z", "commit", "-q", "--allow-empty", "-m", "New commit")
+
+	c, err := NewClient(dir, nil, false)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	heads, err := c.GetBranchHeads(context.Background(), "owner/repo")
+	if err != nil {
+		t.Fatalf("GetBranchHeads() error = %v", err)
+	}
+
+	if len(heads) != 2 || heads[0].Name != "main" || heads[1].Name != "stale" {
+		t.Fatalf("got heads %+v, want main and stale", heads)
+	}
+	if want := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC); !heads[1].CommittedAt.Equal(want) {
+		t.Errorf("stale committed at %v, want %v", heads[1].CommittedAt, want)
+	}
+	if heads[1].Author.Login != "dev1" || heads[1].SHA == "" || heads[1].SHA == heads[0].SHA {
+		t.Errorf("got stale head %+v, want its own commit by dev1", heads[1])
+	}
+}
+
+func TestNewClientNotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	if _, err := NewClient(t.TempDir(), nil, false); err == nil {
+		t.Error("NewClient() expected error for a directory that is not a git repository")
+	}
+}
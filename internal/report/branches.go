@@ -0,0 +1,174 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
+)
+
+// branchComparer is implemented by GitHub clients that compare a branch with a base branch
+type branchComparer interface {
+	CompareBranches(ctx context.Context, repo, base, head string) (*types.BranchComparison, error)
+}
+
+// compareBranches compares every branch except the default one with the
+// default branch and keeps only the commits that are not on it. Branches
+// without own commits in the period are dropped. A branch that cannot be
+// compared, or whose comparison does not list all its commits, keeps all its
+// commits. Returns a warning for each such branch.
+func (g *Generator) compareBranches(ctx context.Context, repo, defaultBranch string, branches []types.Branch) ([]types.Branch, []string) {
+	comparer, ok := g.githubClient.(branchComparer)
+	if !ok || defaultBranch == "" {
+		return branches, nil
+	}
+
+	names := make([]string, 0, len(branches))
+	for _, branch := range branches {
+		if branch.Name != defaultBranch {
+			names = append(names, branch.Name)
+		}
+	}
+
+	comparisons := make(map[string]*types.BranchComparison)
+	var warnings []string
+	var mu sync.Mutex
+
+	maxWorkers := 5
+	_ = utils.ProcessInParallelWithContext(ctx, names, maxWorkers, func(ctx context.Context, name string) error {
+		comparison, err := comparer.CompareBranches(ctx, repo, defaultBranch, name)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			warning := fmt.Sprintf("Branch %s could not be compared with %s: %v", name, defaultBranch, err)
+			g.logger.Warning(warning)
+			mu.Lock()
+			warnings = append(warnings, warning)
+			mu.Unlock()
+			return nil
+		}
+
+		mu.Lock()
+		comparisons[name] = comparison
+		mu.Unlock()
+		return nil
+	})
+
+	result, merged, truncated := applyBranchComparisons(branches, defaultBranch, comparisons)
+	if len(merged) > 0 {
+		g.logger.Info(fmt.Sprintf("Skipped %d branches without commits beyond %s: %s",
+			len(merged), defaultBranch, strings.Join(merged, ", ")))
+	}
+	for _, name := range truncated {
+		warning := fmt.Sprintf("Branch %s has too many commits beyond %s to list; it shows all its commits of the period", name, defaultBranch)
+		g.logger.Warning(warning)
+		warnings = append(warnings, warning)
+	}
+
+	return result, warnings
+}
+
+// applyBranchComparisons restricts each compared branch to the commits that
+// are not on the default branch and recalculates its statistics. A branch
+// whose comparison is truncated keeps all its commits, since the commits
+// left out are its newest ones. Returns the remaining branches, the names of
+// the dropped ones and the names of the branches with truncated comparisons.
+func applyBranchComparisons(branches []types.Branch, defaultBranch string, comparisons map[string]*types.BranchComparison) ([]types.Branch, []string, []string) {
+	result := make([]types.Branch, 0, len(branches))
+	var dropped, truncated []string
+	for _, branch := range branches {
+		comparison, ok := comparisons[branch.Name]
+		if !ok {
+			result = append(result, branch)
+			continue
+		}
+
+		branch.BaseBranch = defaultBranch
+		branch.Ahead = comparison.Ahead
+		branch.Behind = comparison.Behind
+
+		if comparison.Truncated {
+			truncated = append(truncated, branch.Name)
+			result = append(result, branch)
+			continue
+		}
+
+		own := make(map[string]bool, len(comparison.Commits))
+		for _, sha := range comparison.Commits {
+			own[sha] = true
+		}
+
+		commits := make([]types.Commit, 0, len(branch.Commits))
+		for _, commit := range branch.Commits {
+			if own[commit.SHA] {
+				commits = append(commits, commit)
+			}
+		}
+
+		if len(commits) == 0 {
+			dropped = append(dropped, branch.Name)
+			continue
+		}
+
+		branch.Commits = commits
+		branch.TotalAdded = 0
+		branch.TotalDeleted = 0
+		branch.LastActivity = time.Time{}
+		for _, commit := range commits {
+			branch.TotalAdded += commit.Additions
+			branch.TotalDeleted += commit.Deletions
+			if commit.Date.After(branch.LastActivity) {
+				branch.LastActivity = commit.Date
+			}
+		}
+		branch.Authors = commitAuthors(commits)
+
+		result = append(result, branch)
+	}
+	return result, dropped, truncated
+}
+
+// linkBranchPullRequests fills in the pull requests opened from each branch
+func linkBranchPullRequests(data *types.ReportData) {
+	prs := uniquePRs(data.OpenPRs, data.UpdatedPRs)
+	for i := range data.Branches {
+		branch := &data.Branches[i]
+		branch.PRs = nil
+		for _, pr := range prs {
+			if isOpenedFrom(pr, data.Repository, branch.Name) {
+				branch.PRs = append(branch.PRs, pr)
+			}
+		}
+	}
+}
+
+// isOpenedFrom reports whether a pull request was opened from a branch of the
+// repository; PRs from forks may use the same branch names
+func isOpenedFrom(pr types.PullRequest, repo, branch string) bool {
+	return pr.HeadRef == branch && strings.EqualFold(pr.HeadRepo, repo)
+}
+
+// formatBranchPullRequests lists the pull requests of a branch with their
+// state, e.g. "[#12: Add search](...) (open)", or "none"
+func formatBranchPullRequests(branch types.Branch) string {
+	if len(branch.PRs) == 0 {
+		return "none"
+	}
+
+	links := make([]string, len(branch.PRs))
+	for i, pr := range branch.PRs {
+		links[i] = fmt.Sprintf("[#%d: %s](%s) (%s)", pr.Number, pr.Title, pr.URL, pr.State)
+	}
+	return strings.Join(links, ", ")
+}
+
+// formatBranchComparison describes the position of a branch relative to its
+// base branch, e.g. "3 ahead, 12 behind `main`"
+func formatBranchComparison(branch types.Branch) string {
+	return fmt.Sprintf("%d ahead, %d behind `%s`", branch.Ahead, branch.Behind, branch.BaseBranch)
+}
@@ -0,0 +1,126 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestApplyBranchComparisons(t *testing.T) {
+	alice := types.Author{Login: "alice"}
+	bob := types.Author{Login: "bob"}
+
+	m1 := types.Commit{SHA: "m1", Author: bob, Additions: 10, Deletions: 1, Date: time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC)}
+	f1 := types.Commit{SHA: "f1", Author: alice, Additions: 5, Deletions: 2, Date: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)}
+
+	branches := []types.Branch{
+		{Name: "main", Commits: []types.Commit{m1}, TotalAdded: 10, TotalDeleted: 1, Authors: []string{"bob"}},
+		{Name: "feature", Commits: []types.Commit{f1, m1}, TotalAdded: 15, TotalDeleted: 3, Authors: []string{"alice", "bob"}, LastActivity: m1.Date},
+		{Name: "merged", Commits: []types.Commit{m1}, TotalAdded: 10, TotalDeleted: 1, Authors: []string{"bob"}},
+		{Name: "unknown", Commits: []types.Commit{m1}, TotalAdded: 10, TotalDeleted: 1, Authors: []string{"bob"}},
+		{Name: "long", Commits: []types.Commit{f1, m1}, TotalAdded: 15, TotalDeleted: 3, Authors: []string{"alice", "bob"}},
+	}
+	comparisons := map[string]*types.BranchComparison{
+		"feature": {Ahead: 1, Behind: 4, Commits: []string{"f1"}},
+		"merged":  {Ahead: 0, Behind: 2},
+		"long":    {Ahead: 1500, Behind: 1, Commits: []string{"x1"}, Truncated: true},
+	}
+
+	got, dropped, truncated := applyBranchComparisons(branches, "main", comparisons)
+
+	if len(dropped) != 1 || dropped[0] != "merged" {
+		t.Errorf("got dropped branches %v, want merged", dropped)
+	}
+	if len(truncated) != 1 || truncated[0] != "long" {
+		t.Errorf("got truncated branches %v, want long", truncated)
+	}
+	if len(got) != 4 || got[0].Name != "main" || got[1].Name != "feature" || got[2].Name != "unknown" || got[3].Name != "long" {
+		t.Fatalf("got branches %+v, want main, feature, unknown and long", got)
+	}
+
+	feature := got[1]
+	if len(feature.Commits) != 1 || feature.Commits[0].SHA != "f1" {
+		t.Errorf("got feature commits %+v, want only f1", feature.Commits)
+	}
+	if feature.BaseBranch != "main" || feature.Ahead != 1 || feature.Behind != 4 {
+		t.Errorf("got feature %s: %d ahead, %d behind, want main: 1 ahead, 4 behind", feature.BaseBranch, feature.Ahead, feature.Behind)
+	}
+	if feature.TotalAdded != 5 || feature.TotalDeleted != 2 || len(feature.Authors) != 1 || feature.Authors[0] != "alice" {
+		t.Errorf("got feature stats +%d/-%d by %v, want +5/-2 by alice", feature.TotalAdded, feature.TotalDeleted, feature.Authors)
+	}
+	// The last activity no longer comes from the inherited main commit
+	if !feature.LastActivity.Equal(f1.Date) {
+		t.Errorf("got feature last activity %v, want %v", feature.LastActivity, f1.Date)
+	}
+
+	// Branches with truncated comparisons keep all their commits
+	if long := got[3]; len(long.Commits) != 2 || long.Ahead != 1500 || long.BaseBranch != "main" {
+		t.Errorf("got truncated branch %+v, want all commits and 1500 ahead of main", long)
+	}
+
+	// Branches that were not compared keep all their commits
+	if got[2].BaseBranch != "" || len(got[2].Commits) != 1 {
+		t.Errorf("got uncompared branch %+v, want it unchanged", got[2])
+	}
+}
+
+func TestLinkBranchPullRequests(t *testing.T) {
+	data := &types.ReportData{
+		Repository: "o/r",
+		Branches:   []types.Branch{{Name: "feature"}, {Name: "main"}},
+		OpenPRs: []types.PullRequest{
+			{Number: 3, HeadRef: "feature", HeadRepo: "o/r", State: types.PRStateOpen},
+			// Pull requests from forks may use the same branch names
+			{Number: 4, HeadRef: "main", HeadRepo: "fork/r", State: types.PRStateOpen},
+		},
+		UpdatedPRs: []types.PullRequest{
+			{Number: 3, HeadRef: "feature", HeadRepo: "o/r", State: types.PRStateOpen},
+			{Number: 1, HeadRef: "feature", HeadRepo: "o/r", State: types.PRStateClosed},
+			{Number: 2, HeadRef: "other", HeadRepo: "o/r", State: types.PRStateMerged},
+			{Number: 5, HeadRef: "feature", HeadRepo: "fork/r", State: types.PRStateMerged},
+		},
+	}
+
+	linkBranchPullRequests(data)
+
+	if prs := data.Branches[0].PRs; len(prs) != 2 || prs[0].Number != 3 || prs[1].Number != 1 {
+		t.Errorf("got feature pull requests %+v, want #3 and #1", prs)
+	}
+	if prs := data.Branches[1].PRs; len(prs) != 0 {
+		t.Errorf("got main pull requests %+v, want none", prs)
+	}
+}
+
+func TestGenerateBranchSectionComparedBranch(t *testing.T) {
+	branch := types.Branch{
+		Name:         "feature",
+		Commits:      []types.Commit{{SHA: "f1", Author: types.Author{Login: "alice"}}},
+		Authors:      []string{"alice"},
+		BaseBranch:   "main",
+		Ahead:        1,
+		Behind:       4,
+		LastActivity: time.Date(2025, 1, 12, 9, 30, 0, 0, time.UTC),
+		PRs: []types.PullRequest{
+			{Number: 3, Title: "Add search", URL: "https://github.com/o/r/pull/3", State: types.PRStateOpen},
+		},
+	}
+
+	got := generateBranchSection(branch)
+
+	for _, want := range []string{
+		"- **Compared to Default Branch**: 1 ahead, 4 behind `main`",
+		"- **Last Activity**: 2025-01-12 09:30",
+		"- **Pull Requests**: [#3: Add search](https://github.com/o/r/pull/3) (open)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	branch.PRs = nil
+	if got := generateBranchSection(branch); !strings.Contains(got, "- **Pull Requests**: none") {
+		t.Errorf("expected section without pull requests to say none, got:\n%s", got)
+	}
+}
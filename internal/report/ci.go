@@ -0,0 +1,253 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// Limits of the CI health section
+const (
+	// maxWorkflowRows is the number of workflow and branch rows listed in the report
+	maxWorkflowRows = 20
+	// maxLongestJobs is the number of longest-running jobs listed in the report
+	maxLongestJobs = 10
+)
+
+// isFailedRun reports whether a run conclusion counts as a failure.
+// Cancelled, skipped and neutral runs are neither passed nor failed.
+func isFailedRun(conclusion string) bool {
+	switch conclusion {
+	case types.RunConclusionFailure, types.RunConclusionTimedOut, types.RunConclusionStartupFailure:
+		return true
+	}
+	return false
+}
+
+// calculateCIHealth summarizes the workflow runs of the period, or returns nil
+// when there are none. Only completed runs that passed or failed are counted.
+func calculateCIHealth(runs []types.WorkflowRun, defaultBranch string) *types.CIHealth {
+	if len(runs) == 0 {
+		return nil
+	}
+
+	health := &types.CIHealth{DefaultBranch: defaultBranch}
+
+	// Newest first, so the first run of each group is its latest one
+	counted := make([]types.WorkflowRun, 0, len(runs))
+	for _, run := range runs {
+		if run.Conclusion == types.RunConclusionSuccess || isFailedRun(run.Conclusion) {
+			counted = append(counted, run)
+		}
+	}
+	sort.SliceStable(counted, func(i, j int) bool {
+		return counted[i].StartedAt.After(counted[j].StartedAt)
+	})
+
+	// Workflows are told apart by ID, so a renamed workflow keeps its runs
+	// together; runs without an ID fall back to the workflow name
+	type workflowKey struct {
+		id       int64
+		workflow string
+		branch   string
+	}
+	type commitKey struct {
+		workflow workflowKey
+		sha      string
+	}
+	byWorkflow := make(map[workflowKey]*types.WorkflowStats)
+	byCommit := make(map[commitKey]*types.FlakyWorkflow)
+	var workflowOrder []workflowKey
+	var commitOrder []commitKey
+	passed := 0
+
+	for _, run := range counted {
+		failed := isFailedRun(run.Conclusion)
+		if !failed {
+			passed++
+		}
+
+		wk := workflowKey{id: run.WorkflowID, branch: run.Branch}
+		if run.WorkflowID == 0 {
+			wk.workflow = run.Workflow
+		}
+		stats, ok := byWorkflow[wk]
+		if !ok {
+			stats = &types.WorkflowStats{
+				Workflow:       run.Workflow,
+				Branch:         run.Branch,
+				LastConclusion: run.Conclusion,
+				LastRunURL:     run.URL,
+				LastRunAt:      run.StartedAt,
+			}
+			byWorkflow[wk] = stats
+			workflowOrder = append(workflowOrder, wk)
+		}
+		stats.Runs++
+		if failed {
+			stats.Failed++
+		} else {
+			stats.Passed++
+		}
+
+		ck := commitKey{workflowKey{id: wk.id, workflow: wk.workflow}, run.SHA}
+		commit, ok := byCommit[ck]
+		if !ok {
+			commit = &types.FlakyWorkflow{Workflow: run.Workflow, Branch: run.Branch, SHA: run.SHA, URL: run.URL}
+			byCommit[ck] = commit
+			commitOrder = append(commitOrder, ck)
+		}
+		// Earlier attempts of a re-run count with their observed conclusions
+		for _, conclusion := range append([]string{run.Conclusion}, run.PreviousConclusions...) {
+			switch {
+			case isFailedRun(conclusion):
+				commit.Failed++
+			case conclusion == types.RunConclusionSuccess:
+				commit.Passed++
+			}
+		}
+	}
+
+	health.TotalRuns = len(counted)
+	health.SuccessRate = percentage(passed, len(counted))
+
+	for _, wk := range workflowOrder {
+		stats := byWorkflow[wk]
+		stats.SuccessRate = percentage(stats.Passed, stats.Runs)
+		health.Workflows = append(health.Workflows, *stats)
+
+		if defaultBranch != "" && stats.Branch == defaultBranch && isFailedRun(stats.LastConclusion) {
+			health.Red = append(health.Red, *stats)
+		}
+	}
+
+	// Busiest workflows first, then by name
+	sort.SliceStable(health.Workflows, func(i, j int) bool {
+		a, b := health.Workflows[i], health.Workflows[j]
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		if a.Workflow != b.Workflow {
+			return a.Workflow < b.Workflow
+		}
+		return a.Branch < b.Branch
+	})
+	sort.SliceStable(health.Red, func(i, j int) bool {
+		return health.Red[i].Workflow < health.Red[j].Workflow
+	})
+
+	for _, ck := range commitOrder {
+		if commit := byCommit[ck]; commit.Passed > 0 && commit.Failed > 0 {
+			health.Flaky = append(health.Flaky, *commit)
+		}
+	}
+	sort.SliceStable(health.Flaky, func(i, j int) bool {
+		return health.Flaky[i].Passed+health.Flaky[i].Failed > health.Flaky[j].Passed+health.Flaky[j].Failed
+	})
+
+	health.LongestJobs = longestJobs(runs)
+
+	return health
+}
+
+// longestJobs lists the longest-running jobs of the runs, longest first
+func longestJobs(runs []types.WorkflowRun) []types.JobDuration {
+	var jobs []types.JobDuration
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			if job.StartedAt.IsZero() || job.CompletedAt.Before(job.StartedAt) {
+				continue
+			}
+			jobs = append(jobs, types.JobDuration{
+				Workflow: run.Workflow,
+				Job:      job.Name,
+				Branch:   run.Branch,
+				Minutes:  job.CompletedAt.Sub(job.StartedAt).Minutes(),
+				URL:      job.URL,
+			})
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Minutes > jobs[j].Minutes
+	})
+	if len(jobs) > maxLongestJobs {
+		jobs = jobs[:maxLongestJobs]
+	}
+
+	return jobs
+}
+
+// percentage returns part as a percentage of total (0 for an empty total)
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+// generateCISection generates the GitHub Actions workflow run health section
+func generateCISection(health *types.CIHealth) string {
+	if health == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("## 🧪 CI Health\n\n")
+
+	if health.TotalRuns == 0 {
+		sb.WriteString("No workflow runs passed or failed during this period\n\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("**Success Rate**: %.1f%% (%d runs)\n\n", health.SuccessRate, health.TotalRuns))
+
+	if len(health.Red) > 0 {
+		sb.WriteString(fmt.Sprintf("### 🔴 Failing on %s\n\n", health.DefaultBranch))
+		for _, stats := range health.Red {
+			sb.WriteString(fmt.Sprintf("- [%s](%s): last run %s on %s (%d of %d runs failed)\n",
+				stats.Workflow, stats.LastRunURL, stats.LastConclusion, stats.LastRunAt.Format("2006-01-02"), stats.Failed, stats.Runs))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("### Workflows\n\n")
+	sb.WriteString("| Workflow | Branch | Runs | Passed | Failed | Success Rate | Last Run |\n")
+	sb.WriteString("|----------|--------|------|--------|--------|--------------|----------|\n")
+	for i, stats := range health.Workflows {
+		if i == maxWorkflowRows {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("| %s | `%s` | %d | %d | %d | %.1f%% | [%s](%s) |\n",
+			stats.Workflow, stats.Branch, stats.Runs, stats.Passed, stats.Failed, stats.SuccessRate, stats.LastConclusion, stats.LastRunURL))
+	}
+	sb.WriteString("\n")
+	if len(health.Workflows) > maxWorkflowRows {
+		sb.WriteString(fmt.Sprintf("... and %d more workflow and branch combinations\n\n", len(health.Workflows)-maxWorkflowRows))
+	}
+
+	if len(health.Flaky) > 0 {
+		sb.WriteString("### Flaky Workflows\n\n")
+		for _, flaky := range health.Flaky {
+			sb.WriteString(fmt.Sprintf("- **%s** on `%s` at [`%s`](%s): %d passed, %d failed\n",
+				flaky.Workflow, flaky.Branch, shortSHA(flaky.SHA), flaky.URL, flaky.Passed, flaky.Failed))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(health.LongestJobs) > 0 {
+		sb.WriteString("### Longest Jobs\n\n")
+		sb.WriteString("| Job | Workflow | Branch | Duration |\n")
+		sb.WriteString("|-----|----------|--------|----------|\n")
+		for _, job := range health.LongestJobs {
+			sb.WriteString(fmt.Sprintf("| [%s](%s) | %s | `%s` | %s |\n",
+				job.Job, job.URL, job.Workflow, job.Branch, formatHours(job.Minutes/60)))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,177 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestCalculateCIHealth(t *testing.T) {
+	if got := calculateCIHealth(nil, "main"); got != nil {
+		t.Errorf("calculateCIHealth(nil) = %+v, want nil", got)
+	}
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	runs := []types.WorkflowRun{
+		// Oldest first to check that the latest run is picked by start time
+		{ID: 1, Workflow: "CI", Branch: "main", SHA: "aaa", Conclusion: types.RunConclusionSuccess, Attempt: 1, StartedAt: at(2, 0), URL: "https://github.com/o/r/actions/runs/1"},
+		// Passed on the second attempt after a failure on the same commit
+		{ID: 2, Workflow: "CI", Branch: "main", SHA: "bbb", Conclusion: types.RunConclusionSuccess, Attempt: 2, PreviousConclusions: []string{types.RunConclusionFailure}, StartedAt: at(3, 0), URL: "https://github.com/o/r/actions/runs/2",
+			Jobs: []types.WorkflowJob{
+				{Name: "test", StartedAt: at(3, 0), CompletedAt: at(3, 0).Add(25 * time.Minute), URL: "https://github.com/o/r/actions/runs/2/job/21"},
+				{Name: "lint", StartedAt: at(3, 0), CompletedAt: at(3, 0).Add(5 * time.Minute), URL: "https://github.com/o/r/actions/runs/2/job/22"},
+			}},
+		{ID: 3, Workflow: "CI", Branch: "main", SHA: "ccc", Conclusion: types.RunConclusionTimedOut, Attempt: 1, StartedAt: at(4, 0), URL: "https://github.com/o/r/actions/runs/3"},
+		// Cancelled runs are not counted
+		{ID: 4, Workflow: "CI", Branch: "main", SHA: "ddd", Conclusion: "cancelled", Attempt: 1, StartedAt: at(5, 0)},
+		// In-progress runs have no conclusion yet
+		{ID: 5, Workflow: "CI", Branch: "main", SHA: "eee", Status: "in_progress", StartedAt: at(6, 0)},
+		// Two runs on the same commit with different results
+		{ID: 6, Workflow: "Deploy", Branch: "feature", SHA: "fff", Conclusion: types.RunConclusionFailure, Attempt: 1, StartedAt: at(4, 0), URL: "https://github.com/o/r/actions/runs/6"},
+		{ID: 7, Workflow: "Deploy", Branch: "feature", SHA: "fff", Conclusion: types.RunConclusionSuccess, Attempt: 1, StartedAt: at(4, 1), URL: "https://github.com/o/r/actions/runs/7"},
+		// Failing outside the default branch does not make the workflow red
+		{ID: 8, Workflow: "Docs", Branch: "feature", SHA: "ggg", Conclusion: types.RunConclusionFailure, Attempt: 1, StartedAt: at(4, 0)},
+	}
+
+	got := calculateCIHealth(runs, "main")
+
+	if got.TotalRuns != 6 {
+		t.Errorf("TotalRuns = %d, want 6", got.TotalRuns)
+	}
+	if got.SuccessRate != 50 {
+		t.Errorf("SuccessRate = %v, want 50", got.SuccessRate)
+	}
+
+	if len(got.Workflows) != 3 {
+		t.Fatalf("got %d workflows, want 3: %+v", len(got.Workflows), got.Workflows)
+	}
+	ci := got.Workflows[0]
+	if ci.Workflow != "CI" || ci.Runs != 3 || ci.Passed != 2 || ci.Failed != 1 {
+		t.Errorf("Workflows[0] = %+v, want CI with 3 runs, 2 passed, 1 failed", ci)
+	}
+	if ci.LastConclusion != types.RunConclusionTimedOut || ci.LastRunURL != "https://github.com/o/r/actions/runs/3" {
+		t.Errorf("Workflows[0] last run = %s %s, want timed_out run 3", ci.LastConclusion, ci.LastRunURL)
+	}
+
+	if len(got.Red) != 1 || got.Red[0].Workflow != "CI" {
+		t.Errorf("Red = %+v, want only CI", got.Red)
+	}
+
+	if len(got.Flaky) != 2 {
+		t.Fatalf("got %d flaky workflows, want 2: %+v", len(got.Flaky), got.Flaky)
+	}
+	for _, flaky := range got.Flaky {
+		if flaky.Passed != 1 || flaky.Failed != 1 {
+			t.Errorf("flaky %s at %s = %d passed, %d failed, want 1 and 1", flaky.Workflow, flaky.SHA, flaky.Passed, flaky.Failed)
+		}
+	}
+
+	if len(got.LongestJobs) != 2 || got.LongestJobs[0].Job != "test" || got.LongestJobs[0].Minutes != 25 {
+		t.Errorf("LongestJobs = %+v, want test (25m) first", got.LongestJobs)
+	}
+}
+
+func TestCalculateCIHealthReruns(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2025, 1, 2, hour, 0, 0, 0, time.UTC)
+	}
+
+	runs := []types.WorkflowRun{
+		// Re-run after a cancelled attempt: nothing failed
+		{ID: 1, WorkflowID: 10, Workflow: "CI", Branch: "main", SHA: "aaa", Conclusion: types.RunConclusionSuccess, Attempt: 2,
+			PreviousConclusions: []string{"cancelled"}, StartedAt: at(1)},
+		// Re-run whose earlier attempts were not fetched: nothing is assumed about them
+		{ID: 2, WorkflowID: 10, Workflow: "CI", Branch: "main", SHA: "bbb", Conclusion: types.RunConclusionSuccess, Attempt: 3, StartedAt: at(2)},
+		// The workflow was renamed: same ID, so the runs are grouped together
+		{ID: 3, WorkflowID: 10, Workflow: "Build", Branch: "main", SHA: "ccc", Conclusion: types.RunConclusionSuccess, Attempt: 3,
+			PreviousConclusions: []string{types.RunConclusionFailure, types.RunConclusionTimedOut}, StartedAt: at(3)},
+		// Another workflow with the old name
+		{ID: 4, WorkflowID: 20, Workflow: "CI", Branch: "main", SHA: "ccc", Conclusion: types.RunConclusionSuccess, Attempt: 1, StartedAt: at(4)},
+	}
+
+	got := calculateCIHealth(runs, "main")
+
+	if len(got.Workflows) != 2 {
+		t.Fatalf("got %d workflows, want 2 (grouped by workflow ID): %+v", len(got.Workflows), got.Workflows)
+	}
+	if build := got.Workflows[0]; build.Workflow != "Build" || build.Runs != 3 {
+		t.Errorf("Workflows[0] = %+v, want the renamed workflow with 3 runs under its latest name", build)
+	}
+
+	if len(got.Flaky) != 1 {
+		t.Fatalf("got flaky workflows %+v, want only the run with failed attempts", got.Flaky)
+	}
+	if flaky := got.Flaky[0]; flaky.SHA != "ccc" || flaky.Passed != 1 || flaky.Failed != 2 {
+		t.Errorf("flaky = %+v, want ccc with 1 passed and 2 failed attempts", flaky)
+	}
+}
+
+func TestCalculateCIHealthWithoutDefaultBranch(t *testing.T) {
+	runs := []types.WorkflowRun{
+		{Workflow: "CI", Branch: "main", SHA: "aaa", Conclusion: types.RunConclusionFailure, StartedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	got := calculateCIHealth(runs, "")
+
+	if len(got.Red) != 0 {
+		t.Errorf("Red = %+v without a default branch, want none", got.Red)
+	}
+}
+
+func TestGenerateCISection(t *testing.T) {
+	if got := generateCISection(nil); got != "" {
+		t.Errorf("generateCISection(nil) = %q, want empty", got)
+	}
+
+	if got := generateCISection(&types.CIHealth{}); !strings.Contains(got, "No workflow runs passed or failed during this period") {
+		t.Errorf("generateCISection() without runs = %q, want empty message", got)
+	}
+
+	red := types.WorkflowStats{
+		Workflow:       "CI",
+		Branch:         "main",
+		Runs:           4,
+		Passed:         3,
+		Failed:         1,
+		SuccessRate:    75,
+		LastConclusion: types.RunConclusionFailure,
+		LastRunURL:     "https://github.com/o/r/actions/runs/9",
+		LastRunAt:      time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC),
+	}
+	health := &types.CIHealth{
+		DefaultBranch: "main",
+		TotalRuns:     4,
+		SuccessRate:   75,
+		Workflows:     []types.WorkflowStats{red},
+		Red:           []types.WorkflowStats{red},
+		Flaky: []types.FlakyWorkflow{{
+			Workflow: "CI", Branch: "main", SHA: "0123456789abcdef", Passed: 1, Failed: 2,
+			URL: "https://github.com/o/r/actions/runs/8",
+		}},
+		LongestJobs: []types.JobDuration{{
+			Workflow: "CI", Job: "test", Branch: "main", Minutes: 90,
+			URL: "https://github.com/o/r/actions/runs/8/job/81",
+		}},
+	}
+
+	got := generateCISection(health)
+
+	for _, want := range []string{
+		"## 🧪 CI Health",
+		"**Success Rate**: 75.0% (4 runs)",
+		"### 🔴 Failing on main",
+		"- [CI](https://github.com/o/r/actions/runs/9): last run failure on 2025-01-09 (1 of 4 runs failed)",
+		"| CI | `main` | 4 | 3 | 1 | 75.0% | [failure](https://github.com/o/r/actions/runs/9) |",
+		"- **CI** on `main` at [`0123456`](https://github.com/o/r/actions/runs/8): 1 passed, 2 failed",
+		"| [test](https://github.com/o/r/actions/runs/8/job/81) | CI | `main` | 1h 30m |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package report
+
+import (
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// attributeCommits records on every commit the branch it was introduced on and
+// counts the commits introduced on each branch. A commit reachable from several
+// branches belongs to the default branch if it is on it, otherwise to the
+// branch the others were created from (see introducedOn). Commits without a
+// SHA stay on their branch. Pull requests must be linked to the branches first.
+func attributeCommits(data *types.ReportData) {
+	shas := make(map[string]map[string]bool, len(data.Branches))
+	for _, branch := range data.Branches {
+		shas[branch.Name] = make(map[string]bool, len(branch.Commits))
+		for _, commit := range branch.Commits {
+			if commit.SHA != "" {
+				shas[branch.Name][commit.SHA] = true
+			}
+		}
+	}
+
+	owners := make(map[string]int)
+	for i, branch := range data.Branches {
+		for _, commit := range branch.Commits {
+			owner, seen := owners[commit.SHA]
+			if !seen || introducedOn(data.Branches[i], data.Branches[owner], shas, data.DefaultBranch) {
+				owners[commit.SHA] = i
+			}
+		}
+	}
+
+	for i := range data.Branches {
+		branch := &data.Branches[i]
+		branch.IntroducedCommits = 0
+		for j := range branch.Commits {
+			commit := &branch.Commits[j]
+			commit.IntroducedOn = branch.Name
+			if commit.SHA != "" {
+				commit.IntroducedOn = data.Branches[owners[commit.SHA]].Name
+			}
+			if commit.IntroducedOn == branch.Name {
+				branch.IntroducedCommits++
+			}
+		}
+	}
+}
+
+// introducedOn reports whether a commit on both branches is attributed to
+// branch rather than to current. The default branch always wins. Otherwise
+// the branch the other one was created from wins (see isBaseOf). Two branches
+// that both received commits after one was created from the other, without a
+// pull request between them, cannot be told apart from their histories; the
+// one with fewer commits in the period wins then, by name on ties.
+func introducedOn(branch, current types.Branch, shas map[string]map[string]bool, defaultBranch string) bool {
+	switch {
+	case current.Name == defaultBranch:
+		return false
+	case branch.Name == defaultBranch:
+		return true
+	case isBaseOf(branch, current, shas):
+		return true
+	case isBaseOf(current, branch, shas):
+		return false
+	case len(branch.Commits) != len(current.Commits):
+		return len(branch.Commits) < len(current.Commits)
+	default:
+		return branch.Name < current.Name
+	}
+}
+
+// isBaseOf reports whether head was created from base: every commit of base
+// in the period is in the history of head (base is an ancestor of head), or a
+// pull request from head targets base. shas holds the commit SHAs per branch.
+func isBaseOf(base, head types.Branch, shas map[string]map[string]bool) bool {
+	if len(base.Commits) < len(head.Commits) {
+		ancestor := true
+		for _, commit := range base.Commits {
+			if commit.SHA == "" || !shas[head.Name][commit.SHA] {
+				ancestor = false
+				break
+			}
+		}
+		if ancestor {
+			return true
+		}
+	}
+
+	for _, pr := range head.PRs {
+		if pr.BaseRef == base.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueCommits returns every commit of the branches once, in branch order.
+// Commits without attribution are attributed to the first branch they are on;
+// commits without a SHA cannot be told apart and are all kept.
+func uniqueCommits(branches []types.Branch) []types.Commit {
+	seen := make(map[string]bool)
+	var commits []types.Commit
+	for _, branch := range branches {
+		for _, commit := range branch.Commits {
+			if commit.SHA != "" && seen[commit.SHA] {
+				continue
+			}
+			seen[commit.SHA] = true
+
+			if commit.IntroducedOn == "" {
+				commit.IntroducedOn = branch.Name
+			}
+			commits = append(commits, commit)
+		}
+	}
+	return commits
+}
+
+// inheritedCommits counts the commits of a branch that are attributed to another branch
+func inheritedCommits(branch types.Branch) int {
+	count := 0
+	for _, commit := range branch.Commits {
+		if commit.IntroducedOn != "" && commit.IntroducedOn != branch.Name {
+			count++
+		}
+	}
+	return count
+}
+
+// countBranchCommits counts the commits of all branches, counting a commit
+// once per branch it is on
+func countBranchCommits(branches []types.Branch) int {
+	count := 0
+	for _, branch := range branches {
+		count += len(branch.Commits)
+	}
+	return count
+}
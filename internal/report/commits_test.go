@@ -0,0 +1,159 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// branchesWithSharedCommits returns main with two commits, feature-a branched
+// from main with one new commit and feature-b branched from feature-a with one
+// more commit
+func branchesWithSharedCommits() []types.Branch {
+	alice := types.Author{Login: "alice"}
+	bob := types.Author{Login: "bob"}
+
+	m1 := types.Commit{SHA: "m1", Author: alice, Additions: 10}
+	m2 := types.Commit{SHA: "m2", Author: bob, Additions: 20}
+	a1 := types.Commit{SHA: "a1", Author: alice, Additions: 30}
+	b1 := types.Commit{SHA: "b1", Author: bob, Additions: 40}
+
+	return []types.Branch{
+		{Name: "feature-b", Commits: []types.Commit{b1, a1, m2, m1}},
+		{Name: "main", Commits: []types.Commit{m2, m1}},
+		{Name: "feature-a", Commits: []types.Commit{a1, m2, m1}},
+	}
+}
+
+func TestAttributeCommits(t *testing.T) {
+	data := &types.ReportData{DefaultBranch: "main", Branches: branchesWithSharedCommits()}
+
+	attributeCommits(data)
+
+	want := map[string]int{"feature-b": 1, "main": 2, "feature-a": 1}
+	for _, branch := range data.Branches {
+		if branch.IntroducedCommits != want[branch.Name] {
+			t.Errorf("branch %s IntroducedCommits = %d, want %d", branch.Name, branch.IntroducedCommits, want[branch.Name])
+		}
+	}
+
+	introducedOn := make(map[string]string)
+	for _, branch := range data.Branches {
+		for _, commit := range branch.Commits {
+			if previous, ok := introducedOn[commit.SHA]; ok && previous != commit.IntroducedOn {
+				t.Errorf("commit %s introduced on %s and %s, want a single branch", commit.SHA, previous, commit.IntroducedOn)
+			}
+			introducedOn[commit.SHA] = commit.IntroducedOn
+		}
+	}
+	for sha, branch := range map[string]string{"m1": "main", "m2": "main", "a1": "feature-a", "b1": "feature-b"} {
+		if introducedOn[sha] != branch {
+			t.Errorf("commit %s introduced on %s, want %s", sha, introducedOn[sha], branch)
+		}
+	}
+}
+
+func TestAttributeCommitsWithoutDefaultBranch(t *testing.T) {
+	data := &types.ReportData{Branches: []types.Branch{
+		{Name: "release", Commits: []types.Commit{{SHA: "x1"}, {SHA: "x2"}}},
+		{Name: "hotfix", Commits: []types.Commit{{SHA: "x1"}}},
+		{Name: "backport", Commits: []types.Commit{{SHA: "x1"}}},
+	}}
+
+	attributeCommits(data)
+
+	// The smallest branches win, by name on ties
+	if got := data.Branches[0].Commits[0].IntroducedOn; got != "backport" {
+		t.Errorf("commit x1 introduced on %s, want backport", got)
+	}
+	if got := data.Branches[0].IntroducedCommits; got != 1 {
+		t.Errorf("branch release IntroducedCommits = %d, want 1", got)
+	}
+}
+
+func TestAttributeCommitsBaseBranchMovedOn(t *testing.T) {
+	// feature-b was created from feature-a at a1; feature-a received three
+	// more commits afterwards, so it has more commits in the period
+	branches := func() []types.Branch {
+		return []types.Branch{
+			{Name: "feature-a", Commits: []types.Commit{{SHA: "a4"}, {SHA: "a3"}, {SHA: "a2"}, {SHA: "a1"}}},
+			{Name: "feature-b", Commits: []types.Commit{{SHA: "b1"}, {SHA: "a1"}}},
+		}
+	}
+
+	t.Run("pull request into the base branch", func(t *testing.T) {
+		data := &types.ReportData{Repository: "o/r", DefaultBranch: "main", Branches: branches()}
+		data.Branches[1].PRs = []types.PullRequest{{Number: 1, HeadRef: "feature-b", HeadRepo: "o/r", BaseRef: "feature-a"}}
+
+		attributeCommits(data)
+
+		if got := data.Branches[1].Commits[1].IntroducedOn; got != "feature-a" {
+			t.Errorf("commit a1 introduced on %s, want feature-a", got)
+		}
+		if got := data.Branches[0].IntroducedCommits; got != 4 {
+			t.Errorf("branch feature-a IntroducedCommits = %d, want 4", got)
+		}
+		if got := data.Branches[1].IntroducedCommits; got != 1 {
+			t.Errorf("branch feature-b IntroducedCommits = %d, want 1", got)
+		}
+	})
+
+	t.Run("no pull request between the branches", func(t *testing.T) {
+		data := &types.ReportData{DefaultBranch: "main", Branches: branches()}
+
+		attributeCommits(data)
+
+		// The histories are symmetric; the branch with fewer commits wins
+		if got := data.Branches[1].Commits[1].IntroducedOn; got != "feature-b" {
+			t.Errorf("commit a1 introduced on %s, want feature-b", got)
+		}
+	})
+}
+
+func TestCalculateStatsCountsSharedCommitsOnce(t *testing.T) {
+	data := &types.ReportData{DefaultBranch: "main", Branches: branchesWithSharedCommits()}
+	attributeCommits(data)
+
+	overall := calculateOverallStats(data)
+	if overall.TotalCommits != 4 || overall.BranchCommits != 9 {
+		t.Errorf("calculateOverallStats() = %d commits (%d across branches), want 4 (9)", overall.TotalCommits, overall.BranchCommits)
+	}
+
+	authors := calculateAuthorStats(data)
+	for _, stats := range authors {
+		if stats.TotalCommits != 2 {
+			t.Errorf("author %s TotalCommits = %d, want 2", stats.Author.Login, stats.TotalCommits)
+		}
+		if stats.Author.Login == "alice" {
+			if stats.TotalAdded != 40 {
+				t.Errorf("alice TotalAdded = %d, want 40", stats.TotalAdded)
+			}
+			if len(stats.BranchActivity) != 2 || stats.BranchActivity["main"].Commits != 1 || stats.BranchActivity["feature-a"].Commits != 1 {
+				t.Errorf("alice BranchActivity = %+v, want one commit on main and one on feature-a", stats.BranchActivity)
+			}
+		}
+	}
+
+	if got := calculatePeriodStats(data, data.Period); got.Commits != 4 || got.LinesAdded != 100 {
+		t.Errorf("calculatePeriodStats() = %d commits, +%d lines, want 4 commits, +100 lines", got.Commits, got.LinesAdded)
+	}
+
+	if got := generateSummaryStats(overall); !strings.Contains(got, "- **Total Commits**: 4 (9 across branches)") {
+		t.Errorf("generateSummaryStats() missing commits across branches, got:\n%s", got)
+	}
+}
+
+func TestGenerateBranchSectionMarksInheritedCommits(t *testing.T) {
+	data := &types.ReportData{DefaultBranch: "main", Branches: branchesWithSharedCommits()}
+	attributeCommits(data)
+
+	got := generateBranchSection(data.Branches[2])
+
+	if !strings.Contains(got, "- **Total Commits**: 3 (2 introduced on other branches)") {
+		t.Errorf("generateBranchSection() missing inherited commit count, got:\n%s", got)
+	}
+	if strings.Count(got, "| **Introduced on**: `main`") != 2 {
+		t.Errorf("generateBranchSection() should mark the 2 commits of main, got:\n%s", got)
+	}
+}
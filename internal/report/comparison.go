@@ -0,0 +1,126 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// ComparePrevious compares the report period with the preceding period of equal length
+const ComparePrevious = "previous"
+
+// previousPeriod returns the period of equal length that ends where period starts
+func previousPeriod(period types.Period) types.Period {
+	return types.Period{
+		From: period.From.Add(-period.To.Sub(period.From)),
+		To:   period.From,
+	}
+}
+
+// compareWithPrevious collects the activity of the period preceding opts.Period
+// and compares it with the already collected data
+func (g *Generator) compareWithPrevious(ctx context.Context, opts Options, data *types.ReportData) (*types.PeriodComparison, error) {
+	previous := previousPeriod(opts.Period)
+	g.logger.Progress(fmt.Sprintf("Collecting previous period %s to %s for comparison...",
+		previous.From.Format("2006-01-02"), previous.To.Format("2006-01-02")))
+
+	prevData, err := g.collectPeriod(ctx, opts.Repository, previous, opts.Period.To)
+	if err != nil {
+		return nil, err
+	}
+	filterByUser(prevData, opts.User)
+
+	return &types.PeriodComparison{
+		PreviousPeriod: previous,
+		Current:        calculatePeriodStats(data, opts.Period),
+		Previous:       calculatePeriodStats(prevData, previous),
+	}, nil
+}
+
+// collectPeriod collects the data needed for period statistics: branches with
+// commits and closed issues of the period, and pull requests updated between
+// the start of the period and prsUntil, so that pull requests opened or merged
+// in the period are found even when they were updated later
+func (g *Generator) collectPeriod(ctx context.Context, repo string, period types.Period, prsUntil time.Time) (*types.ReportData, error) {
+	data := &types.ReportData{
+		Repository: repo,
+		Period:     period,
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+
+	eg.Go(func() error {
+		branches, err := g.githubClient.GetActiveBranches(egCtx, repo, period.From, period.To)
+		data.Branches = branches
+		return err
+	})
+
+	eg.Go(func() error {
+		prs, err := g.githubClient.GetUpdatedPullRequests(egCtx, repo, period.From.Format(time.RFC3339), prsUntil.Format(time.RFC3339))
+		data.UpdatedPRs = prs
+		return err
+	})
+
+	eg.Go(func() error {
+		issues, err := g.githubClient.GetClosedIssues(egCtx, repo, period.From.Format(time.RFC3339), period.To.Format(time.RFC3339))
+		data.ClosedIssues = issues
+		return err
+	})
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// calculatePeriodStats counts the activity of data that falls into period
+func calculatePeriodStats(data *types.ReportData, period types.Period) types.PeriodStats {
+	var stats types.PeriodStats
+
+	authorSet := make(map[string]bool)
+	for _, commit := range uniqueCommits(data.Branches) {
+		stats.Commits++
+		stats.LinesAdded += commit.Additions
+		stats.LinesDeleted += commit.Deletions
+		authorSet[commit.Author.Login] = true
+	}
+	stats.Authors = len(authorSet)
+
+	for _, pr := range uniquePRs(data.OpenPRs, data.UpdatedPRs) {
+		if inPeriod(pr.CreatedAt, period) {
+			stats.PRsOpened++
+		}
+		if pr.MergedAt != nil && inPeriod(*pr.MergedAt, period) {
+			stats.PRsMerged++
+		}
+	}
+
+	for _, issue := range data.ClosedIssues {
+		if issue.ClosedAt != nil && inPeriod(*issue.ClosedAt, period) {
+			stats.IssuesClosed++
+		}
+	}
+
+	return stats
+}
+
+// inPeriod reports whether t lies within period (inclusive)
+func inPeriod(t time.Time, period types.Period) bool {
+	return !t.Before(period.From) && !t.After(period.To)
+}
+
+// reviewsInPeriod returns the reviews submitted within period
+func reviewsInPeriod(reviews []types.Review, period types.Period) []types.Review {
+	result := make([]types.Review, 0, len(reviews))
+	for _, review := range reviews {
+		if inPeriod(review.SubmittedAt, period) {
+			result = append(result, review)
+		}
+	}
+	return result
+}
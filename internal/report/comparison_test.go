@@ -0,0 +1,126 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestPreviousPeriod(t *testing.T) {
+	period := types.Period{
+		From: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	got := previousPeriod(period)
+
+	wantFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if !got.From.Equal(wantFrom) || !got.To.Equal(period.From) {
+		t.Errorf("previousPeriod() = %v to %v, want %v to %v", got.From, got.To, wantFrom, period.From)
+	}
+}
+
+func TestCalculatePeriodStats(t *testing.T) {
+	period := types.Period{
+		From: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	inside := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	data := &types.ReportData{
+		Branches: []types.Branch{
+			{Name: "main", Commits: []types.Commit{
+				{SHA: "1", Author: types.Author{Login: "alice"}, Additions: 10, Deletions: 2},
+				{SHA: "2", Author: types.Author{Login: "bob"}, Additions: 5, Deletions: 1},
+			}},
+			{Name: "feature", Commits: []types.Commit{
+				{SHA: "3", Author: types.Author{Login: "alice"}, Additions: 1},
+			}},
+		},
+		OpenPRs: []types.PullRequest{
+			{Number: 1, CreatedAt: inside},
+			{Number: 2, CreatedAt: before},
+		},
+		UpdatedPRs: []types.PullRequest{
+			{Number: 1, CreatedAt: inside},
+			{Number: 3, CreatedAt: before, MergedAt: &inside},
+			{Number: 4, CreatedAt: inside, MergedAt: &after},
+		},
+		ClosedIssues: []types.Issue{
+			{Number: 10, ClosedAt: &inside},
+			{Number: 11, ClosedAt: &after},
+		},
+	}
+
+	got := calculatePeriodStats(data, period)
+
+	want := types.PeriodStats{
+		Commits:      3,
+		Authors:      2,
+		PRsOpened:    2,
+		PRsMerged:    1,
+		IssuesClosed: 1,
+		LinesAdded:   16,
+		LinesDeleted: 3,
+	}
+	if got != want {
+		t.Errorf("calculatePeriodStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFormatChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  int
+		previous int
+		want     string
+	}{
+		{name: "increase", current: 10, previous: 8, want: "⬆️ +2 (+25%)"},
+		{name: "decrease", current: 5, previous: 10, want: "⬇️ -5 (-50%)"},
+		{name: "unchanged", current: 3, previous: 3, want: "➖ 0"},
+		{name: "no previous activity", current: 4, previous: 0, want: "⬆️ +4 (new)"},
+		{name: "both zero", current: 0, previous: 0, want: "➖ 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatChange(types.ComparisonMetric{Name: "Commits", Current: tt.current, Previous: tt.previous})
+			if got != tt.want {
+				t.Errorf("formatChange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateComparisonSection(t *testing.T) {
+	if got := generateComparisonSection(nil); got != "" {
+		t.Errorf("generateComparisonSection(nil) = %q, want empty", got)
+	}
+
+	comparison := &types.PeriodComparison{
+		PreviousPeriod: types.Period{
+			From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
+		Current:  types.PeriodStats{Commits: 10, PRsMerged: 2},
+		Previous: types.PeriodStats{Commits: 8, PRsMerged: 4},
+	}
+
+	got := generateComparisonSection(comparison)
+
+	for _, want := range []string{
+		"## 📈 Compared to Previous Period",
+		"*Previous period: 2025-01-01 to 2025-01-08*",
+		"| Commits | 10 | 8 | ⬆️ +2 (+25%) |",
+		"| PRs Merged | 2 | 4 | ⬇️ -2 (-50%) |",
+		"| Issues Closed | 0 | 0 | ➖ 0 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generateComparisonSection() missing %q\n%s", want, got)
+		}
+	}
+}
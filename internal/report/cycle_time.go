@@ -0,0 +1,155 @@
+package report
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// calculateCycleTime measures how long pull requests waited for review,
+// approval and merge. A timing is counted when its event (first review,
+// first approval, merge) happened during the report period.
+func calculateCycleTime(data *types.ReportData) *types.CycleTimeAnalytics {
+	analytics := &types.CycleTimeAnalytics{}
+
+	byAuthor := make(map[string][]types.PRCycleTime)
+	for _, pr := range uniquePRs(data.OpenPRs, data.UpdatedPRs) {
+		cycle, ok := prCycleTime(pr, data.Period)
+		if !ok {
+			continue
+		}
+		analytics.PullRequests = append(analytics.PullRequests, cycle)
+		byAuthor[cycle.Author] = append(byAuthor[cycle.Author], cycle)
+	}
+
+	analytics.Overall = summarizeCycleTimes("", analytics.PullRequests)
+	for author, cycles := range byAuthor {
+		analytics.Authors = append(analytics.Authors, summarizeCycleTimes(author, cycles))
+	}
+
+	// Sort by number of PRs (descending), then by login for a stable order
+	sort.Slice(analytics.Authors, func(i, j int) bool {
+		if analytics.Authors[i].PRs != analytics.Authors[j].PRs {
+			return analytics.Authors[i].PRs > analytics.Authors[j].PRs
+		}
+		return analytics.Authors[i].Author < analytics.Authors[j].Author
+	})
+
+	return analytics
+}
+
+// prCycleTime measures a single pull request. ok is false when none of its
+// events happened during the period.
+func prCycleTime(pr types.PullRequest, period types.Period) (cycle types.PRCycleTime, ok bool) {
+	cycle = types.PRCycleTime{
+		Number: pr.Number,
+		Title:  pr.Title,
+		URL:    pr.URL,
+		Author: pr.Author.Login,
+	}
+
+	// Replies of the author in review threads are not reviews
+	var reviews []types.Review
+	for _, review := range pr.ReviewDetails {
+		if review.Author.Login != pr.Author.Login && !review.SubmittedAt.IsZero() {
+			reviews = append(reviews, review)
+		}
+	}
+	sort.SliceStable(reviews, func(i, j int) bool {
+		return reviews[i].SubmittedAt.Before(reviews[j].SubmittedAt)
+	})
+
+	if len(reviews) > 0 {
+		cycle.ReviewRounds = 1
+		if first := reviews[0].SubmittedAt; inPeriod(first, period) {
+			cycle.TimeToFirstReview = hoursSince(pr.CreatedAt, first)
+		}
+	}
+
+	approved := false
+	for _, review := range reviews {
+		switch review.State {
+		case types.ReviewStateChangesRequested:
+			cycle.ReviewRounds++
+		case types.ReviewStateApproved:
+			if !approved && inPeriod(review.SubmittedAt, period) {
+				cycle.TimeToApproval = hoursSince(pr.CreatedAt, review.SubmittedAt)
+			}
+			approved = true
+		}
+	}
+
+	if pr.MergedAt != nil && inPeriod(*pr.MergedAt, period) {
+		cycle.TimeToMerge = hoursSince(pr.CreatedAt, *pr.MergedAt)
+	}
+
+	ok = cycle.TimeToFirstReview != nil || cycle.TimeToApproval != nil || cycle.TimeToMerge != nil
+	return cycle, ok
+}
+
+// summarizeCycleTimes computes medians and 90th percentiles of cycle times
+func summarizeCycleTimes(author string, cycles []types.PRCycleTime) types.CycleTimeStats {
+	var firstReview, approval, merge, rounds []float64
+	for _, cycle := range cycles {
+		if cycle.TimeToFirstReview != nil {
+			firstReview = append(firstReview, *cycle.TimeToFirstReview)
+		}
+		if cycle.TimeToApproval != nil {
+			approval = append(approval, *cycle.TimeToApproval)
+		}
+		if cycle.TimeToMerge != nil {
+			merge = append(merge, *cycle.TimeToMerge)
+		}
+		if cycle.ReviewRounds > 0 {
+			rounds = append(rounds, float64(cycle.ReviewRounds))
+		}
+	}
+
+	return types.CycleTimeStats{
+		Author:             author,
+		PRs:                len(cycles),
+		TimeToFirstReview:  durationStats(firstReview),
+		TimeToApproval:     durationStats(approval),
+		TimeToMerge:        durationStats(merge),
+		ReviewedPRs:        len(rounds),
+		MedianReviewRounds: percentile(rounds, 50),
+		P90ReviewRounds:    percentile(rounds, 90),
+	}
+}
+
+// durationStats summarizes durations in hours
+func durationStats(hours []float64) types.DurationStats {
+	return types.DurationStats{
+		Count:  len(hours),
+		Median: percentile(hours, 50),
+		P90:    percentile(hours, 90),
+	}
+}
+
+// percentile returns the p-th percentile of values using linear interpolation
+// between the closest ranks (0 for no values)
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// hoursSince returns the hours between from and to, never negative
+func hoursSince(from, to time.Time) *float64 {
+	hours := to.Sub(from).Hours()
+	if hours < 0 {
+		hours = 0
+	}
+	return &hours
+}
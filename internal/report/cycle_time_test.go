@@ -0,0 +1,206 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		p      float64
+		want   float64
+	}{
+		{name: "no values", values: nil, p: 50, want: 0},
+		{name: "single value", values: []float64{7}, p: 90, want: 7},
+		{name: "median of odd count", values: []float64{5, 1, 3}, p: 50, want: 3},
+		{name: "median of even count", values: []float64{4, 1, 3, 2}, p: 50, want: 2.5},
+		{name: "p90 interpolates", values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, p: 90, want: 10},
+		{name: "p90 between ranks", values: []float64{0, 10}, p: 90, want: 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentile(tt.values, tt.p); got != tt.want {
+				t.Errorf("percentile(%v, %v) = %v, want %v", tt.values, tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPRCycleTime(t *testing.T) {
+	period := types.Period{
+		From: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	created := time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC)
+	merged := created.Add(48 * time.Hour)
+
+	pr := types.PullRequest{
+		Number:    1,
+		Author:    types.Author{Login: "alice"},
+		CreatedAt: created,
+		MergedAt:  &merged,
+		ReviewDetails: []types.Review{
+			// The author's own reply is not a review
+			{Author: types.Author{Login: "alice"}, State: types.ReviewStateCommented, SubmittedAt: created.Add(time.Hour)},
+			{Author: types.Author{Login: "bob"}, State: types.ReviewStateApproved, SubmittedAt: created.Add(30 * time.Hour)},
+			{Author: types.Author{Login: "bob"}, State: types.ReviewStateChangesRequested, SubmittedAt: created.Add(4 * time.Hour)},
+		},
+	}
+
+	cycle, ok := prCycleTime(pr, period)
+	if !ok {
+		t.Fatal("prCycleTime() ok = false, want true")
+	}
+	if cycle.TimeToFirstReview == nil || *cycle.TimeToFirstReview != 4 {
+		t.Errorf("TimeToFirstReview = %v, want 4", cycle.TimeToFirstReview)
+	}
+	if cycle.TimeToApproval == nil || *cycle.TimeToApproval != 30 {
+		t.Errorf("TimeToApproval = %v, want 30", cycle.TimeToApproval)
+	}
+	if cycle.TimeToMerge == nil || *cycle.TimeToMerge != 48 {
+		t.Errorf("TimeToMerge = %v, want 48", cycle.TimeToMerge)
+	}
+	if cycle.ReviewRounds != 2 {
+		t.Errorf("ReviewRounds = %d, want 2", cycle.ReviewRounds)
+	}
+}
+
+func TestPRCycleTimeOutsidePeriod(t *testing.T) {
+	period := types.Period{
+		From: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	merged := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	pr := types.PullRequest{
+		Number:    2,
+		Author:    types.Author{Login: "alice"},
+		CreatedAt: created,
+		MergedAt:  &merged,
+		ReviewDetails: []types.Review{
+			{Author: types.Author{Login: "bob"}, State: types.ReviewStateApproved, SubmittedAt: created.Add(24 * time.Hour)},
+		},
+	}
+
+	cycle, ok := prCycleTime(pr, period)
+	if !ok {
+		t.Fatal("prCycleTime() ok = false, want true")
+	}
+	if cycle.TimeToFirstReview != nil || cycle.TimeToApproval != nil {
+		t.Errorf("review timings = %v / %v, want nil for reviews before the period", cycle.TimeToFirstReview, cycle.TimeToApproval)
+	}
+	if cycle.TimeToMerge == nil || *cycle.TimeToMerge != 216 {
+		t.Errorf("TimeToMerge = %v, want 216", cycle.TimeToMerge)
+	}
+
+	pr.MergedAt = nil
+	if _, ok := prCycleTime(pr, period); ok {
+		t.Error("prCycleTime() ok = true for a PR without events in the period, want false")
+	}
+}
+
+func TestCalculateCycleTime(t *testing.T) {
+	period := types.Period{
+		From: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	created := time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC)
+	mergedAfter := func(hours int) *time.Time {
+		merged := created.Add(time.Duration(hours) * time.Hour)
+		return &merged
+	}
+
+	data := &types.ReportData{
+		Period: period,
+		OpenPRs: []types.PullRequest{
+			{Number: 1, Author: types.Author{Login: "bob"}, CreatedAt: created},
+		},
+		UpdatedPRs: []types.PullRequest{
+			{Number: 2, Author: types.Author{Login: "bob"}, CreatedAt: created, MergedAt: mergedAfter(10)},
+			{Number: 3, Author: types.Author{Login: "alice"}, CreatedAt: created, MergedAt: mergedAfter(2)},
+			{Number: 4, Author: types.Author{Login: "alice"}, CreatedAt: created, MergedAt: mergedAfter(6)},
+			{Number: 5, Author: types.Author{Login: "carol"}, CreatedAt: created, MergedAt: mergedAfter(1)},
+		},
+	}
+
+	got := calculateCycleTime(data)
+
+	if len(got.PullRequests) != 4 {
+		t.Errorf("PullRequests = %d, want 4 (PR without events excluded)", len(got.PullRequests))
+	}
+	if got.Overall.TimeToMerge.Count != 4 || got.Overall.TimeToMerge.Median != 4 {
+		t.Errorf("Overall.TimeToMerge = %+v, want count 4 and median 4", got.Overall.TimeToMerge)
+	}
+
+	var authors []string
+	for _, author := range got.Authors {
+		authors = append(authors, author.Author)
+	}
+	if want := "alice,bob,carol"; strings.Join(authors, ",") != want {
+		t.Errorf("Authors = %v, want %s", authors, want)
+	}
+}
+
+func TestFormatHours(t *testing.T) {
+	tests := []struct {
+		hours float64
+		want  string
+	}{
+		{hours: 0.25, want: "15m"},
+		{hours: 5.5, want: "5h 30m"},
+		{hours: 52, want: "2d 4h"},
+	}
+
+	for _, tt := range tests {
+		if got := formatHours(tt.hours); got != tt.want {
+			t.Errorf("formatHours(%v) = %q, want %q", tt.hours, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateCycleTimeSection(t *testing.T) {
+	if got := generateCycleTimeSection(nil); got != "" {
+		t.Errorf("generateCycleTimeSection(nil) = %q, want empty", got)
+	}
+
+	empty := generateCycleTimeSection(&types.CycleTimeAnalytics{})
+	if !strings.Contains(empty, "No pull requests were reviewed or merged during this period") {
+		t.Errorf("expected empty message, got:\n%s", empty)
+	}
+
+	merge := 30.0
+	analytics := &types.CycleTimeAnalytics{
+		PullRequests: []types.PRCycleTime{{Number: 1, Author: "alice", TimeToMerge: &merge}},
+		Overall: types.CycleTimeStats{
+			PRs:         1,
+			TimeToMerge: types.DurationStats{Count: 1, Median: 30, P90: 30},
+		},
+		Authors: []types.CycleTimeStats{{
+			Author:      "alice",
+			PRs:         1,
+			TimeToMerge: types.DurationStats{Count: 1, Median: 30, P90: 30},
+		}},
+	}
+
+	got := generateCycleTimeSection(analytics)
+
+	for _, want := range []string{
+		"## ⏱️ Pull Request Cycle Time",
+		"| Time to First Review | 0 | - | - |",
+		"| Time to Merge | 1 | 1d 6h | 1d 6h |",
+		"| Review Rounds | 0 | - | - |",
+		"### By Author",
+		"[alice](https://github.com/alice)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got:\n%s", want, got)
+		}
+	}
+}
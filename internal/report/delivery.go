@@ -0,0 +1,191 @@
+package report
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// DefaultIncidentLabels are the issue labels that mark incidents unless configured otherwise
+var DefaultIncidentLabels = []string{"incident"}
+
+// prReferenceSuffix matches the " (#123)" suffix GitHub adds to squash-merged commit messages
+var prReferenceSuffix = regexp.MustCompile(`\s+\(#\d+\)$`)
+
+// calculateDeliveryMetrics computes DORA-style delivery metrics: deployment
+// frequency, lead time for changes, change failure rate and time to restore.
+// Successful production deployments created during the period count as
+// deployments, or else releases and tags published during the period, or else
+// pull requests merged during the period; reverts and issues with one of
+// incidentLabels count as failed changes.
+func calculateDeliveryMetrics(data *types.ReportData, incidentLabels []string) *types.DeliveryMetrics {
+	if len(incidentLabels) == 0 {
+		incidentLabels = DefaultIncidentLabels
+	}
+
+	metrics := &types.DeliveryMetrics{
+		IncidentLabels: incidentLabels,
+	}
+
+	// Deployments and lead time for changes
+	var leadTimes []float64
+	if deployments := productionDeployments(data); len(deployments) > 0 {
+		metrics.DeploymentSource = types.DeploymentSourceDeployments
+		metrics.Deployments = len(deployments)
+		for _, deployment := range deployments {
+			for _, commit := range deployment.Commits {
+				leadTimes = append(leadTimes, *hoursSince(commit.Date, deployment.CreatedAt))
+			}
+		}
+	} else if releases := deployedReleases(data); len(releases) > 0 {
+		metrics.DeploymentSource = types.DeploymentSourceReleases
+		metrics.Deployments = len(releases)
+		for _, release := range releases {
+			for _, commit := range release.Commits {
+				leadTimes = append(leadTimes, *hoursSince(commit.Date, release.PublishedAt))
+			}
+		}
+	} else {
+		metrics.DeploymentSource = types.DeploymentSourceMergedPRs
+		for _, pr := range mergedPRs(data) {
+			metrics.Deployments++
+
+			start := pr.CreatedAt
+			if pr.FirstCommitAt != nil && pr.FirstCommitAt.Before(start) {
+				start = *pr.FirstCommitAt
+			}
+			leadTimes = append(leadTimes, *hoursSince(start, *pr.MergedAt))
+		}
+	}
+	metrics.LeadTime = durationStats(leadTimes)
+
+	if weeks := data.Period.To.Sub(data.Period.From).Hours() / (7 * 24); weeks > 0 {
+		metrics.DeploymentsPerWeek = float64(metrics.Deployments) / weeks
+	}
+
+	// Reverts merged through pull requests
+	revertTitles := make(map[string]bool)
+	for _, pr := range mergedPRs(data) {
+		if isRevert(pr.Title) {
+			revertTitles[normalizeRevertTitle(pr.Title)] = true
+			metrics.Failures = append(metrics.Failures, types.ChangeFailure{
+				Kind:   types.FailureKindRevert,
+				Number: pr.Number,
+				Title:  pr.Title,
+				URL:    pr.URL,
+				At:     *pr.MergedAt,
+			})
+		}
+	}
+
+	// Reverts pushed directly, skipping the commits of revert pull requests
+	seen := make(map[string]bool)
+	for _, branch := range data.Branches {
+		for _, commit := range branch.Commits {
+			title := firstLine(commit.Message)
+			if seen[commit.SHA] || !isRevert(title) || revertTitles[normalizeRevertTitle(title)] {
+				continue
+			}
+			seen[commit.SHA] = true
+			revertTitles[normalizeRevertTitle(title)] = true
+
+			metrics.Failures = append(metrics.Failures, types.ChangeFailure{
+				Kind:  types.FailureKindRevert,
+				SHA:   commit.SHA,
+				Title: title,
+				URL:   commit.URL,
+				At:    commit.Date,
+			})
+		}
+	}
+
+	// Incidents opened during the period are failures; those closed during
+	// the period measure the time to restore
+	var restoreTimes []float64
+	for _, issue := range append(append([]types.Issue{}, data.OpenIssues...), data.ClosedIssues...) {
+		if !hasAnyLabel(issue.Labels, incidentLabels) {
+			continue
+		}
+
+		if issue.ClosedAt != nil && inPeriod(*issue.ClosedAt, data.Period) {
+			restoreTimes = append(restoreTimes, *hoursSince(issue.CreatedAt, *issue.ClosedAt))
+		}
+
+		if inPeriod(issue.CreatedAt, data.Period) {
+			metrics.Failures = append(metrics.Failures, types.ChangeFailure{
+				Kind:       types.FailureKindIncident,
+				Number:     issue.Number,
+				Title:      issue.Title,
+				URL:        issue.URL,
+				At:         issue.CreatedAt,
+				RestoredAt: issue.ClosedAt,
+			})
+		}
+	}
+	metrics.TimeToRestore = durationStats(restoreTimes)
+
+	sort.SliceStable(metrics.Failures, func(i, j int) bool {
+		return metrics.Failures[i].At.Before(metrics.Failures[j].At)
+	})
+
+	metrics.FailedChanges = len(metrics.Failures)
+	if metrics.Deployments > 0 {
+		metrics.ChangeFailureRate = float64(metrics.FailedChanges) / float64(metrics.Deployments) * 100
+		if metrics.ChangeFailureRate > 100 {
+			metrics.ChangeFailureRate = 100
+		}
+	}
+
+	return metrics
+}
+
+// productionDeployments returns the successful deployments to production
+// environments created during the period
+func productionDeployments(data *types.ReportData) []types.Deployment {
+	var deployments []types.Deployment
+	for _, deployment := range data.Deployments {
+		if deployment.Production && deployment.IsSuccessful() && inPeriod(deployment.CreatedAt, data.Period) {
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments
+}
+
+// deployedReleases returns the releases and tags published during the
+// period, leaving out pre-releases
+func deployedReleases(data *types.ReportData) []types.Release {
+	var releases []types.Release
+	for _, release := range data.Releases {
+		if !release.Prerelease && inPeriod(release.PublishedAt, data.Period) {
+			releases = append(releases, release)
+		}
+	}
+	return releases
+}
+
+// isRevert reports whether a PR title or commit message line reverts a change,
+// e.g. `Revert "Add feature"` or `revert: add feature`
+func isRevert(title string) bool {
+	lower := strings.ToLower(strings.TrimSpace(title))
+	return strings.HasPrefix(lower, `revert "`) || strings.HasPrefix(lower, "revert:")
+}
+
+// normalizeRevertTitle strips the pull request reference of squash-merged
+// commits so a revert commit can be matched to its pull request
+func normalizeRevertTitle(title string) string {
+	return strings.ToLower(prReferenceSuffix.ReplaceAllString(strings.TrimSpace(title), ""))
+}
+
+// hasAnyLabel reports whether labels contain one of wanted (case-insensitive)
+func hasAnyLabel(labels, wanted []string) bool {
+	for _, label := range labels {
+		for _, w := range wanted {
+			if strings.EqualFold(label, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
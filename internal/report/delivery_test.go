@@ -0,0 +1,239 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestIsRevert(t *testing.T) {
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{title: `Revert "Add login page"`, want: true},
+		{title: "revert: drop cache layer", want: true},
+		{title: "Reverting is hard", want: false},
+		{title: "Add revert button", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isRevert(tt.title); got != tt.want {
+			t.Errorf("isRevert(%q) = %v, want %v", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestCalculateDeliveryMetrics(t *testing.T) {
+	period := types.Period{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2025, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	data := &types.ReportData{
+		Period: period,
+		Branches: []types.Branch{
+			{Name: "main", Commits: []types.Commit{
+				// Squash-merged commit of revert PR #3, counted once
+				{SHA: "aaa1111", Message: `Revert "Add login page" (#3)`, Date: at(6, 0)},
+				// Revert pushed directly
+				{SHA: "bbb2222", Message: "revert: drop cache layer\n\nBroke staging", Date: at(7, 0), URL: "https://github.com/o/r/commit/bbb2222"},
+				{SHA: "ccc3333", Message: "Add cache layer", Date: at(5, 0)},
+			}},
+			{Name: "revert-3", Commits: []types.Commit{
+				{SHA: "aaa0000", Message: `Revert "Add login page"`, Date: at(5, 12)},
+			}},
+		},
+		UpdatedPRs: []types.PullRequest{
+			// Lead time from the first commit: 2 days
+			{Number: 1, Title: "Add login page", CreatedAt: at(3, 0), FirstCommitAt: ptr(at(2, 0)), MergedAt: ptr(at(4, 0))},
+			// Lead time from creation without a known first commit: 1 day
+			{Number: 2, Title: "Fix typo", CreatedAt: at(4, 0), MergedAt: ptr(at(5, 0))},
+			{Number: 3, Title: `Revert "Add login page"`, CreatedAt: at(5, 12), MergedAt: ptr(at(6, 0)), URL: "https://github.com/o/r/pull/3"},
+			// Merged before the period
+			{Number: 4, Title: "Old change", CreatedAt: at(1, 0), MergedAt: ptr(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC))},
+		},
+		OpenIssues: []types.Issue{
+			{Number: 10, Title: "Checkout down", Labels: []string{"Incident"}, CreatedAt: at(8, 0)},
+			{Number: 11, Title: "Feature request", Labels: []string{"enhancement"}, CreatedAt: at(8, 0)},
+		},
+		ClosedIssues: []types.Issue{
+			// Opened before the period: restores count, the failure does not
+			{Number: 12, Title: "Login errors", Labels: []string{"incident"}, CreatedAt: time.Date(2024, 12, 31, 20, 0, 0, 0, time.UTC), ClosedAt: ptr(at(1, 2))},
+			{Number: 13, Title: "Slow API", Labels: []string{"incident"}, CreatedAt: at(9, 0), ClosedAt: ptr(at(9, 4))},
+		},
+	}
+
+	got := calculateDeliveryMetrics(data, nil)
+
+	if got.DeploymentSource != types.DeploymentSourceMergedPRs {
+		t.Errorf("DeploymentSource = %q, want %q", got.DeploymentSource, types.DeploymentSourceMergedPRs)
+	}
+	if got.Deployments != 3 || got.DeploymentsPerWeek != 1.5 {
+		t.Errorf("Deployments = %d (%v per week), want 3 (1.5 per week)", got.Deployments, got.DeploymentsPerWeek)
+	}
+	if got.LeadTime.Count != 3 || got.LeadTime.Median != 24 {
+		t.Errorf("LeadTime = %+v, want count 3 and median 24", got.LeadTime)
+	}
+	if got.TimeToRestore.Count != 2 || got.TimeToRestore.Median != 5 {
+		t.Errorf("TimeToRestore = %+v, want count 2 and median 5", got.TimeToRestore)
+	}
+
+	var failures []string
+	for _, failure := range got.Failures {
+		failures = append(failures, failure.Kind+":"+failure.Title)
+	}
+	want := []string{
+		`revert:Revert "Add login page"`,
+		"revert:revert: drop cache layer",
+		"incident:Checkout down",
+		"incident:Slow API",
+	}
+	if strings.Join(failures, "|") != strings.Join(want, "|") {
+		t.Errorf("Failures = %v, want %v", failures, want)
+	}
+	if got.FailedChanges != 4 || got.ChangeFailureRate != 100 {
+		t.Errorf("FailedChanges = %d (%.1f%%), want 4 (capped at 100%%)", got.FailedChanges, got.ChangeFailureRate)
+	}
+}
+
+func TestCalculateDeliveryMetricsIncidentLabels(t *testing.T) {
+	data := &types.ReportData{
+		Period: types.Period{
+			From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
+		OpenIssues: []types.Issue{
+			{Number: 1, Labels: []string{"incident"}, CreatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+			{Number: 2, Labels: []string{"sev1"}, CreatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	got := calculateDeliveryMetrics(data, []string{"sev1", "outage"})
+
+	if got.FailedChanges != 1 || got.Failures[0].Number != 2 {
+		t.Errorf("Failures = %+v, want only issue #2", got.Failures)
+	}
+	if got.ChangeFailureRate != 0 {
+		t.Errorf("ChangeFailureRate = %v without deployments, want 0", got.ChangeFailureRate)
+	}
+}
+
+func TestGenerateDeliverySection(t *testing.T) {
+	if got := generateDeliverySection(nil); got != "" {
+		t.Errorf("generateDeliverySection(nil) = %q, want empty", got)
+	}
+
+	restored := time.Date(2025, 1, 9, 4, 0, 0, 0, time.UTC)
+	metrics := &types.DeliveryMetrics{
+		DeploymentSource:   types.DeploymentSourceMergedPRs,
+		Deployments:        4,
+		DeploymentsPerWeek: 2,
+		LeadTime:           types.DurationStats{Count: 4, Median: 26, P90: 50},
+		FailedChanges:      1,
+		ChangeFailureRate:  25,
+		Failures: []types.ChangeFailure{{
+			Kind:       types.FailureKindIncident,
+			Number:     13,
+			Title:      "Slow API",
+			URL:        "https://github.com/o/r/issues/13",
+			At:         time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC),
+			RestoredAt: &restored,
+		}},
+		IncidentLabels: []string{"incident", "outage"},
+	}
+
+	got := generateDeliverySection(metrics)
+
+	for _, want := range []string{
+		"## 🚀 Delivery Metrics",
+		"Deployments are pull requests merged during the period; failed changes are reverts and issues labeled `incident` or `outage`.",
+		"| Deployment Frequency | 4 (2.0 per week) |",
+		"| Lead Time for Changes | median 1d 2h, p90 2d 2h (4 changes) |",
+		"| Change Failure Rate | 25.0% (1 of 4) |",
+		"| Time to Restore | - |",
+		"- **Incident**: [Issue #13: Slow API](https://github.com/o/r/issues/13), opened 2025-01-09, restored in 4h 0m",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestCalculateDeliveryMetricsFromReleases(t *testing.T) {
+	published := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	merged := published.Add(-24 * time.Hour)
+
+	data := &types.ReportData{
+		Period: types.Period{
+			From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+		UpdatedPRs: []types.PullRequest{
+			{Number: 1, CreatedAt: merged.Add(-time.Hour), MergedAt: &merged},
+			{Number: 2, CreatedAt: merged.Add(-time.Hour), MergedAt: &merged},
+		},
+		Releases: []types.Release{
+			{TagName: "v1.1.0-rc.1", Prerelease: true, PublishedAt: published.Add(-48 * time.Hour)},
+			{TagName: "v1.1.0", PublishedAt: published, Commits: []types.Commit{
+				{SHA: "a", Date: published.Add(-10 * time.Hour)},
+				{SHA: "b", Date: published.Add(-30 * time.Hour)},
+				{SHA: "c", Date: published.Add(-20 * time.Hour)},
+			}},
+		},
+	}
+
+	got := calculateDeliveryMetrics(data, nil)
+
+	if got.DeploymentSource != types.DeploymentSourceReleases || got.Deployments != 1 {
+		t.Errorf("got %d deployments from %q, want 1 from releases (pre-release excluded)", got.Deployments, got.DeploymentSource)
+	}
+	if got.LeadTime.Count != 3 || got.LeadTime.Median != 20 {
+		t.Errorf("LeadTime = %+v, want count 3 and median 20 (commit to release)", got.LeadTime)
+	}
+}
+
+func TestCalculateDeliveryMetricsFromDeployments(t *testing.T) {
+	deployed := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	data := &types.ReportData{
+		Period: types.Period{
+			From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+		Releases: []types.Release{
+			{TagName: "v1.1.0", PublishedAt: deployed.Add(-time.Hour)},
+		},
+		Deployments: []types.Deployment{
+			{ID: 4, Environment: "production", Production: true, State: types.DeploymentStateSuccess, CreatedAt: deployed, Commits: []types.Commit{
+				{SHA: "a", Date: deployed.Add(-10 * time.Hour)},
+				{SHA: "b", Date: deployed.Add(-30 * time.Hour)},
+			}},
+			// Replaced by the later deployment, still a successful one
+			{ID: 3, Environment: "production", Production: true, State: types.DeploymentStateInactive, CreatedAt: deployed.Add(-48 * time.Hour)},
+			{ID: 2, Environment: "production", Production: true, State: types.DeploymentStateFailure, CreatedAt: deployed.Add(-72 * time.Hour)},
+			{ID: 1, Environment: "staging", State: types.DeploymentStateSuccess, CreatedAt: deployed.Add(-96 * time.Hour)},
+		},
+	}
+
+	got := calculateDeliveryMetrics(data, nil)
+
+	if got.DeploymentSource != types.DeploymentSourceDeployments || got.Deployments != 2 {
+		t.Errorf("got %d deployments from %q, want 2 successful production deployments", got.Deployments, got.DeploymentSource)
+	}
+	if got.LeadTime.Count != 2 || got.LeadTime.Median != 20 {
+		t.Errorf("LeadTime = %+v, want count 2 and median 20 (commit to deployment)", got.LeadTime)
+	}
+
+	// Without a successful production deployment, releases are used
+	data.Deployments = data.Deployments[2:]
+	if got := calculateDeliveryMetrics(data, nil); got.DeploymentSource != types.DeploymentSourceReleases {
+		t.Errorf("got deployment source %q without production deployments, want releases", got.DeploymentSource)
+	}
+}
@@ -0,0 +1,175 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// maxDeploymentCommits is the number of commits without a pull request listed per deployment
+const maxDeploymentCommits = 10
+
+// environmentStats summarizes the deployments to an environment
+type environmentStats struct {
+	Environment  string
+	Deployments  []types.Deployment
+	Succeeded    int
+	Failed       int
+	RolledBack   int
+	LastDeployed time.Time
+	LastSHA      string
+}
+
+// linkDeploymentPullRequests fills in the pull requests of each deployment
+// from the pull request numbers referenced by its commits
+func linkDeploymentPullRequests(data *types.ReportData) {
+	known := knownPullRequests(data)
+	for i := range data.Deployments {
+		deployment := &data.Deployments[i]
+		deployment.PullRequests = commitPullRequests(deployment.Commits, known, data.RepositoryURL)
+	}
+}
+
+// groupDeploymentsByEnvironment groups deployments by environment, sorted by
+// environment name. Deployments keep their order, newest first.
+func groupDeploymentsByEnvironment(deployments []types.Deployment) []environmentStats {
+	byEnvironment := make(map[string]*environmentStats)
+	var environments []string
+	for _, deployment := range deployments {
+		stats, ok := byEnvironment[deployment.Environment]
+		if !ok {
+			stats = &environmentStats{Environment: deployment.Environment}
+			byEnvironment[deployment.Environment] = stats
+			environments = append(environments, deployment.Environment)
+		}
+
+		stats.Deployments = append(stats.Deployments, deployment)
+		switch {
+		case deployment.IsFailed():
+			stats.Failed++
+		case deployment.State == types.DeploymentStateSuccess || deployment.State == types.DeploymentStateInactive:
+			stats.Succeeded++
+		}
+		if deployment.RolledBack {
+			stats.RolledBack++
+		}
+		if deployment.CreatedAt.After(stats.LastDeployed) {
+			stats.LastDeployed = deployment.CreatedAt
+			stats.LastSHA = deployment.SHA
+		}
+	}
+
+	sort.Strings(environments)
+	result := make([]environmentStats, len(environments))
+	for i, environment := range environments {
+		result[i] = *byEnvironment[environment]
+	}
+	return result
+}
+
+// problemDeployments returns the failed and rolled-back deployments
+func problemDeployments(deployments []types.Deployment) []types.Deployment {
+	var result []types.Deployment
+	for _, deployment := range deployments {
+		if deployment.IsFailed() || deployment.RolledBack {
+			result = append(result, deployment)
+		}
+	}
+	return result
+}
+
+// formatDeploymentState describes the outcome of a deployment, e.g. "failure"
+// or "success, rolled back from abc1234"
+func formatDeploymentState(deployment types.Deployment) string {
+	state := deployment.State
+	if state == "" {
+		state = "no status"
+	}
+	if deployment.RolledBack {
+		state += ", rolled back from " + shortSHA(deployment.PreviousSHA)
+	}
+	return state
+}
+
+// commitsWithoutPullRequest returns the commits that were not merged from a pull request
+func commitsWithoutPullRequest(commits []types.Commit) []types.Commit {
+	var result []types.Commit
+	for _, commit := range commits {
+		if _, ok := referencedPRNumber(commit.Message); !ok {
+			result = append(result, commit)
+		}
+	}
+	return result
+}
+
+// generateDeploymentsSection generates the section listing deployments per environment
+func generateDeploymentsSection(data *types.ReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString("## 🚢 Deployments\n\n")
+
+	if len(data.Deployments) == 0 {
+		sb.WriteString("No deployments were made during this period\n\n")
+		return sb.String()
+	}
+
+	environments := groupDeploymentsByEnvironment(data.Deployments)
+
+	sb.WriteString("| Environment | Deployments | Succeeded | Failed | Rolled Back | Last Deployed |\n")
+	sb.WriteString("|-------------|-------------|-----------|--------|-------------|---------------|\n")
+	for _, env := range environments {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %s ([`%s`](%s/commit/%s)) |\n",
+			env.Environment, len(env.Deployments), env.Succeeded, env.Failed, env.RolledBack,
+			env.LastDeployed.Format("2006-01-02"), shortSHA(env.LastSHA), data.RepositoryURL, env.LastSHA))
+	}
+	sb.WriteString("\n")
+
+	if problems := problemDeployments(data.Deployments); len(problems) > 0 {
+		sb.WriteString("### ⚠️ Failed and Rolled-Back Deployments\n\n")
+		for _, deployment := range problems {
+			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", deployment.Environment, formatDeployment(deployment, data.RepositoryURL)))
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, env := range environments {
+		sb.WriteString(fmt.Sprintf("### %s\n\n", env.Environment))
+		for _, deployment := range env.Deployments {
+			sb.WriteString(fmt.Sprintf("- %s\n", formatDeployment(deployment, data.RepositoryURL)))
+			if deployment.PreviousSHA != "" && !deployment.RolledBack {
+				sb.WriteString(fmt.Sprintf("  - Changes since `%s`: %d commits, %d pull requests\n",
+					shortSHA(deployment.PreviousSHA), len(deployment.Commits), len(deployment.PullRequests)))
+			}
+			for _, pr := range deployment.PullRequests {
+				sb.WriteString(fmt.Sprintf("  - [#%d: %s](%s) by %s\n", pr.Number, pr.Title, pr.URL, formatAuthorLink(pr.Author.Login)))
+			}
+			direct := commitsWithoutPullRequest(deployment.Commits)
+			for i, commit := range direct {
+				if i == maxDeploymentCommits {
+					sb.WriteString(fmt.Sprintf("  - ... and %d more commits\n", len(direct)-maxDeploymentCommits))
+					break
+				}
+				sb.WriteString(fmt.Sprintf("  - [`%s`](%s) %s by %s\n", shortSHA(commit.SHA), commit.URL, firstLine(commit.Message), formatAuthorLink(commit.Author.Login)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// formatDeployment formats a deployment as a Markdown list entry, e.g.
+// "2025-01-10 15:04 [`abc1234`](...) `main` by [alice](...): [success](...)"
+func formatDeployment(deployment types.Deployment, repositoryURL string) string {
+	state := formatDeploymentState(deployment)
+	if deployment.URL != "" {
+		state = fmt.Sprintf("[%s](%s)", state, deployment.URL)
+	}
+
+	return fmt.Sprintf("%s [`%s`](%s/commit/%s) `%s` by %s: %s",
+		formatDate(deployment.CreatedAt), shortSHA(deployment.SHA), repositoryURL, deployment.SHA,
+		deployment.Ref, formatAuthorLink(deployment.Creator.Login), state)
+}
@@ -0,0 +1,128 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestLinkDeploymentPullRequests(t *testing.T) {
+	data := &types.ReportData{
+		RepositoryURL: "https://github.com/o/r",
+		UpdatedPRs: []types.PullRequest{
+			{Number: 12, Title: "Add search", URL: "https://github.com/o/r/pull/12", Author: types.Author{Login: "bob"}},
+		},
+		Deployments: []types.Deployment{{
+			Environment: "production",
+			Commits: []types.Commit{
+				{SHA: "c1", Message: "Add search (#12)"},
+				{SHA: "c2", Message: "Merge pull request #15 from o/fix-typo\n\nFix typo", Author: types.Author{Login: "carol"}},
+				{SHA: "c3", Message: "Bump version"},
+			},
+		}},
+	}
+
+	linkDeploymentPullRequests(data)
+
+	prs := data.Deployments[0].PullRequests
+	if len(prs) != 2 {
+		t.Fatalf("got %d pull requests, want 2", len(prs))
+	}
+	if prs[0].Number != 12 || prs[0].Title != "Add search" {
+		t.Errorf("got first pull request %+v, want known #12", prs[0])
+	}
+	if prs[1].Number != 15 || prs[1].URL != "https://github.com/o/r/pull/15" || prs[1].Author.Login != "carol" {
+		t.Errorf("got second pull request %+v, want #15 built from the commit", prs[1])
+	}
+}
+
+func TestGroupDeploymentsByEnvironment(t *testing.T) {
+	at := func(day int) time.Time {
+		return time.Date(2025, 1, day, 12, 0, 0, 0, time.UTC)
+	}
+
+	deployments := []types.Deployment{
+		{Environment: "staging", SHA: "ccc", State: types.DeploymentStateSuccess, CreatedAt: at(12)},
+		{Environment: "production", SHA: "aaa", State: types.DeploymentStateSuccess, RolledBack: true, CreatedAt: at(11)},
+		{Environment: "production", SHA: "bbb", State: types.DeploymentStateError, CreatedAt: at(10)},
+		{Environment: "production", SHA: "aaa", State: types.DeploymentStateInactive, CreatedAt: at(9)},
+		{Environment: "staging", SHA: "bbb", CreatedAt: at(8)},
+	}
+
+	got := groupDeploymentsByEnvironment(deployments)
+
+	if len(got) != 2 || got[0].Environment != "production" || got[1].Environment != "staging" {
+		t.Fatalf("got environments %+v, want production and staging", got)
+	}
+
+	production := got[0]
+	if len(production.Deployments) != 3 || production.Succeeded != 2 || production.Failed != 1 || production.RolledBack != 1 {
+		t.Errorf("got production %+v, want 3 deployments, 2 succeeded, 1 failed, 1 rolled back", production)
+	}
+	if !production.LastDeployed.Equal(at(11)) || production.LastSHA != "aaa" {
+		t.Errorf("got production last deployed %v at %s, want day 11 at aaa", production.LastDeployed, production.LastSHA)
+	}
+
+	// Deployments without a status are neither succeeded nor failed
+	if staging := got[1]; staging.Succeeded != 1 || staging.Failed != 0 {
+		t.Errorf("got staging %+v, want 1 succeeded, 0 failed", staging)
+	}
+
+	if problems := problemDeployments(deployments); len(problems) != 2 {
+		t.Errorf("got %d problem deployments, want the rollback and the error", len(problems))
+	}
+}
+
+func TestGenerateDeploymentsSection(t *testing.T) {
+	if got := generateDeploymentsSection(&types.ReportData{}); !strings.Contains(got, "No deployments were made during this period") {
+		t.Errorf("generateDeploymentsSection() without deployments = %q, want empty message", got)
+	}
+
+	data := &types.ReportData{
+		RepositoryURL: "https://github.com/o/r",
+		Deployments: []types.Deployment{
+			{
+				Environment: "production", Ref: "v1.0.0", SHA: "aaaaaaaaaa", State: types.DeploymentStateSuccess,
+				Creator: types.Author{Login: "alice"}, CreatedAt: time.Date(2025, 1, 11, 12, 0, 0, 0, time.UTC),
+				PreviousSHA: "bbbbbbbbbb", RolledBack: true,
+			},
+			{
+				Environment: "production", Ref: "v1.1.0", SHA: "bbbbbbbbbb", State: types.DeploymentStateFailure,
+				Creator: types.Author{Login: "alice"}, CreatedAt: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
+				URL: "https://github.com/o/r/actions/runs/4", PreviousSHA: "aaaaaaaaaa",
+				Commits: []types.Commit{
+					{SHA: "c1", Message: "Add search (#12)"},
+					{SHA: "c2cccccccc", Message: "Bump version", URL: "https://github.com/o/r/commit/c2cccccccc", Author: types.Author{Login: "bob"}},
+				},
+				PullRequests: []types.PullRequest{
+					{Number: 12, Title: "Add search", URL: "https://github.com/o/r/pull/12", Author: types.Author{Login: "bob"}},
+				},
+			},
+		},
+	}
+
+	got := generateDeploymentsSection(data)
+
+	for _, want := range []string{
+		"## 🚢 Deployments",
+		"| production | 2 | 1 | 1 | 1 | 2025-01-11 ([`aaaaaaa`](https://github.com/o/r/commit/aaaaaaaaaa)) |",
+		"### ⚠️ Failed and Rolled-Back Deployments",
+		"- **production**: 2025-01-11 12:00 [`aaaaaaa`](https://github.com/o/r/commit/aaaaaaaaaa) `v1.0.0` by [alice](https://github.com/alice): success, rolled back from bbbbbbb",
+		"- **production**: 2025-01-10 12:00 [`bbbbbbb`](https://github.com/o/r/commit/bbbbbbbbbb) `v1.1.0` by [alice](https://github.com/alice): [failure](https://github.com/o/r/actions/runs/4)",
+		"### production",
+		"  - Changes since `aaaaaaa`: 2 commits, 1 pull requests",
+		"  - [#12: Add search](https://github.com/o/r/pull/12) by [bob](https://github.com/bob)",
+		"  - [`c2ccccc`](https://github.com/o/r/commit/c2cccccccc) Bump version by [bob](https://github.com/bob)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	// The squash-merged commit is listed through its pull request only
+	if strings.Contains(got, "Add search (#12)") {
+		t.Errorf("expected commit of pull request #12 not to be listed, got:\n%s", got)
+	}
+}
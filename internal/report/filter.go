@@ -0,0 +1,155 @@
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// filterByUser restricts collected data to the activity of a single user.
+// Commits and pull requests are kept when authored by the user, issues when
+// authored by or assigned to the user. Branches without remaining commits are dropped.
+// Reviews the user submitted on other pull requests are kept in ReviewsGiven.
+func filterByUser(data *types.ReportData, login string) {
+	if login == "" {
+		return
+	}
+
+	data.User = login
+
+	// Filter branches and their commits
+	branches := make([]types.Branch, 0, len(data.Branches))
+	for _, branch := range data.Branches {
+		commits := make([]types.Commit, 0, len(branch.Commits))
+		for _, commit := range branch.Commits {
+			if isUser(commit.Author.Login, login) {
+				commits = append(commits, commit)
+			}
+		}
+
+		if len(commits) == 0 {
+			continue
+		}
+
+		branch.Commits = commits
+		branch.TotalAdded = 0
+		branch.TotalDeleted = 0
+		branch.IntroducedCommits = 0
+		for _, commit := range commits {
+			branch.TotalAdded += commit.Additions
+			branch.TotalDeleted += commit.Deletions
+			if commit.IntroducedOn == branch.Name {
+				branch.IntroducedCommits++
+			}
+		}
+		branch.Authors = commitAuthors(commits)
+
+		branches = append(branches, branch)
+	}
+	data.Branches = branches
+
+	// Keep the branches whose latest commit is by the user
+	heads := make([]types.BranchHead, 0, len(data.BranchHeads))
+	for _, head := range data.BranchHeads {
+		if isUser(head.Author.Login, login) {
+			heads = append(heads, head)
+		}
+	}
+	data.BranchHeads = heads
+
+	// Collect reviews the user gave during the period on other authors' pull
+	// requests before dropping them
+	data.ReviewsGiven = make([]types.ReviewActivity, 0)
+	for _, pr := range uniquePRs(data.OpenPRs, data.UpdatedPRs) {
+		if isUser(pr.Author.Login, login) {
+			continue
+		}
+		for _, review := range pr.ReviewDetails {
+			if isUser(review.Author.Login, login) && inPeriod(review.SubmittedAt, data.Period) {
+				data.ReviewsGiven = append(data.ReviewsGiven, types.ReviewActivity{
+					PRNumber: pr.Number,
+					PRTitle:  pr.Title,
+					PRURL:    pr.URL,
+					PRAuthor: pr.Author,
+					Review:   review,
+				})
+			}
+		}
+	}
+
+	// Filter pull requests (reviews received stay attached to the user's PRs)
+	data.OpenPRs = filterPRsByAuthor(data.OpenPRs, login)
+	data.UpdatedPRs = filterPRsByAuthor(data.UpdatedPRs, login)
+
+	// Filter issues
+	data.OpenIssues = filterIssuesByUser(data.OpenIssues, login)
+	data.ClosedIssues = filterIssuesByUser(data.ClosedIssues, login)
+}
+
+// filterAuthorStatsByUser keeps only statistics of the given user
+func filterAuthorStatsByUser(authorStats []types.AuthorStats, login string) []types.AuthorStats {
+	if login == "" {
+		return authorStats
+	}
+
+	result := make([]types.AuthorStats, 0, 1)
+	for _, stats := range authorStats {
+		if isUser(stats.Author.Login, login) {
+			result = append(result, stats)
+		}
+	}
+	return result
+}
+
+// filterPRsByAuthor keeps pull requests authored by the given user
+func filterPRsByAuthor(prs []types.PullRequest, login string) []types.PullRequest {
+	result := make([]types.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if isUser(pr.Author.Login, login) {
+			result = append(result, pr)
+		}
+	}
+	return result
+}
+
+// filterIssuesByUser keeps issues authored by or assigned to the given user
+func filterIssuesByUser(issues []types.Issue, login string) []types.Issue {
+	result := make([]types.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if isUser(issue.Author.Login, login) {
+			result = append(result, issue)
+			continue
+		}
+		for _, assignee := range issue.Assignees {
+			if isUser(assignee.Login, login) {
+				result = append(result, issue)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// isUser compares GitHub logins case-insensitively
+func isUser(login, user string) bool {
+	return strings.EqualFold(login, user)
+}
+
+// commitAuthors extracts unique author logins from commits and returns them sorted
+func commitAuthors(commits []types.Commit) []string {
+	authorMap := make(map[string]bool)
+	for _, commit := range commits {
+		if commit.Author.Login != "" {
+			authorMap[commit.Author.Login] = true
+		}
+	}
+
+	authors := make([]string, 0, len(authorMap))
+	for login := range authorMap {
+		authors = append(authors, login)
+	}
+	sort.Strings(authors)
+
+	return authors
+}
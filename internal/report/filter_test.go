@@ -0,0 +1,141 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestFilterByUser(t *testing.T) {
+	alice := types.Author{Login: "alice"}
+	bob := types.Author{Login: "bob"}
+
+	data := &types.ReportData{
+		Branches: []types.Branch{
+			{
+				Name: "main",
+				Commits: []types.Commit{
+					{SHA: "1", Author: alice, Additions: 10, Deletions: 1},
+					{SHA: "2", Author: bob, Additions: 20, Deletions: 2},
+				},
+				TotalAdded:   30,
+				TotalDeleted: 3,
+				Authors:      []string{"alice", "bob"},
+			},
+			{
+				Name:    "feature",
+				Commits: []types.Commit{{SHA: "3", Author: bob}},
+				Authors: []string{"bob"},
+			},
+		},
+		OpenPRs: []types.PullRequest{
+			{Number: 1, Author: alice},
+			{Number: 2, Author: bob},
+		},
+		UpdatedPRs: []types.PullRequest{
+			{Number: 3, Author: bob},
+		},
+		OpenIssues: []types.Issue{
+			{Number: 10, Author: alice},
+			{Number: 11, Author: bob, Assignees: []types.Author{alice}},
+			{Number: 12, Author: bob},
+		},
+		ClosedIssues: []types.Issue{
+			{Number: 13, Author: bob},
+		},
+	}
+
+	filterByUser(data, "Alice")
+
+	if data.User != "Alice" {
+		t.Errorf("filterByUser() User = %q, want Alice", data.User)
+	}
+	if len(data.Branches) != 1 {
+		t.Fatalf("filterByUser() kept %d branches, want 1", len(data.Branches))
+	}
+	branch := data.Branches[0]
+	if branch.Name != "main" || len(branch.Commits) != 1 {
+		t.Errorf("filterByUser() branch = %s with %d commits, want main with 1", branch.Name, len(branch.Commits))
+	}
+	if branch.TotalAdded != 10 || branch.TotalDeleted != 1 {
+		t.Errorf("filterByUser() branch totals = +%d/-%d, want +10/-1", branch.TotalAdded, branch.TotalDeleted)
+	}
+	if len(branch.Authors) != 1 || branch.Authors[0] != "alice" {
+		t.Errorf("filterByUser() branch authors = %v, want [alice]", branch.Authors)
+	}
+	if len(data.OpenPRs) != 1 || data.OpenPRs[0].Number != 1 {
+		t.Errorf("filterByUser() OpenPRs = %v, want only #1", data.OpenPRs)
+	}
+	if len(data.UpdatedPRs) != 0 {
+		t.Errorf("filterByUser() UpdatedPRs = %v, want none", data.UpdatedPRs)
+	}
+	if len(data.OpenIssues) != 2 {
+		t.Errorf("filterByUser() kept %d open issues, want 2 (authored and assigned)", len(data.OpenIssues))
+	}
+	if len(data.ClosedIssues) != 0 {
+		t.Errorf("filterByUser() kept %d closed issues, want 0", len(data.ClosedIssues))
+	}
+}
+
+func TestFilterByUserReviewsGiven(t *testing.T) {
+	alice := types.Author{Login: "alice"}
+	bob := types.Author{Login: "bob"}
+	period := types.Period{
+		From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+	}
+
+	data := &types.ReportData{
+		Period: period,
+		OpenPRs: []types.PullRequest{
+			{
+				Number: 2,
+				Author: bob,
+				ReviewDetails: []types.Review{
+					// Given before the period on a pull request that is still open
+					{Author: alice, State: types.ReviewStateChangesRequested, SubmittedAt: period.From.Add(-72 * time.Hour)},
+					{Author: alice, State: types.ReviewStateApproved, SubmittedAt: period.From.Add(24 * time.Hour)},
+					{Author: bob, State: types.ReviewStateCommented, SubmittedAt: period.From.Add(25 * time.Hour)},
+				},
+			},
+		},
+	}
+
+	filterByUser(data, "alice")
+
+	if len(data.ReviewsGiven) != 1 {
+		t.Fatalf("filterByUser() kept %d reviews given, want 1", len(data.ReviewsGiven))
+	}
+	if got := data.ReviewsGiven[0]; got.PRNumber != 2 || got.Review.State != types.ReviewStateApproved {
+		t.Errorf("filterByUser() review given = #%d %s, want #2 %s", got.PRNumber, got.Review.State, types.ReviewStateApproved)
+	}
+}
+
+func TestFilterByUserEmptyLogin(t *testing.T) {
+	data := &types.ReportData{
+		OpenPRs: []types.PullRequest{{Number: 1, Author: types.Author{Login: "bob"}}},
+	}
+
+	filterByUser(data, "")
+
+	if len(data.OpenPRs) != 1 {
+		t.Errorf("filterByUser() with empty login modified data")
+	}
+}
+
+func TestFilterAuthorStatsByUser(t *testing.T) {
+	stats := []types.AuthorStats{
+		{Author: types.Author{Login: "alice"}},
+		{Author: types.Author{Login: "bob"}},
+	}
+
+	got := filterAuthorStatsByUser(stats, "bob")
+	if len(got) != 1 || got[0].Author.Login != "bob" {
+		t.Errorf("filterAuthorStatsByUser() = %v, want only bob", got)
+	}
+
+	if got := filterAuthorStatsByUser(stats, ""); len(got) != 2 {
+		t.Errorf("filterAuthorStatsByUser() with empty login = %d entries, want 2", len(got))
+	}
+}
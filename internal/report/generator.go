@@ -1,6 +1,8 @@
 package report
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -17,39 +19,87 @@ import (
 
 // GitHubClient defines the interface for GitHub API operations
 type GitHubClient interface {
-	GetActiveBranches(repo string, from, to time.Time) ([]types.Branch, error)
-	GetOpenPullRequests(repo string) ([]types.PullRequest, error)
-	GetUpdatedPullRequests(repo, from, to string) ([]types.PullRequest, error)
-	GetOpenIssues(repo string) ([]types.Issue, error)
-	GetClosedIssues(repo, from, to string) ([]types.Issue, error)
+	GetActiveBranches(ctx context.Context, repo string, from, to time.Time) ([]types.Branch, error)
+	GetOpenPullRequests(ctx context.Context, repo string) ([]types.PullRequest, error)
+	GetUpdatedPullRequests(ctx context.Context, repo, from, to string) ([]types.PullRequest, error)
+	GetOpenIssues(ctx context.Context, repo string) ([]types.Issue, error)
+	GetClosedIssues(ctx context.Context, repo, from, to string) ([]types.Issue, error)
+	GetPullRequestReviews(ctx context.Context, repo string, prNumber int) ([]types.Review, error)
 }
 
 // LLMClient defines the interface for LLM operations
 type LLMClient interface {
-	GenerateOverallSummary(data *types.ReportData, language, model string) (string, error)
-	GenerateBranchSummary(branch *types.Branch, language, model string) (string, error)
-	GeneratePRSummary(pr *types.PullRequest, language, model string) (string, error)
+	GenerateOverallSummary(ctx context.Context, data *types.ReportData, language, model string) (string, error)
+	GenerateBranchSummary(ctx context.Context, branch *types.Branch, language, model string) (string, error)
+	GeneratePRSummary(ctx context.Context, pr *types.PullRequest, language, model string) (string, error)
 }
 
 // Generator generates reports based on GitHub activity data
 type Generator struct {
 	githubClient GitHubClient
 	llmClient    LLMClient
+	renderer     Renderer
+	snapshots    SnapshotStore
 	logger       *logger.Logger
 }
 
 // GenerationStats holds statistics about the report generation process
 type GenerationStats struct {
-	TotalBranches       int
-	TotalAISummaries    int
-	SuccessfulSummaries int
-	FailedSummaries     int
+	TotalBranches       int `json:"total_branches"`
+	TotalAISummaries    int `json:"total_ai_summaries"`
+	SuccessfulSummaries int `json:"successful_summaries"`
+	FailedSummaries     int `json:"failed_summaries"`
+	// Warnings lists data collection problems worth surfacing in the report
+	Warnings []string `json:"warnings,omitempty"`
 }
 
+// truncationReporter is implemented by GitHub clients that cap list results
+type truncationReporter interface {
+	TruncatedResources() []string
+}
+
+// releaseCollector is implemented by GitHub clients that list releases and tags
+type releaseCollector interface {
+	GetReleases(ctx context.Context, repo string, from, to time.Time) ([]types.Release, error)
+}
+
+// deploymentCollector is implemented by GitHub clients that list deployments
+type deploymentCollector interface {
+	GetDeployments(ctx context.Context, repo string, from, to time.Time) ([]types.Deployment, error)
+}
+
+// workflowCollector is implemented by GitHub clients that list GitHub Actions workflow runs
+type workflowCollector interface {
+	GetWorkflowRuns(ctx context.Context, repo string, from, to time.Time) ([]types.WorkflowRun, error)
+}
+
+// defaultBranchGetter is implemented by GitHub clients that look up the repository default branch
+type defaultBranchGetter interface {
+	GetDefaultBranch(ctx context.Context, repo string) (string, error)
+}
+
+// releaseSummarizer is implemented by LLM clients that summarize releases
+type releaseSummarizer interface {
+	GenerateReleaseSummary(ctx context.Context, release *types.Release, language, model string) (string, error)
+}
+
+// Supported output formats
+const (
+	// FormatMarkdown renders the report as Markdown (default)
+	FormatMarkdown = "markdown"
+	// FormatJSON renders the report as versioned JSON document
+	FormatJSON = "json"
+	// FormatHTML renders the report as a self-contained HTML page
+	FormatHTML = "html"
+)
+
 // Options contains configuration for report generation
 type Options struct {
 	// Repository is the repository name (owner/repo)
 	Repository string
+	// Repositories lists the repositories of a combined report.
+	// With more than one entry Repository is ignored.
+	Repositories []string
 	// Period is the time period to analyze
 	Period types.Period
 	// User is an optional filter by user login
@@ -58,6 +108,23 @@ type Options struct {
 	Model string
 	// Language is the output language for AI summaries
 	Language string
+	// Format is the output format (markdown, json or html)
+	Format string
+	// Template is an optional path to a text/template file used instead of the built-in layout
+	Template string
+	// Compare selects a period comparison (ComparePrevious) or none when empty
+	Compare string
+	// IncidentLabels lists the issue labels that mark incidents (DefaultIncidentLabels when empty)
+	IncidentLabels []string
+	// Hygiene enables the collection of branch heads, merged branches and pull
+	// request conflicts for the repository hygiene section
+	Hygiene bool
+	// StaleBranchDays is the number of days without commits after which a branch is stale
+	// (DefaultStaleBranchDays when zero)
+	StaleBranchDays int
+	// StalePRDays is the number of days without updates after which an open PR is stale
+	// (DefaultStalePRDays when zero)
+	StalePRDays int
 }
 
 // NewGenerator creates a new report generator
@@ -79,91 +146,184 @@ func NewGeneratorWithClients(ghClient GitHubClient, llmClient LLMClient) *Genera
 	}
 }
 
-// Generate generates a full report based on the provided options
-func (g *Generator) Generate(opts Options) (string, error) {
+// Generate generates a full report based on the provided options.
+// When ctx is cancelled or times out, the data collected so far is rendered
+// as a partial report with a warning instead of failing.
+func (g *Generator) Generate(ctx context.Context, opts Options) (string, error) {
+	switch len(opts.Repositories) {
+	case 0:
+	case 1:
+		opts.Repository = opts.Repositories[0]
+	default:
+		return g.generateMulti(ctx, opts)
+	}
+
 	// Initialize statistics
 	stats := &GenerationStats{}
 
 	// Collect data from GitHub
-	data, err := g.collectData(opts)
+	data, err := g.prepareData(ctx, opts, stats)
 	if err != nil {
 		return "", err
 	}
 
-	stats.TotalBranches = len(data.Branches)
+	g.addTruncationWarnings(stats)
+	g.summarize(ctx, data, opts, stats)
+	g.saveSnapshot(ctx, data)
 
-	// Generate AI summary if LLM client is available
-	var overallSummary string
-	if g.llmClient != nil {
-		g.logger.Info("Generating AI summaries...")
-		summary, err := g.llmClient.GenerateOverallSummary(data, opts.Language, opts.Model)
+	// Render report with the configured or requested renderer
+	renderer, err := g.selectRenderer(opts)
+	if err != nil {
+		return "", err
+	}
+
+	return renderer.Render(data, stats)
+}
+
+// prepareData collects the data of opts.Repository, applies the user filter
+// and calculates statistics
+func (g *Generator) prepareData(ctx context.Context, opts Options, stats *GenerationStats) (*types.ReportData, error) {
+	data, err := g.collectData(ctx, opts, stats)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restrict data to a single user if requested
+	if opts.User != "" {
+		filterByUser(data, opts.User)
+		g.logger.Info(fmt.Sprintf("Filtered activity for user %s: %d branches, %d open PRs, %d updated PRs, %d open issues, %d closed issues",
+			opts.User, len(data.Branches), len(data.OpenPRs), len(data.UpdatedPRs), len(data.OpenIssues), len(data.ClosedIssues)))
+	}
+
+	stats.TotalBranches += len(data.Branches)
+
+	// Calculate statistics before AI generation so prompts can use them
+	data.OverallStats = calculateOverallStats(data)
+	data.AuthorStats = filterAuthorStatsByUser(calculateAuthorStats(data), opts.User)
+	data.CycleTime = calculateCycleTime(data)
+	data.Delivery = calculateDeliveryMetrics(data, opts.IncidentLabels)
+	data.CI = calculateCIHealth(data.WorkflowRuns, data.DefaultBranch)
+	if opts.Hygiene {
+		data.Hygiene = calculateHygiene(data, opts.StaleBranchDays, opts.StalePRDays)
+	}
+	data.Hotspots = calculateHotspots(data)
+
+	// Compare with the previous period; a failure only drops the comparison
+	if opts.Compare == ComparePrevious && ctx.Err() == nil {
+		comparison, err := g.compareWithPrevious(ctx, opts, data)
 		if err != nil {
-			g.logger.Warning(fmt.Sprintf("Failed to generate overall summary: %v", err))
-			overallSummary = "Summary generation failed. Please check the activity details below."
-			stats.FailedSummaries++
+			warning := fmt.Sprintf("Comparison with the previous period failed: %v", err)
+			g.logger.Warning(warning)
+			stats.Warnings = append(stats.Warnings, warning)
 		} else {
-			overallSummary = summary
-			g.logger.Success("Overall summary generated")
-			stats.SuccessfulSummaries++
+			data.Comparison = comparison
 		}
-		stats.TotalAISummaries++
+	}
 
-		// Generate branch summaries in parallel with rate limiting
-		maxWorkers := 5 // Limit concurrent LLM requests
-		branchSummaryErrors := 0
-		var branchMu sync.Mutex
+	return data, nil
+}
 
-		err = utils.ProcessInParallel(data.Branches, maxWorkers, func(branch types.Branch) error {
-			branchSummary, err := g.llmClient.GenerateBranchSummary(&branch, opts.Language, opts.Model)
+// addTruncationWarnings warns when listings were cut at the item cap
+func (g *Generator) addTruncationWarnings(stats *GenerationStats) {
+	reporter, ok := g.githubClient.(truncationReporter)
+	if !ok {
+		return
+	}
 
-			// Find the branch in data.Branches and update it
-			branchMu.Lock()
-			for i := range data.Branches {
-				if data.Branches[i].Name == branch.Name {
-					if err != nil {
-						g.logger.Warning(fmt.Sprintf("Failed to generate summary for branch %s: %v", branch.Name, err))
-						data.Branches[i].AISummary = fmt.Sprintf("Development activity in branch %s", branch.Name)
-						branchSummaryErrors++
-					} else {
-						data.Branches[i].AISummary = branchSummary
-					}
-					stats.TotalAISummaries++
-					break
+	for _, resource := range reporter.TruncatedResources() {
+		g.logger.Warning(fmt.Sprintf("Results truncated: %s", resource))
+		stats.Warnings = append(stats.Warnings, fmt.Sprintf("Results were truncated at the item limit: %s", resource))
+	}
+}
+
+// summarize fills in the AI summaries of data if an LLM client is available
+func (g *Generator) summarize(ctx context.Context, data *types.ReportData, opts Options, stats *GenerationStats) {
+	switch {
+	case g.llmClient == nil:
+		data.AISummary = "[AI summary generation disabled]"
+	case ctx.Err() != nil:
+		data.AISummary = fmt.Sprintf("[AI summary skipped: the run %s]", interruptReason(ctx.Err()))
+	default:
+		data.AISummary = g.generateSummaries(ctx, data, opts, stats)
+	}
+}
+
+// generateSummaries generates the overall, branch and pull request AI summaries
+// and returns the overall summary. Summaries not generated before ctx is done are skipped.
+func (g *Generator) generateSummaries(ctx context.Context, data *types.ReportData, opts Options, stats *GenerationStats) string {
+	var overallSummary string
+
+	g.logger.Info("Generating AI summaries...")
+	summary, err := g.llmClient.GenerateOverallSummary(ctx, data, opts.Language, opts.Model)
+	if err != nil {
+		g.logger.Warning(fmt.Sprintf("Failed to generate overall summary: %v", err))
+		overallSummary = "Summary generation failed. Please check the activity details below."
+		stats.FailedSummaries++
+	} else {
+		overallSummary = summary
+		g.logger.Success("Overall summary generated")
+		stats.SuccessfulSummaries++
+	}
+	stats.TotalAISummaries++
+
+	// Generate branch summaries in parallel with rate limiting
+	maxWorkers := 5 // Limit concurrent LLM requests
+	branchSuccessCount := 0
+	branchSummaryErrors := 0
+	var branchMu sync.Mutex
+
+	err = utils.ProcessInParallelWithContext(ctx, data.Branches, maxWorkers, func(ctx context.Context, branch types.Branch) error {
+		branchSummary, err := g.llmClient.GenerateBranchSummary(ctx, &branch, opts.Language, opts.Model)
+
+		// Find the branch in data.Branches and update it
+		branchMu.Lock()
+		for i := range data.Branches {
+			if data.Branches[i].Name == branch.Name {
+				if err != nil {
+					g.logger.Warning(fmt.Sprintf("Failed to generate summary for branch %s: %v", branch.Name, err))
+					data.Branches[i].AISummary = fmt.Sprintf("Development activity in branch %s", branch.Name)
+					branchSummaryErrors++
+				} else {
+					data.Branches[i].AISummary = branchSummary
+					branchSuccessCount++
 				}
+				stats.TotalAISummaries++
+				break
 			}
-			branchMu.Unlock()
+		}
+		branchMu.Unlock()
 
-			// Don't fail the entire process if one summary fails
-			return nil
-		})
+		// Don't fail the entire process if one summary fails
+		return nil
+	})
 
-		if err != nil {
-			g.logger.Warning(fmt.Sprintf("Error generating branch summaries: %v", err))
-		}
+	if err != nil {
+		g.logger.Warning(fmt.Sprintf("Error generating branch summaries: %v", err))
+	}
 
-		stats.SuccessfulSummaries += (stats.TotalBranches - branchSummaryErrors)
-		stats.FailedSummaries += branchSummaryErrors
-		g.logger.Success(fmt.Sprintf("Branch summaries generated (%d/%d)", stats.TotalBranches-branchSummaryErrors, stats.TotalBranches))
+	stats.SuccessfulSummaries += branchSuccessCount
+	stats.FailedSummaries += branchSummaryErrors
+	g.logger.Success(fmt.Sprintf("Branch summaries generated (%d/%d)", branchSuccessCount, len(data.Branches)))
 
-		// Generate PR summaries in parallel
-		totalPRs := len(data.OpenPRs) + len(data.UpdatedPRs)
-		prSuccessCount := 0
-		prErrors := 0
-		var prMu sync.Mutex
+	// Generate PR summaries in parallel
+	totalPRs := len(data.OpenPRs) + len(data.UpdatedPRs)
+	prSuccessCount := 0
+	prErrors := 0
+	var prMu sync.Mutex
 
-		// Generate summaries for open PRs
-		err = utils.ProcessInParallel(data.OpenPRs, maxWorkers, func(pr types.PullRequest) error {
-			prSummary, err := g.llmClient.GeneratePRSummary(&pr, opts.Language, opts.Model)
+	summarizePRs := func(prs []types.PullRequest) error {
+		return utils.ProcessInParallelWithContext(ctx, prs, maxWorkers, func(ctx context.Context, pr types.PullRequest) error {
+			prSummary, err := g.llmClient.GeneratePRSummary(ctx, &pr, opts.Language, opts.Model)
 
 			prMu.Lock()
-			for i := range data.OpenPRs {
-				if data.OpenPRs[i].Number == pr.Number {
+			for i := range prs {
+				if prs[i].Number == pr.Number {
 					if err != nil {
 						g.logger.Warning(fmt.Sprintf("Failed to generate summary for PR #%d: %v", pr.Number, err))
-						data.OpenPRs[i].AISummary = fmt.Sprintf("Pull request: %s", pr.Title)
+						prs[i].AISummary = fmt.Sprintf("Pull request: %s", pr.Title)
 						prErrors++
 					} else {
-						data.OpenPRs[i].AISummary = prSummary
+						prs[i].AISummary = prSummary
 						prSuccessCount++
 					}
 					stats.TotalAISummaries++
@@ -173,69 +333,131 @@ func (g *Generator) Generate(opts Options) (string, error) {
 			prMu.Unlock()
 			return nil
 		})
+	}
 
-		if err != nil {
-			g.logger.Warning(fmt.Sprintf("Error generating open PR summaries: %v", err))
-		}
+	// Generate summaries for open PRs
+	if err := summarizePRs(data.OpenPRs); err != nil {
+		g.logger.Warning(fmt.Sprintf("Error generating open PR summaries: %v", err))
+	}
 
-		// Generate summaries for updated PRs
-		err = utils.ProcessInParallel(data.UpdatedPRs, maxWorkers, func(pr types.PullRequest) error {
-			prSummary, err := g.llmClient.GeneratePRSummary(&pr, opts.Language, opts.Model)
+	// Generate summaries for updated PRs
+	if err := summarizePRs(data.UpdatedPRs); err != nil {
+		g.logger.Warning(fmt.Sprintf("Error generating updated PR summaries: %v", err))
+	}
 
-			prMu.Lock()
-			for i := range data.UpdatedPRs {
-				if data.UpdatedPRs[i].Number == pr.Number {
-					if err != nil {
-						g.logger.Warning(fmt.Sprintf("Failed to generate summary for PR #%d: %v", pr.Number, err))
-						data.UpdatedPRs[i].AISummary = fmt.Sprintf("Pull request: %s", pr.Title)
-						prErrors++
-					} else {
-						data.UpdatedPRs[i].AISummary = prSummary
-						prSuccessCount++
-					}
-					stats.TotalAISummaries++
-					break
-				}
-			}
-			prMu.Unlock()
-			return nil
-		})
+	stats.SuccessfulSummaries += prSuccessCount
+	stats.FailedSummaries += prErrors
+	g.logger.Success(fmt.Sprintf("PR summaries generated (%d/%d)", prSuccessCount, totalPRs))
+
+	g.summarizeReleases(ctx, data, opts, stats)
+
+	if err := ctx.Err(); err != nil {
+		warning := fmt.Sprintf("AI summaries are incomplete: the run %s", interruptReason(err))
+		g.logger.Warning(warning)
+		stats.Warnings = append(stats.Warnings, warning)
+	}
+
+	return overallSummary
+}
+
+// summarizeReleases generates the AI summaries of releases in parallel if
+// the LLM client supports them
+func (g *Generator) summarizeReleases(ctx context.Context, data *types.ReportData, opts Options, stats *GenerationStats) {
+	summarizer, ok := g.llmClient.(releaseSummarizer)
+	if !ok || len(data.Releases) == 0 {
+		return
+	}
+
+	indexes := make([]int, len(data.Releases))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	successCount := 0
+	var mu sync.Mutex
+
+	// Each worker writes only its own release
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, 5, func(ctx context.Context, i int) error {
+		release := &data.Releases[i]
+		summary, err := summarizer.GenerateReleaseSummary(ctx, release, opts.Language, opts.Model)
 
+		mu.Lock()
+		defer mu.Unlock()
 		if err != nil {
-			g.logger.Warning(fmt.Sprintf("Error generating updated PR summaries: %v", err))
+			g.logger.Warning(fmt.Sprintf("Failed to generate summary for release %s: %v", release.TagName, err))
+			stats.FailedSummaries++
+		} else {
+			release.AISummary = summary
+			successCount++
+			stats.SuccessfulSummaries++
 		}
+		stats.TotalAISummaries++
+		return nil
+	})
 
-		stats.SuccessfulSummaries += prSuccessCount
-		stats.FailedSummaries += prErrors
-		g.logger.Success(fmt.Sprintf("PR summaries generated (%d/%d)", prSuccessCount, totalPRs))
-	} else {
-		overallSummary = "[AI summary generation disabled]"
+	g.logger.Success(fmt.Sprintf("Release summaries generated (%d/%d)", successCount, len(data.Releases)))
+}
+
+// selectRenderer picks the renderer for the report: an explicitly set renderer
+// takes precedence over a user-supplied template, which takes precedence over the format
+func (g *Generator) selectRenderer(opts Options) (Renderer, error) {
+	if g.renderer != nil {
+		return g.renderer, nil
 	}
 
-	// Generate markdown report
-	report := g.generateMarkdown(data, overallSummary, stats)
+	if opts.Template != "" {
+		return NewTemplateRendererFromFile(opts.Template)
+	}
+
+	return NewRenderer(opts.Format)
+}
 
-	return report, nil
+// SetRenderer overrides the renderer used to produce the final report
+func (g *Generator) SetRenderer(renderer Renderer) {
+	g.renderer = renderer
 }
 
-// collectData collects all necessary data from GitHub API in parallel
-func (g *Generator) collectData(opts Options) (*types.ReportData, error) {
+// collectData collects all necessary data from GitHub API in parallel.
+// Requests failing because ctx is done leave their part of the data empty
+// and add a warning to stats, so a partial report can still be rendered.
+func (g *Generator) collectData(ctx context.Context, opts Options, stats *GenerationStats) (*types.ReportData, error) {
 	// Convert times to ISO8601 format for API calls
 	fromISO := opts.Period.From.Format(time.RFC3339)
 	toISO := opts.Period.To.Format(time.RFC3339)
 
 	// Use errgroup for parallel data collection
-	var eg errgroup.Group
+	eg, egCtx := errgroup.WithContext(ctx)
 	var branches []types.Branch
 	var openPRs, updatedPRs []types.PullRequest
 	var openIssues, closedIssues []types.Issue
+	var releases []types.Release
+	var deployments []types.Deployment
+	var workflowRuns []types.WorkflowRun
+	var branchHeads []types.BranchHead
+	var defaultBranch string
+	var missing []string
 	var mu sync.Mutex
 
+	// interrupted records data left out because the run was cancelled or timed out
+	interrupted := func(what string, err error) bool {
+		if ctx.Err() == nil {
+			return false
+		}
+		g.logger.Warning(fmt.Sprintf("Stopped collecting %s: %v", what, err))
+		mu.Lock()
+		missing = append(missing, what)
+		mu.Unlock()
+		return true
+	}
+
 	// Get active branches
 	g.logger.Progress("Collecting branches...")
 	eg.Go(func() error {
-		b, err := g.githubClient.GetActiveBranches(opts.Repository, opts.Period.From, opts.Period.To)
+		b, err := g.githubClient.GetActiveBranches(egCtx, opts.Repository, opts.Period.From, opts.Period.To)
 		if err != nil {
+			if interrupted("branches", err) {
+				return nil
+			}
 			return err
 		}
 		mu.Lock()
@@ -247,8 +469,11 @@ func (g *Generator) collectData(opts Options) (*types.ReportData, error) {
 	// Get open and updated pull requests
 	g.logger.Progress("Collecting pull requests...")
 	eg.Go(func() error {
-		prs, err := g.githubClient.GetOpenPullRequests(opts.Repository)
+		prs, err := g.githubClient.GetOpenPullRequests(egCtx, opts.Repository)
 		if err != nil {
+			if interrupted("open pull requests", err) {
+				return nil
+			}
 			return err
 		}
 		mu.Lock()
@@ -258,8 +483,11 @@ func (g *Generator) collectData(opts Options) (*types.ReportData, error) {
 	})
 
 	eg.Go(func() error {
-		prs, err := g.githubClient.GetUpdatedPullRequests(opts.Repository, fromISO, toISO)
+		prs, err := g.githubClient.GetUpdatedPullRequests(egCtx, opts.Repository, fromISO, toISO)
 		if err != nil {
+			if interrupted("updated pull requests", err) {
+				return nil
+			}
 			return err
 		}
 		mu.Lock()
@@ -271,8 +499,11 @@ func (g *Generator) collectData(opts Options) (*types.ReportData, error) {
 	// Get open and closed issues
 	g.logger.Progress("Collecting issues...")
 	eg.Go(func() error {
-		issues, err := g.githubClient.GetOpenIssues(opts.Repository)
+		issues, err := g.githubClient.GetOpenIssues(egCtx, opts.Repository)
 		if err != nil {
+			if interrupted("open issues", err) {
+				return nil
+			}
 			return err
 		}
 		mu.Lock()
@@ -282,8 +513,11 @@ func (g *Generator) collectData(opts Options) (*types.ReportData, error) {
 	})
 
 	eg.Go(func() error {
-		issues, err := g.githubClient.GetClosedIssues(opts.Repository, fromISO, toISO)
+		issues, err := g.githubClient.GetClosedIssues(egCtx, opts.Repository, fromISO, toISO)
 		if err != nil {
+			if interrupted("closed issues", err) {
+				return nil
+			}
 			return err
 		}
 		mu.Lock()
@@ -292,11 +526,149 @@ func (g *Generator) collectData(opts Options) (*types.ReportData, error) {
 		return nil
 	})
 
+	// Get releases and tags; a failure only drops the releases section
+	if collector, ok := g.githubClient.(releaseCollector); ok {
+		g.logger.Progress("Collecting releases...")
+		eg.Go(func() error {
+			r, err := collector.GetReleases(egCtx, opts.Repository, opts.Period.From, opts.Period.To)
+			if err != nil {
+				if interrupted("releases", err) {
+					return nil
+				}
+				warning := fmt.Sprintf("Releases could not be collected: %v", err)
+				g.logger.Warning(warning)
+				mu.Lock()
+				stats.Warnings = append(stats.Warnings, warning)
+				mu.Unlock()
+				return nil
+			}
+			warnings := releaseWarnings(r)
+			for _, warning := range warnings {
+				g.logger.Warning(warning)
+			}
+			mu.Lock()
+			releases = r
+			stats.Warnings = append(stats.Warnings, warnings...)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	// Get deployments; a failure only drops the deployments section
+	if collector, ok := g.githubClient.(deploymentCollector); ok {
+		g.logger.Progress("Collecting deployments...")
+		eg.Go(func() error {
+			d, err := collector.GetDeployments(egCtx, opts.Repository, opts.Period.From, opts.Period.To)
+			if err != nil {
+				if interrupted("deployments", err) {
+					return nil
+				}
+				warning := fmt.Sprintf("Deployments could not be collected: %v", err)
+				g.logger.Warning(warning)
+				mu.Lock()
+				stats.Warnings = append(stats.Warnings, warning)
+				mu.Unlock()
+				return nil
+			}
+			mu.Lock()
+			deployments = d
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	// Get workflow runs and the default branch; a failure only drops the CI section
+	if collector, ok := g.githubClient.(workflowCollector); ok {
+		g.logger.Progress("Collecting workflow runs...")
+		eg.Go(func() error {
+			runs, err := collector.GetWorkflowRuns(egCtx, opts.Repository, opts.Period.From, opts.Period.To)
+			if err != nil {
+				if interrupted("workflow runs", err) {
+					return nil
+				}
+				warning := fmt.Sprintf("Workflow runs could not be collected: %v", err)
+				g.logger.Warning(warning)
+				mu.Lock()
+				stats.Warnings = append(stats.Warnings, warning)
+				mu.Unlock()
+				return nil
+			}
+			mu.Lock()
+			workflowRuns = runs
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	// Get the latest commit of every branch; a failure only drops stale branches
+	if lister, ok := g.githubClient.(branchHeadLister); ok && opts.Hygiene {
+		g.logger.Progress("Collecting branch heads...")
+		eg.Go(func() error {
+			heads, err := lister.GetBranchHeads(egCtx, opts.Repository)
+			if err != nil {
+				if interrupted("branch heads", err) {
+					return nil
+				}
+				warning := fmt.Sprintf("Branch heads could not be collected: %v", err)
+				g.logger.Warning(warning)
+				mu.Lock()
+				stats.Warnings = append(stats.Warnings, warning)
+				mu.Unlock()
+				return nil
+			}
+			mu.Lock()
+			branchHeads = heads
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if getter, ok := g.githubClient.(defaultBranchGetter); ok {
+		eg.Go(func() error {
+			branch, err := getter.GetDefaultBranch(egCtx, opts.Repository)
+			if err != nil {
+				if !interrupted("default branch", err) {
+					g.logger.Warning(fmt.Sprintf("Default branch could not be determined: %v", err))
+				}
+				return nil
+			}
+			mu.Lock()
+			defaultBranch = branch
+			mu.Unlock()
+			return nil
+		})
+	}
+
 	// Wait for all goroutines to complete
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
 
+	// Get reviews for all collected pull requests
+	g.logger.Progress("Collecting code reviews...")
+	reviewCount := g.collectReviews(ctx, opts.Repository, opts.Period, openPRs, updatedPRs)
+	if err := ctx.Err(); err != nil {
+		interrupted("code reviews", err)
+	}
+
+	// Keep only the commits of each branch that are not on the default branch
+	if _, ok := g.githubClient.(branchComparer); ok && defaultBranch != "" {
+		g.logger.Progress(fmt.Sprintf("Comparing branches with %s...", defaultBranch))
+		var warnings []string
+		branches, warnings = g.compareBranches(ctx, opts.Repository, defaultBranch, branches)
+		stats.Warnings = append(stats.Warnings, warnings...)
+		if err := ctx.Err(); err != nil {
+			interrupted("branch comparisons", err)
+		}
+	}
+
+	// Find branches that were merged into the default branch and conflicting pull requests
+	if opts.Hygiene && ctx.Err() == nil {
+		g.logger.Progress("Checking branches and pull requests for hygiene...")
+		stats.Warnings = append(stats.Warnings, g.markMergedBranches(ctx, opts.Repository, defaultBranch, branchHeads, updatedPRs)...)
+		stats.Warnings = append(stats.Warnings, g.markConflicts(ctx, opts.Repository, openPRs)...)
+	}
+
 	// Log results
 	g.logger.Success(fmt.Sprintf("Found %d active branches", len(branches)))
 
@@ -307,11 +679,25 @@ func (g *Generator) collectData(opts Options) (*types.ReportData, error) {
 	g.logger.Success(fmt.Sprintf("Collected %d commits", totalCommits))
 	g.logger.Success(fmt.Sprintf("Found %d open PRs, %d updated PRs", len(openPRs), len(updatedPRs)))
 	g.logger.Success(fmt.Sprintf("Found %d open issues, %d closed issues", len(openIssues), len(closedIssues)))
+	g.logger.Success(fmt.Sprintf("Found %d code reviews", reviewCount))
+	g.logger.Success(fmt.Sprintf("Found %d releases and tags", len(releases)))
+	g.logger.Success(fmt.Sprintf("Found %d deployments", len(deployments)))
+	g.logger.Success(fmt.Sprintf("Found %d workflow runs", len(workflowRuns)))
+	g.logger.Success(fmt.Sprintf("Found %d branch heads", len(branchHeads)))
+
+	if err := ctx.Err(); err != nil {
+		warning := fmt.Sprintf("Report is incomplete: the run %s before all data was collected", interruptReason(err))
+		if len(missing) > 0 {
+			warning += fmt.Sprintf(" (missing: %s)", strings.Join(missing, ", "))
+		}
+		stats.Warnings = append(stats.Warnings, warning)
+	}
 
 	// Create report data
 	data := &types.ReportData{
 		Repository:    opts.Repository,
 		RepositoryURL: "https://github.com/" + opts.Repository,
+		DefaultBranch: defaultBranch,
 		Period:        opts.Period,
 		GeneratedAt:   time.Now().UTC(),
 		Branches:      branches,
@@ -319,55 +705,87 @@ func (g *Generator) collectData(opts Options) (*types.ReportData, error) {
 		UpdatedPRs:    updatedPRs,
 		OpenIssues:    openIssues,
 		ClosedIssues:  closedIssues,
+		Releases:      releases,
+		Deployments:   deployments,
+		WorkflowRuns:  workflowRuns,
+		BranchHeads:   branchHeads,
 	}
+	linkBranchPullRequests(data)
+	attributeCommits(data)
+	linkReleasePullRequests(data)
+	linkDeploymentPullRequests(data)
 
 	return data, nil
 }
 
-// generateMarkdown generates a markdown report from collected data
-func (g *Generator) generateMarkdown(data *types.ReportData, overallSummary string, stats *GenerationStats) string {
-	var sb strings.Builder
-
-	// Calculate overall statistics
-	data.OverallStats = calculateOverallStats(data)
+// collectReviews fetches reviews for every unique pull request in parallel
+// and stores them in the provided slices. Returns the number of reviews
+// submitted during the period; older reviews are kept for cycle times only.
+// Failures for individual PRs are logged and don't fail the report.
+func (g *Generator) collectReviews(ctx context.Context, repo string, period types.Period, openPRs, updatedPRs []types.PullRequest) int {
+	// Collect unique PR numbers
+	seen := make(map[int]bool)
+	numbers := make([]int, 0, len(openPRs)+len(updatedPRs))
+	for _, pr := range append(append([]types.PullRequest{}, openPRs...), updatedPRs...) {
+		if !seen[pr.Number] {
+			seen[pr.Number] = true
+			numbers = append(numbers, pr.Number)
+		}
+	}
 
-	// Calculate author statistics
-	data.AuthorStats = calculateAuthorStats(data)
+	reviewsByPR := make(map[int][]types.Review)
+	var mu sync.Mutex
 
-	// Generate header
-	sb.WriteString(generateHeader(data))
+	maxWorkers := 5
+	_ = utils.ProcessInParallelWithContext(ctx, numbers, maxWorkers, func(ctx context.Context, number int) error {
+		reviews, err := g.githubClient.GetPullRequestReviews(ctx, repo, number)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			g.logger.Warning(fmt.Sprintf("Failed to get reviews for PR #%d: %v", number, err))
+			return nil
+		}
 
-	// Generate summary statistics
-	sb.WriteString(generateSummaryStats(data.OverallStats))
+		mu.Lock()
+		reviewsByPR[number] = reviews
+		mu.Unlock()
+		return nil
+	})
 
-	// Overall AI Summary
-	sb.WriteString("## 📊 Overall Summary\n\n")
-	sb.WriteString(overallSummary)
-	sb.WriteString("\n\n")
+	// Store reviews in PR objects, skipping replies of the PR author
+	// (GitHub records them as COMMENTED reviews)
+	total := 0
+	for _, prs := range [][]types.PullRequest{openPRs, updatedPRs} {
+		for i := range prs {
+			reviews, ok := reviewsByPR[prs[i].Number]
+			if !ok {
+				continue
+			}
 
-	// Generate branches section
-	if len(data.Branches) > 0 {
-		sb.WriteString(generateBranchesSection(data.Branches))
+			filtered := make([]types.Review, 0, len(reviews))
+			for _, review := range reviews {
+				if review.Author.Login != prs[i].Author.Login {
+					filtered = append(filtered, review)
+				}
+			}
+			prs[i].ReviewDetails = filtered
+			prs[i].Reviews = len(filtered)
+		}
+	}
+	for _, pr := range uniquePRs(openPRs, updatedPRs) {
+		total += len(reviewsInPeriod(pr.ReviewDetails, period))
 	}
 
-	// Generate pull requests sections
-	sb.WriteString(generateOpenPRsSection(data.OpenPRs))
-	sb.WriteString(generateUpdatedPRsSection(data.UpdatedPRs))
-
-	// Generate issues sections
-	sb.WriteString(generateOpenIssuesSection(data.OpenIssues))
-	sb.WriteString(generateClosedIssuesSection(data.ClosedIssues))
-
-	// Generate code reviews section
-	sb.WriteString(generateCodeReviewsSection(data))
-
-	// Generate author statistics section
-	sb.WriteString(generateAuthorStatsSection(data.AuthorStats))
-
-	// Generate footer with statistics
-	sb.WriteString(generateFooter(stats))
+	return total
+}
 
-	return sb.String()
+// interruptReason describes why the run stopped early for report warnings
+func interruptReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timed out"
+	}
+	return "was interrupted"
 }
 
 // generateFooter generates the report footer with generation statistics
@@ -384,5 +802,11 @@ func generateFooter(stats *GenerationStats) string {
 			stats.TotalAISummaries))
 	}
 
+	if stats != nil {
+		for _, warning := range stats.Warnings {
+			sb.WriteString(fmt.Sprintf("\n> ⚠️ %s\n", warning))
+		}
+	}
+
 	return sb.String()
 }
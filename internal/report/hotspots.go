@@ -0,0 +1,221 @@
+package report
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// maxHotspotRows is the number of files and directories listed per hotspot table
+const maxHotspotRows = 10
+
+// maxModuleRows is the number of modules listed in the churn table
+const maxModuleRows = 20
+
+// rootModule is the module name of files at the repository root
+const rootModule = "(root)"
+
+// pathAccumulator collects the statistics of a single path
+type pathAccumulator struct {
+	stats   types.PathStats
+	authors map[string]bool
+}
+
+// pathAccumulators collects statistics for a set of paths
+type pathAccumulators map[string]*pathAccumulator
+
+// add records a commit changing the path; a commit touching several files
+// under the same directory counts once for the directory
+func (a pathAccumulators) add(p string, commit types.Commit, additions, deletions int, counted map[string]bool) {
+	acc, exists := a[p]
+	if !exists {
+		acc = &pathAccumulator{stats: types.PathStats{Path: p}, authors: make(map[string]bool)}
+		a[p] = acc
+	}
+
+	if !counted[p] {
+		counted[p] = true
+		acc.stats.Commits++
+	}
+	acc.stats.Additions += additions
+	acc.stats.Deletions += deletions
+	if commit.Author.Login != "" {
+		acc.authors[commit.Author.Login] = true
+	}
+}
+
+// list returns the collected statistics with sorted author lists
+func (a pathAccumulators) list() []types.PathStats {
+	result := make([]types.PathStats, 0, len(a))
+	for _, acc := range a {
+		stats := acc.stats
+		stats.Authors = make([]string, 0, len(acc.authors))
+		for author := range acc.authors {
+			stats.Authors = append(stats.Authors, author)
+		}
+		sort.Strings(stats.Authors)
+		result = append(result, stats)
+	}
+	return result
+}
+
+// calculateHotspots finds the most changed files and directories, the churn
+// per top-level module and the files changed by the most authors. Every
+// commit is counted once even if it is on several branches. It returns nil
+// when no commit has file-level statistics.
+func calculateHotspots(data *types.ReportData) *types.Hotspots {
+	files := make(pathAccumulators)
+	directories := make(pathAccumulators)
+	modules := make(pathAccumulators)
+
+	hasFiles := false
+	for _, commit := range uniqueCommits(data.Branches) {
+		if len(commit.Files) == 0 {
+			continue
+		}
+		hasFiles = true
+
+		countedFiles := make(map[string]bool)
+		countedDirectories := make(map[string]bool)
+		countedModules := make(map[string]bool)
+		for _, file := range commit.Files {
+			files.add(file.Path, commit, file.Additions, file.Deletions, countedFiles)
+
+			// Files at the root are covered by the module table only
+			if dir := path.Dir(file.Path); dir != "." {
+				directories.add(dir, commit, file.Additions, file.Deletions, countedDirectories)
+			}
+
+			module := rootModule
+			if first, _, found := strings.Cut(file.Path, "/"); found {
+				module = first
+			}
+			modules.add(module, commit, file.Additions, file.Deletions, countedModules)
+		}
+	}
+
+	if !hasFiles {
+		return nil
+	}
+
+	hotspots := &types.Hotspots{
+		Files:       files.list(),
+		Directories: directories.list(),
+		Modules:     modules.list(),
+	}
+
+	// Files with a single author are not shared
+	hotspots.SharedFiles = make([]types.PathStats, 0)
+	for _, file := range hotspots.Files {
+		if len(file.Authors) > 1 {
+			hotspots.SharedFiles = append(hotspots.SharedFiles, file)
+		}
+	}
+
+	sortByCommits(hotspots.Files)
+	sortByCommits(hotspots.Directories)
+	sort.SliceStable(hotspots.Modules, func(i, j int) bool {
+		a, b := hotspots.Modules[i], hotspots.Modules[j]
+		if a.Churn() != b.Churn() {
+			return a.Churn() > b.Churn()
+		}
+		return a.Path < b.Path
+	})
+	sort.SliceStable(hotspots.SharedFiles, func(i, j int) bool {
+		a, b := hotspots.SharedFiles[i], hotspots.SharedFiles[j]
+		if len(a.Authors) != len(b.Authors) {
+			return len(a.Authors) > len(b.Authors)
+		}
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		return a.Path < b.Path
+	})
+
+	hotspots.Files = limitPathStats(hotspots.Files, maxHotspotRows)
+	hotspots.Directories = limitPathStats(hotspots.Directories, maxHotspotRows)
+	hotspots.Modules = limitPathStats(hotspots.Modules, maxModuleRows)
+	hotspots.SharedFiles = limitPathStats(hotspots.SharedFiles, maxHotspotRows)
+
+	return hotspots
+}
+
+// sortByCommits sorts paths by the number of commits, then by churn, highest first
+func sortByCommits(stats []types.PathStats) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		if a.Churn() != b.Churn() {
+			return a.Churn() > b.Churn()
+		}
+		return a.Path < b.Path
+	})
+}
+
+// limitPathStats returns at most limit entries of stats
+func limitPathStats(stats []types.PathStats, limit int) []types.PathStats {
+	if len(stats) > limit {
+		return stats[:limit]
+	}
+	return stats
+}
+
+// generateHotspotsSection generates the section listing the most changed
+// files, directories and modules
+func generateHotspotsSection(hotspots *types.Hotspots) string {
+	if hotspots == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("## 🔥 Hotspots\n\n")
+
+	sb.WriteString("### Most Changed Files\n\n")
+	writePathStatsTable(&sb, "File", hotspots.Files)
+
+	if len(hotspots.Directories) > 0 {
+		sb.WriteString("### Most Changed Directories\n\n")
+		writePathStatsTable(&sb, "Directory", hotspots.Directories)
+	}
+
+	sb.WriteString("### Churn by Module\n\n")
+	sb.WriteString("| Module | Commits | Lines Changed | Authors |\n")
+	sb.WriteString("|--------|---------|---------------|---------|\n")
+	for _, module := range hotspots.Modules {
+		name := module.Path
+		if name != rootModule {
+			name = fmt.Sprintf("`%s`", name)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d (+%d/-%d) | %d |\n",
+			name, module.Commits, module.Churn(), module.Additions, module.Deletions, len(module.Authors)))
+	}
+	sb.WriteString("\n")
+
+	if len(hotspots.SharedFiles) > 0 {
+		sb.WriteString("### Files with Most Authors\n\n")
+		for _, file := range hotspots.SharedFiles {
+			sb.WriteString(fmt.Sprintf("- `%s`: %d authors (%s) in %d commits\n",
+				file.Path, len(file.Authors), formatAuthorLinks(file.Authors), file.Commits))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// writePathStatsTable writes a table of files or directories with their changes
+func writePathStatsTable(sb *strings.Builder, column string, stats []types.PathStats) {
+	sb.WriteString(fmt.Sprintf("| %s | Commits | Lines Changed | Authors |\n", column))
+	sb.WriteString(fmt.Sprintf("|%s|---------|---------------|---------|\n", strings.Repeat("-", len(column)+2)))
+	for _, s := range stats {
+		sb.WriteString(fmt.Sprintf("| `%s` | %d | %d (+%d/-%d) | %d |\n",
+			s.Path, s.Commits, s.Churn(), s.Additions, s.Deletions, len(s.Authors)))
+	}
+	sb.WriteString("\n")
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// hotspotData returns two branches sharing a commit, with changes to files at
+// the root and in nested directories by three authors
+func hotspotData() *types.ReportData {
+	alice := types.Author{Login: "alice"}
+	bob := types.Author{Login: "bob"}
+	carol := types.Author{Login: "carol"}
+
+	shared := types.Commit{SHA: "c1", Author: alice, Files: []types.FileChange{
+		{Path: "internal/api/server.go", Additions: 10, Deletions: 2},
+		{Path: "internal/api/routes.go", Additions: 5, Deletions: 0},
+	}}
+
+	return &types.ReportData{
+		Branches: []types.Branch{
+			{Name: "main", Commits: []types.Commit{
+				shared,
+				{SHA: "c2", Author: bob, Files: []types.FileChange{
+					{Path: "internal/api/server.go", Additions: 3, Deletions: 3},
+					{Path: "README.md", Additions: 1, Deletions: 0},
+				}},
+			}},
+			{Name: "feature", Commits: []types.Commit{
+				shared,
+				{SHA: "c3", Author: carol, Files: []types.FileChange{
+					{Path: "internal/api/server.go", Additions: 1, Deletions: 1},
+					{Path: "cmd/app/main.go", Additions: 40, Deletions: 0},
+				}},
+			}},
+		},
+	}
+}
+
+func TestCalculateHotspots(t *testing.T) {
+	hotspots := calculateHotspots(hotspotData())
+	if hotspots == nil {
+		t.Fatal("calculateHotspots() = nil, want hotspots")
+	}
+
+	// The commit shared by both branches is counted once
+	server := hotspots.Files[0]
+	if server.Path != "internal/api/server.go" || server.Commits != 3 || server.Additions != 14 || server.Deletions != 6 {
+		t.Errorf("got top file %+v, want internal/api/server.go with 3 commits, +14/-6", server)
+	}
+	if got := strings.Join(server.Authors, ","); got != "alice,bob,carol" {
+		t.Errorf("got authors %s, want alice,bob,carol", got)
+	}
+
+	// A commit touching two files in a directory counts once for the directory
+	if dir := hotspots.Directories[0]; dir.Path != "internal/api" || dir.Commits != 3 {
+		t.Errorf("got top directory %+v, want internal/api with 3 commits", dir)
+	}
+	for _, dir := range hotspots.Directories {
+		if dir.Path == "." {
+			t.Error("got root directory, want root files only in modules")
+		}
+	}
+
+	var modules []string
+	for _, module := range hotspots.Modules {
+		modules = append(modules, module.Path)
+	}
+	if got := strings.Join(modules, ","); got != "cmd,internal,(root)" {
+		t.Errorf("got modules %s, want cmd,internal,(root) by churn", got)
+	}
+
+	if len(hotspots.SharedFiles) != 1 || hotspots.SharedFiles[0].Path != "internal/api/server.go" {
+		t.Errorf("got shared files %+v, want only internal/api/server.go", hotspots.SharedFiles)
+	}
+}
+
+func TestCalculateHotspotsWithoutFiles(t *testing.T) {
+	data := &types.ReportData{Branches: []types.Branch{
+		{Name: "main", Commits: []types.Commit{{SHA: "c1", Additions: 5}}},
+	}}
+
+	if hotspots := calculateHotspots(data); hotspots != nil {
+		t.Errorf("calculateHotspots() = %+v, want nil without file statistics", hotspots)
+	}
+}
+
+func TestGenerateHotspotsSection(t *testing.T) {
+	if got := generateHotspotsSection(nil); got != "" {
+		t.Errorf("generateHotspotsSection(nil) = %q, want empty", got)
+	}
+
+	hotspots := calculateHotspots(hotspotData())
+	got := generateHotspotsSection(hotspots)
+
+	for _, want := range []string{
+		"## 🔥 Hotspots",
+		"| `internal/api/server.go` | 3 | 20 (+14/-6) | 3 |",
+		"| `internal/api` | 3 | 25 (+19/-6) | 3 |",
+		"| (root) | 1 | 1 (+1/-0) | 1 |",
+		"- `internal/api/server.go`: 3 authors ([alice](https://github.com/alice), [bob](https://github.com/bob), [carol](https://github.com/carol)) in 3 commits",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	html := generateHTMLHotspotsSection("hotspots", hotspots)
+	if !strings.Contains(html, "<section id=\"hotspots\">") || !strings.Contains(html, "<code>internal/api/server.go</code>") {
+		t.Errorf("generateHTMLHotspotsSection() missing section or file, got:\n%s", html)
+	}
+}
@@ -0,0 +1,1219 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// htmlStyles contains the inline CSS for the self-contained HTML report
+const htmlStyles = `
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; max-width: 1100px; margin: 0 auto; padding: 24px; line-height: 1.5; }
+h1, h2, h3 { border-bottom: 1px solid #d1d9e0; padding-bottom: 0.3em; }
+a { color: #0969da; text-decoration: none; }
+a:hover { text-decoration: underline; }
+nav ul { list-style: none; padding-left: 0; display: flex; flex-wrap: wrap; gap: 12px; }
+.meta { color: #59636e; }
+.stats { display: grid; grid-template-columns: repeat(auto-fill, minmax(170px, 1fr)); gap: 12px; padding: 0; list-style: none; }
+.stats li { border: 1px solid #d1d9e0; border-radius: 6px; padding: 12px; }
+.stats .value { display: block; font-size: 1.6em; font-weight: 600; }
+.summary { white-space: pre-wrap; background: #f6f8fa; border-radius: 6px; padding: 12px; }
+details { border: 1px solid #d1d9e0; border-radius: 6px; padding: 8px 12px; margin: 8px 0; }
+details > summary { cursor: pointer; font-weight: 600; }
+details details { border-color: #eaeef2; }
+pre { background: #f6f8fa; padding: 8px; border-radius: 6px; overflow-x: auto; }
+.added { color: #1a7f37; }
+.deleted { color: #d1242f; }
+table { border-collapse: collapse; width: 100%; margin: 12px 0; }
+th, td { border: 1px solid #d1d9e0; padding: 6px 10px; text-align: left; }
+th.sortable { cursor: pointer; background: #f6f8fa; user-select: none; }
+th.sortable::after { content: " \2195"; color: #8c959f; }
+footer { margin-top: 32px; color: #59636e; font-size: 0.9em; }
+footer .warning { color: #9a6700; }
+`
+
+// htmlScript contains the inline JavaScript that makes tables sortable
+const htmlScript = `
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th.sortable").forEach(function (th, index) {
+    var asc = false;
+    th.addEventListener("click", function () {
+      var tbody = table.tBodies[0];
+      var rows = Array.prototype.slice.call(tbody.rows);
+      asc = !asc;
+      rows.sort(function (a, b) {
+        var x = a.cells[index].getAttribute("data-value") || a.cells[index].textContent;
+        var y = b.cells[index].getAttribute("data-value") || b.cells[index].textContent;
+        var nx = parseFloat(x), ny = parseFloat(y);
+        var cmp = (!isNaN(nx) && !isNaN(ny)) ? nx - ny : x.localeCompare(y);
+        return asc ? cmp : -cmp;
+      });
+      rows.forEach(function (row) { tbody.appendChild(row); });
+    });
+  });
+});
+`
+
+// anchorPattern matches characters that are not allowed in HTML anchors
+var anchorPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// generateHTML generates a self-contained HTML report from collected data
+func generateHTML(data *types.ReportData, stats *GenerationStats) string {
+	var sb strings.Builder
+	anchors := newHTMLAnchors()
+
+	sb.WriteString(generateHTMLDocumentStart(fmt.Sprintf("Repository Activity Report: %s", data.Repository)))
+
+	sb.WriteString(generateHTMLHeader(data))
+	sb.WriteString(generateHTMLNavigation(data))
+	sb.WriteString(generateHTMLSummaryStats(data.OverallStats))
+	sb.WriteString(generateHTMLComparisonSection(data.Comparison))
+	sb.WriteString(generateHTMLUserSection(anchors, "user-activity", "branch", data))
+
+	// Overall AI Summary
+	sb.WriteString("<section id=\"overall-summary\">\n<h2>📊 Overall Summary</h2>\n")
+	sb.WriteString(fmt.Sprintf("<div class=\"summary\">%s</div>\n</section>\n", html.EscapeString(data.AISummary)))
+
+	sb.WriteString(generateHTMLBranchesSection(anchors, data.Branches))
+	sb.WriteString(generateHTMLPRsSection("open-prs", "🔀 Open Pull Requests", "No open pull requests", data.OpenPRs))
+	sb.WriteString(generateHTMLMergedPRsSection("merged-prs", data))
+	sb.WriteString(generateHTMLReleasesSection(anchors, "releases", data.Releases))
+	sb.WriteString(generateHTMLDeploymentsSection(anchors, "deployments", data))
+	sb.WriteString(generateHTMLPRsSection("updated-prs", "🔄 Updated Pull Requests", "No pull requests were updated during this period", data.UpdatedPRs))
+	sb.WriteString(generateHTMLIssuesSection("open-issues", "📋 Open Issues", "No open issues", data.OpenIssues))
+	sb.WriteString(generateHTMLIssuesSection("closed-issues", "✅ Closed Issues", "No issues were closed during this period", data.ClosedIssues))
+	sb.WriteString(generateHTMLCycleTimeSection("cycle-time", data.CycleTime))
+	sb.WriteString(generateHTMLDeliverySection("delivery", data.Delivery))
+	sb.WriteString(generateHTMLCISection("ci", data.CI))
+	sb.WriteString(generateHTMLHygieneSection("hygiene", data))
+	sb.WriteString(generateHTMLHotspotsSection("hotspots", data.Hotspots))
+	sb.WriteString(generateHTMLAuthorStatsSection(anchors, data.AuthorStats))
+	sb.WriteString(generateHTMLFooter(stats))
+	sb.WriteString(generateHTMLDocumentEnd())
+
+	return sb.String()
+}
+
+// generateMultiHTML generates a self-contained HTML report covering several repositories
+func generateMultiHTML(data *types.MultiReportData, stats *GenerationStats) string {
+	var sb strings.Builder
+	anchors := newHTMLAnchors()
+
+	sb.WriteString(generateHTMLDocumentStart(fmt.Sprintf("Activity Report: %d Repositories", len(data.Repositories))))
+	sb.WriteString(generateHTMLMultiHeader(data))
+
+	// Navigation
+	sb.WriteString("<nav>\n<ul>\n")
+	sb.WriteString("<li><a href=\"#summary-statistics\">Summary Statistics</a></li>\n")
+	sb.WriteString("<li><a href=\"#repositories\">Repositories</a></li>\n")
+	sb.WriteString("<li><a href=\"#author-activity\">Author Activity</a></li>\n")
+	for _, report := range data.Reports {
+		sb.WriteString(fmt.Sprintf("<li><a href=\"#%s\">%s</a></li>\n", anchors.anchor("repo", report.Repository), html.EscapeString(report.Repository)))
+	}
+	sb.WriteString("</ul>\n</nav>\n")
+
+	sb.WriteString(generateHTMLSummaryStats(data.OverallStats))
+	sb.WriteString(generateHTMLRepositoriesSection(anchors, data.Reports))
+	sb.WriteString(generateHTMLAuthorStatsSection(anchors, data.AuthorStats))
+	for _, report := range data.Reports {
+		sb.WriteString(generateHTMLRepositorySection(anchors, report))
+	}
+	sb.WriteString(generateHTMLFooter(stats))
+	sb.WriteString(generateHTMLDocumentEnd())
+
+	return sb.String()
+}
+
+// generateHTMLDocumentStart generates the document head with inline styles and opens the body
+func generateHTMLDocumentStart(title string) string {
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n")
+	sb.WriteString("<meta charset=\"utf-8\">\n")
+	sb.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(title)))
+	sb.WriteString("<style>")
+	sb.WriteString(htmlStyles)
+	sb.WriteString("</style>\n</head>\n<body>\n")
+
+	return sb.String()
+}
+
+// generateHTMLDocumentEnd adds the inline script and closes the document
+func generateHTMLDocumentEnd() string {
+	return "<script>" + htmlScript + "</script>\n</body>\n</html>\n"
+}
+
+// htmlAnchor converts a name into a value usable as an HTML id attribute
+func htmlAnchor(prefix, name string) string {
+	slug := strings.Trim(anchorPattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	return prefix + "-" + slug
+}
+
+// htmlAnchors hands out the ids of a single HTML document. Names that reduce to
+// the same slug (feature/x and feature-x) get a numbered suffix in the order
+// they are first seen, so that every id stays unique.
+type htmlAnchors struct {
+	ids  map[string]string
+	used map[string]bool
+}
+
+// newHTMLAnchors creates an empty anchor set for a new document
+func newHTMLAnchors() *htmlAnchors {
+	return &htmlAnchors{ids: make(map[string]string), used: make(map[string]bool)}
+}
+
+// anchor returns the id of name under prefix; links and their targets asking
+// for the same name get the same id
+func (a *htmlAnchors) anchor(prefix, name string) string {
+	key := prefix + "\x00" + name
+	if id, ok := a.ids[key]; ok {
+		return id
+	}
+
+	base := htmlAnchor(prefix, name)
+	id := base
+	for n := 2; a.used[id]; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	a.ids[key] = id
+	a.used[id] = true
+
+	return id
+}
+
+// htmlLink formats an escaped HTML link
+func htmlLink(text, url string) string {
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(url), html.EscapeString(text))
+}
+
+// htmlAuthorLinks formats a list of author logins as HTML links
+func htmlAuthorLinks(authors []string) string {
+	if len(authors) == 0 {
+		return "none"
+	}
+
+	links := make([]string, len(authors))
+	for i, author := range authors {
+		links[i] = htmlLink(author, "https://github.com/"+author)
+	}
+	return strings.Join(links, ", ")
+}
+
+// generateHTMLHeader generates the report header with repository information
+func generateHTMLHeader(data *types.ReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString("<header>\n")
+	sb.WriteString(fmt.Sprintf("<h1>Repository Activity Report: %s</h1>\n", html.EscapeString(repoDisplayName(data.Repository))))
+	sb.WriteString(fmt.Sprintf("<p class=\"meta\"><strong>Repository</strong>: %s<br>\n", htmlLink(data.Repository, data.RepositoryURL)))
+	if data.User != "" {
+		sb.WriteString(fmt.Sprintf("<strong>User</strong>: %s<br>\n", htmlAuthorLinks([]string{data.User})))
+	}
+	sb.WriteString(fmt.Sprintf("<strong>Period</strong>: %s to %s<br>\n",
+		data.Period.From.Format("2006-01-02"),
+		data.Period.To.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("<strong>Report Generated</strong>: %s UTC</p>\n",
+		data.GeneratedAt.Format("2006-01-02 15:04:05")))
+	sb.WriteString("</header>\n")
+
+	return sb.String()
+}
+
+// generateHTMLNavigation generates anchor links to all report sections
+func generateHTMLNavigation(data *types.ReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString("<nav>\n<ul>\n")
+	sb.WriteString("<li><a href=\"#summary-statistics\">Summary Statistics</a></li>\n")
+	if data.Comparison != nil {
+		sb.WriteString("<li><a href=\"#comparison\">Comparison</a></li>\n")
+	}
+	if data.User != "" {
+		sb.WriteString("<li><a href=\"#user-activity\">User Activity</a></li>\n")
+	}
+	sb.WriteString("<li><a href=\"#overall-summary\">Overall Summary</a></li>\n")
+	if len(data.Branches) > 0 {
+		sb.WriteString("<li><a href=\"#branches\">Branches</a></li>\n")
+	}
+	sb.WriteString("<li><a href=\"#open-prs\">Open Pull Requests</a></li>\n")
+	sb.WriteString("<li><a href=\"#merged-prs\">Merged Pull Requests</a></li>\n")
+	sb.WriteString("<li><a href=\"#releases\">Releases</a></li>\n")
+	sb.WriteString("<li><a href=\"#deployments\">Deployments</a></li>\n")
+	sb.WriteString("<li><a href=\"#updated-prs\">Updated Pull Requests</a></li>\n")
+	sb.WriteString("<li><a href=\"#open-issues\">Open Issues</a></li>\n")
+	sb.WriteString("<li><a href=\"#closed-issues\">Closed Issues</a></li>\n")
+	if data.CycleTime != nil {
+		sb.WriteString("<li><a href=\"#cycle-time\">Cycle Time</a></li>\n")
+	}
+	if data.Delivery != nil {
+		sb.WriteString("<li><a href=\"#delivery\">Delivery Metrics</a></li>\n")
+	}
+	if data.CI != nil {
+		sb.WriteString("<li><a href=\"#ci\">CI Health</a></li>\n")
+	}
+	if data.Hygiene != nil {
+		sb.WriteString("<li><a href=\"#hygiene\">Repository Hygiene</a></li>\n")
+	}
+	if data.Hotspots != nil {
+		sb.WriteString("<li><a href=\"#hotspots\">Hotspots</a></li>\n")
+	}
+	sb.WriteString("<li><a href=\"#author-activity\">Author Activity</a></li>\n")
+	sb.WriteString("</ul>\n</nav>\n")
+
+	return sb.String()
+}
+
+// generateHTMLSummaryStats generates the summary statistics section
+func generateHTMLSummaryStats(stats types.OverallStats) string {
+	var sb strings.Builder
+
+	sb.WriteString("<section id=\"summary-statistics\">\n<h2>Summary Statistics</h2>\n<ul class=\"stats\">\n")
+	items := []struct {
+		label string
+		value int
+	}{
+		{"Total Commits", stats.TotalCommits},
+		{"Total Authors", stats.TotalAuthors},
+		{"Open Pull Requests", stats.OpenPRCount},
+		{"Merged Pull Requests", stats.MergedPRCount},
+		{"Closed Without Merge", stats.ClosedUnmergedPRCount},
+		{"Open Issues", stats.OpenIssuesCount},
+		{"Closed Issues", stats.ClosedIssuesCount},
+		{"Code Reviews", stats.ReviewsCount},
+	}
+	for _, item := range items {
+		sb.WriteString(fmt.Sprintf("<li><span class=\"value\">%d</span>%s</li>\n", item.value, item.label))
+	}
+	sb.WriteString("</ul>\n</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLUserSection generates the person-focused overview for a
+// user-filtered report; branch links point to the anchors with branchPrefix
+func generateHTMLUserSection(anchors *htmlAnchors, id, branchPrefix string, data *types.ReportData) string {
+	if data.User == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>👤 Activity of %s</h2>\n", id, html.EscapeString(data.User)))
+
+	sb.WriteString("<h3>Branches</h3>\n")
+	if len(data.Branches) == 0 {
+		sb.WriteString("<p>No commits during this period</p>\n")
+	} else {
+		sb.WriteString("<ul>\n")
+		for _, branch := range data.Branches {
+			sb.WriteString(fmt.Sprintf("<li><a href=\"#%s\">%s</a>: %d commits (<span class=\"added\">+%d</span> / <span class=\"deleted\">-%d</span> lines)</li>\n",
+				anchors.anchor(branchPrefix, branch.Name), html.EscapeString(branch.Name), len(branch.Commits), branch.TotalAdded, branch.TotalDeleted))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("<h3>Pull Requests</h3>\n")
+	prs := uniquePRs(data.OpenPRs, data.UpdatedPRs)
+	if len(prs) == 0 {
+		sb.WriteString("<p>No pull requests authored during this period</p>\n")
+	} else {
+		sb.WriteString("<ul>\n")
+		for _, pr := range prs {
+			sb.WriteString(fmt.Sprintf("<li>%s - %s, %d reviews received</li>\n",
+				htmlLink(fmt.Sprintf("PR #%d: %s", pr.Number, pr.Title), pr.URL), html.EscapeString(pr.State), pr.Reviews))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("<h3>Reviews Given</h3>\n")
+	if len(data.ReviewsGiven) == 0 {
+		sb.WriteString("<p>No reviews given on other pull requests</p>\n")
+	} else {
+		sb.WriteString("<ul>\n")
+		for _, activity := range data.ReviewsGiven {
+			sb.WriteString(fmt.Sprintf("<li>%s by %s - %s on %s</li>\n",
+				htmlLink(fmt.Sprintf("PR #%d: %s", activity.PRNumber, activity.PRTitle), activity.PRURL),
+				htmlAuthorLinks([]string{activity.PRAuthor.Login}),
+				formatReviewStates(map[string]int{activity.Review.State: 1}),
+				activity.Review.SubmittedAt.Format("2006-01-02")))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLBranchesSection generates collapsible sections for all branches
+func generateHTMLBranchesSection(anchors *htmlAnchors, branches []types.Branch) string {
+	if len(branches) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("<section id=\"branches\">\n<h2>🌿 Branches</h2>\n")
+	for _, branch := range branches {
+		sb.WriteString(generateHTMLBranchSection(anchors, "branch", branch))
+	}
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLBranchSection generates a collapsible section for a single branch.
+// The anchor prefix keeps branch ids unique when several repositories share a page.
+func generateHTMLBranchSection(anchors *htmlAnchors, anchorPrefix string, branch types.Branch) string {
+	var sb strings.Builder
+
+	anchor := anchors.anchor(anchorPrefix, branch.Name)
+	sb.WriteString(fmt.Sprintf("<details id=\"%s\" open>\n", anchor))
+	commits := fmt.Sprintf("%d commits", len(branch.Commits))
+	if inherited := inheritedCommits(branch); inherited > 0 {
+		commits += fmt.Sprintf(", %d introduced on other branches", inherited)
+	}
+	sb.WriteString(fmt.Sprintf("<summary>%s <a href=\"#%s\">#</a> <span class=\"meta\">(%s, <span class=\"added\">+%d</span> / <span class=\"deleted\">-%d</span>)</span></summary>\n",
+		html.EscapeString(branch.Name), anchor, commits, branch.TotalAdded, branch.TotalDeleted))
+
+	if branch.AISummary != "" {
+		sb.WriteString("<h3>AI Summary</h3>\n")
+		sb.WriteString(fmt.Sprintf("<div class=\"summary\">%s</div>\n", html.EscapeString(branch.AISummary)))
+	}
+
+	if branch.BaseBranch != "" {
+		sb.WriteString(fmt.Sprintf("<p><strong>Compared to Default Branch</strong>: %d ahead, %d behind <code>%s</code></p>\n",
+			branch.Ahead, branch.Behind, html.EscapeString(branch.BaseBranch)))
+	}
+	if !branch.LastActivity.IsZero() {
+		sb.WriteString(fmt.Sprintf("<p><strong>Last Activity</strong>: %s</p>\n", formatDate(branch.LastActivity)))
+	}
+	if branch.BaseBranch != "" || len(branch.PRs) > 0 {
+		prs := "none"
+		if len(branch.PRs) > 0 {
+			links := make([]string, len(branch.PRs))
+			for i, pr := range branch.PRs {
+				links[i] = fmt.Sprintf("%s (%s)", htmlLink(fmt.Sprintf("#%d: %s", pr.Number, pr.Title), pr.URL), html.EscapeString(pr.State))
+			}
+			prs = strings.Join(links, ", ")
+		}
+		sb.WriteString(fmt.Sprintf("<p><strong>Pull Requests</strong>: %s</p>\n", prs))
+	}
+	sb.WriteString(fmt.Sprintf("<p><strong>Contributors</strong>: %s</p>\n", htmlAuthorLinks(branch.Authors)))
+
+	sb.WriteString("<h3>Commits</h3>\n")
+	for _, commit := range branch.Commits {
+		short, full := formatCommitMessage(commit.Message)
+
+		sb.WriteString("<details>\n")
+		sb.WriteString(fmt.Sprintf("<summary>%s</summary>\n", html.EscapeString(short)))
+		sb.WriteString(fmt.Sprintf("<p>%s | <strong>Author</strong>: %s | <strong>Date</strong>: %s | <span class=\"added\">+%d</span> / <span class=\"deleted\">-%d</span> lines",
+			htmlLink(shortSHA(commit.SHA), commit.URL),
+			htmlLink(commit.Author.Login, commit.Author.ProfileURL),
+			formatDate(commit.Date),
+			commit.Additions,
+			commit.Deletions))
+		if commit.IntroducedOn != "" && commit.IntroducedOn != branch.Name {
+			sb.WriteString(fmt.Sprintf(" | <strong>Introduced on</strong>: <code>%s</code>", html.EscapeString(commit.IntroducedOn)))
+		}
+		sb.WriteString("</p>\n")
+		if strings.Contains(full, "\n") && full != short {
+			sb.WriteString(fmt.Sprintf("<pre>%s</pre>\n", html.EscapeString(full)))
+		}
+		sb.WriteString("</details>\n")
+	}
+
+	sb.WriteString("</details>\n")
+
+	return sb.String()
+}
+
+// shortSHA returns the abbreviated form of a commit SHA
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// generateHTMLPRsSection generates a section with a list of pull requests
+func generateHTMLPRsSection(id, title, emptyMessage string, prs []types.PullRequest) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>%s</h2>\n", id, title))
+
+	if len(prs) == 0 {
+		sb.WriteString(fmt.Sprintf("<p>%s</p>\n</section>\n", emptyMessage))
+		return sb.String()
+	}
+
+	for _, pr := range prs {
+		anchor := fmt.Sprintf("%s-%d", id, pr.Number)
+		sb.WriteString(fmt.Sprintf("<details id=\"%s\">\n", anchor))
+		sb.WriteString(fmt.Sprintf("<summary>PR #%d: %s</summary>\n", pr.Number, html.EscapeString(pr.Title)))
+		sb.WriteString("<ul>\n")
+		sb.WriteString(fmt.Sprintf("<li><strong>Link</strong>: %s</li>\n", htmlLink(pr.URL, pr.URL)))
+		sb.WriteString(fmt.Sprintf("<li><strong>Author</strong>: %s</li>\n", htmlLink(pr.Author.Login, pr.Author.ProfileURL)))
+		sb.WriteString(fmt.Sprintf("<li><strong>Created</strong>: %s</li>\n", pr.CreatedAt.Format("2006-01-02")))
+		sb.WriteString(fmt.Sprintf("<li><strong>Status</strong>: %s</li>\n", html.EscapeString(formatPRStatus(pr))))
+		if pr.HeadRef != "" && pr.BaseRef != "" {
+			sb.WriteString(fmt.Sprintf("<li><strong>Branch</strong>: <code>%s</code> → <code>%s</code></li>\n", html.EscapeString(pr.HeadRef), html.EscapeString(pr.BaseRef)))
+		}
+		if len(pr.Labels) > 0 {
+			sb.WriteString(fmt.Sprintf("<li><strong>Labels</strong>: %s</li>\n", html.EscapeString(strings.Join(pr.Labels, ", "))))
+		}
+		if pr.MergedAt != nil {
+			sb.WriteString(fmt.Sprintf("<li><strong>Merged</strong>: %s%s</li>\n", pr.MergedAt.Format("2006-01-02"), htmlMergedBy(pr.MergedBy)))
+		} else if pr.ClosedAt != nil {
+			sb.WriteString(fmt.Sprintf("<li><strong>Closed</strong>: %s</li>\n", pr.ClosedAt.Format("2006-01-02")))
+		}
+		sb.WriteString(fmt.Sprintf("<li><strong>Comments</strong>: %d</li>\n", pr.Comments))
+		if states := formatReviewStates(countReviewStates(pr.ReviewDetails)); states != "" {
+			sb.WriteString(fmt.Sprintf("<li><strong>Reviews</strong>: %d (%s)</li>\n", pr.Reviews, states))
+		} else {
+			sb.WriteString(fmt.Sprintf("<li><strong>Reviews</strong>: %d</li>\n", pr.Reviews))
+		}
+		sb.WriteString("</ul>\n")
+		if pr.AISummary != "" {
+			sb.WriteString(fmt.Sprintf("<div class=\"summary\">%s</div>\n", html.EscapeString(pr.AISummary)))
+		}
+		sb.WriteString("</details>\n")
+	}
+
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
+
+// htmlMergedBy formats who merged a pull request, e.g. " by <a ...>login</a>"
+func htmlMergedBy(mergedBy *types.Author) string {
+	if mergedBy == nil || mergedBy.Login == "" {
+		return ""
+	}
+	return " by " + htmlLink(mergedBy.Login, mergedBy.ProfileURL)
+}
+
+// generateHTMLMergedPRsSection generates a table with the pull requests merged during the period
+func generateHTMLMergedPRsSection(id string, data *types.ReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>🟣 Merged Pull Requests</h2>\n", id))
+
+	prs := mergedPRs(data)
+	if len(prs) == 0 {
+		sb.WriteString("<p>No pull requests were merged during this period</p>\n</section>\n")
+		return sb.String()
+	}
+
+	sb.WriteString("<table class=\"sortable\">\n<thead><tr>")
+	sb.WriteString("<th class=\"sortable\">#</th><th class=\"sortable\">Title</th><th class=\"sortable\">Author</th><th class=\"sortable\">Merged</th><th>Merged By</th><th>Branch</th>")
+	sb.WriteString("</tr></thead>\n<tbody>\n")
+	for _, pr := range prs {
+		sb.WriteString(fmt.Sprintf("<tr id=\"%s-%d\">", id, pr.Number))
+		sb.WriteString(fmt.Sprintf("<td data-value=\"%d\">%s</td>", pr.Number, htmlLink(fmt.Sprintf("#%d", pr.Number), pr.URL)))
+		sb.WriteString(fmt.Sprintf("<td>%s</td>", html.EscapeString(pr.Title)))
+		sb.WriteString(fmt.Sprintf("<td>%s</td>", htmlLink(pr.Author.Login, pr.Author.ProfileURL)))
+		sb.WriteString(fmt.Sprintf("<td>%s</td>", pr.MergedAt.Format("2006-01-02")))
+		if pr.MergedBy != nil && pr.MergedBy.Login != "" {
+			sb.WriteString(fmt.Sprintf("<td>%s</td>", htmlLink(pr.MergedBy.Login, pr.MergedBy.ProfileURL)))
+		} else {
+			sb.WriteString("<td></td>")
+		}
+		if pr.HeadRef != "" && pr.BaseRef != "" {
+			sb.WriteString(fmt.Sprintf("<td><code>%s</code> → <code>%s</code></td>", html.EscapeString(pr.HeadRef), html.EscapeString(pr.BaseRef)))
+		} else {
+			sb.WriteString("<td></td>")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLIssuesSection generates a table with a list of issues
+func generateHTMLIssuesSection(id, title, emptyMessage string, issues []types.Issue) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>%s</h2>\n", id, title))
+
+	if len(issues) == 0 {
+		sb.WriteString(fmt.Sprintf("<p>%s</p>\n</section>\n", emptyMessage))
+		return sb.String()
+	}
+
+	sb.WriteString("<table class=\"sortable\">\n<thead><tr>")
+	sb.WriteString("<th class=\"sortable\">#</th><th class=\"sortable\">Title</th><th class=\"sortable\">Author</th><th class=\"sortable\">Created</th><th>Labels</th><th>Assignees</th>")
+	sb.WriteString("</tr></thead>\n<tbody>\n")
+	for _, issue := range issues {
+		assignees := make([]string, len(issue.Assignees))
+		for i, assignee := range issue.Assignees {
+			assignees[i] = assignee.Login
+		}
+
+		sb.WriteString(fmt.Sprintf("<tr id=\"%s-%d\">", id, issue.Number))
+		sb.WriteString(fmt.Sprintf("<td data-value=\"%d\">%s</td>", issue.Number, htmlLink(fmt.Sprintf("#%d", issue.Number), issue.URL)))
+		sb.WriteString(fmt.Sprintf("<td>%s</td>", html.EscapeString(issue.Title)))
+		sb.WriteString(fmt.Sprintf("<td>%s</td>", htmlLink(issue.Author.Login, issue.Author.ProfileURL)))
+		sb.WriteString(fmt.Sprintf("<td>%s</td>", issue.CreatedAt.Format("2006-01-02")))
+		sb.WriteString(fmt.Sprintf("<td>%s</td>", html.EscapeString(strings.Join(issue.Labels, ", "))))
+		if len(assignees) > 0 {
+			sb.WriteString(fmt.Sprintf("<td>%s</td>", htmlAuthorLinks(assignees)))
+		} else {
+			sb.WriteString("<td></td>")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLAuthorStatsSection generates a sortable table with author statistics
+func generateHTMLAuthorStatsSection(anchors *htmlAnchors, authorStats []types.AuthorStats) string {
+	var sb strings.Builder
+
+	sb.WriteString("<section id=\"author-activity\">\n<h2>👥 Author Activity</h2>\n")
+
+	if len(authorStats) == 0 {
+		sb.WriteString("<p>No author activity found during this period</p>\n</section>\n")
+		return sb.String()
+	}
+
+	sb.WriteString("<table class=\"sortable\">\n<thead><tr>")
+	for _, column := range []string{"Author", "Commits", "Lines Added", "Lines Deleted", "PRs Created", "Issues Created", "Code Reviews", "Reviews Received"} {
+		sb.WriteString(fmt.Sprintf("<th class=\"sortable\">%s</th>", column))
+	}
+	sb.WriteString("</tr></thead>\n<tbody>\n")
+	for _, stats := range authorStats {
+		sb.WriteString(fmt.Sprintf("<tr id=\"%s\">", anchors.anchor("author", stats.Author.Login)))
+		sb.WriteString(fmt.Sprintf("<td data-value=\"%s\">%s</td>", html.EscapeString(stats.Author.Login), htmlLink(stats.Author.Login, stats.Author.ProfileURL)))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", stats.TotalCommits))
+		sb.WriteString(fmt.Sprintf("<td class=\"added\">%d</td>", stats.TotalAdded))
+		sb.WriteString(fmt.Sprintf("<td class=\"deleted\">%d</td>", stats.TotalDeleted))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", stats.PRsCreated))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", stats.IssuesCreated))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", stats.ReviewsCount))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", stats.ReviewsReceived))
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLMultiHeader generates the header of a multi-repository report
+func generateHTMLMultiHeader(data *types.MultiReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString("<header>\n")
+	sb.WriteString(fmt.Sprintf("<h1>Activity Report: %d Repositories</h1>\n", len(data.Repositories)))
+	sb.WriteString("<p class=\"meta\">")
+	if data.User != "" {
+		sb.WriteString(fmt.Sprintf("<strong>User</strong>: %s<br>\n", htmlAuthorLinks([]string{data.User})))
+	}
+	sb.WriteString(fmt.Sprintf("<strong>Period</strong>: %s to %s<br>\n",
+		data.Period.From.Format("2006-01-02"),
+		data.Period.To.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("<strong>Report Generated</strong>: %s UTC</p>\n",
+		data.GeneratedAt.Format("2006-01-02 15:04:05")))
+	sb.WriteString("</header>\n")
+
+	return sb.String()
+}
+
+// generateHTMLRepositoriesSection generates a sortable cross-repository overview table
+func generateHTMLRepositoriesSection(anchors *htmlAnchors, reports []types.ReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString("<section id=\"repositories\">\n<h2>📦 Repositories</h2>\n")
+	sb.WriteString("<table class=\"sortable\">\n<thead><tr>")
+	for _, column := range []string{"Repository", "Commits", "Authors", "Open PRs", "Updated PRs", "Open Issues", "Closed Issues", "Reviews"} {
+		sb.WriteString(fmt.Sprintf("<th class=\"sortable\">%s</th>", column))
+	}
+	sb.WriteString("</tr></thead>\n<tbody>\n")
+	for _, report := range reports {
+		sb.WriteString("<tr>")
+		sb.WriteString(fmt.Sprintf("<td data-value=\"%s\"><a href=\"#%s\">%s</a></td>",
+			html.EscapeString(report.Repository), anchors.anchor("repo", report.Repository), html.EscapeString(report.Repository)))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", report.OverallStats.TotalCommits))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", report.OverallStats.TotalAuthors))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", report.OverallStats.OpenPRCount))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", len(report.UpdatedPRs)))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", report.OverallStats.OpenIssuesCount))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", report.OverallStats.ClosedIssuesCount))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", report.OverallStats.ReviewsCount))
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLRepositorySection generates the section of a single repository in a
+// multi-repository report. All ids are prefixed with the repository anchor.
+func generateHTMLRepositorySection(anchors *htmlAnchors, data types.ReportData) string {
+	var sb strings.Builder
+
+	prefix := anchors.anchor("repo", data.Repository)
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>📦 %s</h2>\n", prefix, html.EscapeString(data.Repository)))
+	sb.WriteString(fmt.Sprintf("<p class=\"meta\"><strong>Repository</strong>: %s</p>\n", htmlLink(data.Repository, data.RepositoryURL)))
+	sb.WriteString(generateHTMLUserSection(anchors, prefix+"-user-activity", prefix+"-branch", &data))
+	sb.WriteString(fmt.Sprintf("<div class=\"summary\">%s</div>\n", html.EscapeString(data.AISummary)))
+
+	if len(data.Branches) > 0 {
+		sb.WriteString("<h3>🌿 Branches</h3>\n")
+		for _, branch := range data.Branches {
+			sb.WriteString(generateHTMLBranchSection(anchors, prefix+"-branch", branch))
+		}
+	}
+	sb.WriteString(generateHTMLPRsSection(prefix+"-open-prs", "🔀 Open Pull Requests", "No open pull requests", data.OpenPRs))
+	sb.WriteString(generateHTMLMergedPRsSection(prefix+"-merged-prs", &data))
+	sb.WriteString(generateHTMLReleasesSection(anchors, prefix+"-releases", data.Releases))
+	sb.WriteString(generateHTMLDeploymentsSection(anchors, prefix+"-deployments", &data))
+	sb.WriteString(generateHTMLPRsSection(prefix+"-updated-prs", "🔄 Updated Pull Requests", "No pull requests were updated during this period", data.UpdatedPRs))
+	sb.WriteString(generateHTMLIssuesSection(prefix+"-open-issues", "📋 Open Issues", "No open issues", data.OpenIssues))
+	sb.WriteString(generateHTMLIssuesSection(prefix+"-closed-issues", "✅ Closed Issues", "No issues were closed during this period", data.ClosedIssues))
+	sb.WriteString(generateHTMLCycleTimeSection(prefix+"-cycle-time", data.CycleTime))
+	sb.WriteString(generateHTMLDeliverySection(prefix+"-delivery", data.Delivery))
+	sb.WriteString(generateHTMLCISection(prefix+"-ci", data.CI))
+	sb.WriteString(generateHTMLHygieneSection(prefix+"-hygiene", &data))
+	sb.WriteString(generateHTMLHotspotsSection(prefix+"-hotspots", data.Hotspots))
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLFooter generates the report footer with generation statistics
+func generateHTMLFooter(stats *GenerationStats) string {
+	var sb strings.Builder
+
+	sb.WriteString("<footer>\n<hr>\n")
+	sb.WriteString("<p>Generated by <a href=\"https://github.com/hazadus/gh-repomon\">gh-repomon</a></p>\n")
+
+	if stats != nil && stats.TotalAISummaries > 0 {
+		sb.WriteString(fmt.Sprintf("<p>AI Summaries: %d successful, %d failed (total %d)</p>\n",
+			stats.SuccessfulSummaries,
+			stats.FailedSummaries,
+			stats.TotalAISummaries))
+	}
+
+	if stats != nil {
+		for _, warning := range stats.Warnings {
+			sb.WriteString(fmt.Sprintf("<p class=\"warning\">⚠️ %s</p>\n", html.EscapeString(warning)))
+		}
+	}
+
+	sb.WriteString("</footer>\n")
+
+	return sb.String()
+}
+
+// generateHTMLComparisonSection generates the period-over-period comparison table
+func generateHTMLComparisonSection(comparison *types.PeriodComparison) string {
+	if comparison == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("<section id=\"comparison\">\n<h2>📈 Compared to Previous Period</h2>\n")
+	sb.WriteString(fmt.Sprintf("<p class=\"meta\">Previous period: %s to %s</p>\n",
+		comparison.PreviousPeriod.From.Format("2006-01-02"),
+		comparison.PreviousPeriod.To.Format("2006-01-02")))
+	sb.WriteString("<table>\n<thead><tr><th>Metric</th><th>Current</th><th>Previous</th><th>Change</th></tr></thead>\n<tbody>\n")
+	for _, metric := range comparison.Metrics() {
+		sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%d</td><td>%s</td></tr>\n",
+			html.EscapeString(metric.Name), metric.Current, metric.Previous, html.EscapeString(formatChange(metric))))
+	}
+	sb.WriteString("</tbody>\n</table>\n</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLCycleTimeSection generates the pull request cycle-time and review-latency section
+func generateHTMLCycleTimeSection(id string, analytics *types.CycleTimeAnalytics) string {
+	if analytics == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>⏱️ Pull Request Cycle Time</h2>\n", id))
+
+	if len(analytics.PullRequests) == 0 {
+		sb.WriteString("<p>No pull requests were reviewed or merged during this period</p>\n</section>\n")
+		return sb.String()
+	}
+
+	overall := analytics.Overall
+	sb.WriteString("<table>\n<thead><tr><th>Metric</th><th>PRs</th><th>Median</th><th>p90</th></tr></thead>\n<tbody>\n")
+	for _, metric := range []struct {
+		name  string
+		stats types.DurationStats
+	}{
+		{"Time to First Review", overall.TimeToFirstReview},
+		{"Time to Approval", overall.TimeToApproval},
+		{"Time to Merge", overall.TimeToMerge},
+	} {
+		median, p90 := formatDurationStats(metric.stats)
+		sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%s</td><td>%s</td></tr>\n", metric.name, metric.stats.Count, median, p90))
+	}
+	if overall.ReviewedPRs > 0 {
+		sb.WriteString(fmt.Sprintf("<tr><td>Review Rounds</td><td>%d</td><td>%.1f</td><td>%.1f</td></tr>\n", overall.ReviewedPRs, overall.MedianReviewRounds, overall.P90ReviewRounds))
+	} else {
+		sb.WriteString("<tr><td>Review Rounds</td><td>0</td><td>-</td><td>-</td></tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+
+	sb.WriteString("<h3>By Author</h3>\n<table class=\"sortable\">\n<thead><tr>")
+	for _, column := range []string{"Author", "PRs", "First Review (median)", "Approval (median)", "Merge (median)", "Review Rounds (median)"} {
+		sb.WriteString(fmt.Sprintf("<th class=\"sortable\">%s</th>", column))
+	}
+	sb.WriteString("</tr></thead>\n<tbody>\n")
+	for _, author := range analytics.Authors {
+		sb.WriteString("<tr>")
+		sb.WriteString(fmt.Sprintf("<td data-value=\"%s\">%s</td>", html.EscapeString(author.Author), htmlLink(author.Author, "https://github.com/"+author.Author)))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", author.PRs))
+		for _, stats := range []types.DurationStats{author.TimeToFirstReview, author.TimeToApproval, author.TimeToMerge} {
+			median, _ := formatDurationStats(stats)
+			sb.WriteString(fmt.Sprintf("<td data-value=\"%.2f\">%s</td>", stats.Median, median))
+		}
+		sb.WriteString(fmt.Sprintf("<td>%.1f</td>", author.MedianReviewRounds))
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n</section>\n")
+
+	return sb.String()
+}
+
+// htmlChangeFailure formats a failed change as a list item
+func htmlChangeFailure(failure types.ChangeFailure) string {
+	switch {
+	case failure.Kind == types.FailureKindIncident:
+		item := fmt.Sprintf("<li><strong>Incident</strong>: %s, opened %s", htmlLink(fmt.Sprintf("Issue #%d: %s", failure.Number, failure.Title), failure.URL), failure.At.Format("2006-01-02"))
+		if failure.RestoredAt != nil {
+			item += ", restored in " + formatHours(failure.RestoredAt.Sub(failure.At).Hours())
+		}
+		return item + "</li>\n"
+	case failure.Number > 0:
+		return fmt.Sprintf("<li><strong>Revert</strong>: %s, merged %s</li>\n", htmlLink(fmt.Sprintf("PR #%d: %s", failure.Number, failure.Title), failure.URL), failure.At.Format("2006-01-02"))
+	default:
+		return fmt.Sprintf("<li><strong>Revert</strong>: %s %s, committed %s</li>\n", htmlLink(shortSHA(failure.SHA), failure.URL), html.EscapeString(failure.Title), failure.At.Format("2006-01-02"))
+	}
+}
+
+// generateHTMLDeliverySection generates the DORA-style delivery metrics section
+func generateHTMLDeliverySection(id string, metrics *types.DeliveryMetrics) string {
+	if metrics == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>🚀 Delivery Metrics</h2>\n", id))
+
+	labels := make([]string, len(metrics.IncidentLabels))
+	for i, label := range metrics.IncidentLabels {
+		labels[i] = "<code>" + html.EscapeString(label) + "</code>"
+	}
+	sb.WriteString(fmt.Sprintf("<p>%s; failed changes are reverts and issues labeled %s.</p>\n",
+		deploymentSourceDescription(metrics.DeploymentSource), strings.Join(labels, " or ")))
+
+	changeFailureRate := "-"
+	if metrics.Deployments > 0 {
+		changeFailureRate = fmt.Sprintf("%.1f%% (%d of %d)", metrics.ChangeFailureRate, metrics.FailedChanges, metrics.Deployments)
+	}
+
+	sb.WriteString("<table>\n<thead><tr><th>Metric</th><th>Value</th></tr></thead>\n<tbody>\n")
+	sb.WriteString(fmt.Sprintf("<tr><td>Deployment Frequency</td><td>%d (%.1f per week)</td></tr>\n", metrics.Deployments, metrics.DeploymentsPerWeek))
+	sb.WriteString(fmt.Sprintf("<tr><td>Lead Time for Changes</td><td>%s</td></tr>\n", formatDurationSummary(metrics.LeadTime, "changes")))
+	sb.WriteString(fmt.Sprintf("<tr><td>Change Failure Rate</td><td>%s</td></tr>\n", changeFailureRate))
+	sb.WriteString(fmt.Sprintf("<tr><td>Time to Restore</td><td>%s</td></tr>\n", formatDurationSummary(metrics.TimeToRestore, "incidents")))
+	sb.WriteString("</tbody>\n</table>\n")
+
+	if len(metrics.Failures) > 0 {
+		sb.WriteString("<h3>Failed Changes</h3>\n<ul>\n")
+		for _, failure := range metrics.Failures {
+			sb.WriteString(htmlChangeFailure(failure))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLCISection generates the GitHub Actions workflow run health section
+func generateHTMLCISection(id string, health *types.CIHealth) string {
+	if health == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>🧪 CI Health</h2>\n", id))
+
+	if health.TotalRuns == 0 {
+		sb.WriteString("<p>No workflow runs passed or failed during this period</p>\n</section>\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("<p><strong>Success Rate</strong>: %.1f%% (%d runs)</p>\n", health.SuccessRate, health.TotalRuns))
+
+	if len(health.Red) > 0 {
+		sb.WriteString(fmt.Sprintf("<h3>🔴 Failing on %s</h3>\n<ul>\n", html.EscapeString(health.DefaultBranch)))
+		for _, stats := range health.Red {
+			sb.WriteString(fmt.Sprintf("<li>%s: last run %s on %s (%d of %d runs failed)</li>\n",
+				htmlLink(stats.Workflow, stats.LastRunURL), html.EscapeString(stats.LastConclusion), stats.LastRunAt.Format("2006-01-02"), stats.Failed, stats.Runs))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("<h3>Workflows</h3>\n<table class=\"sortable\">\n<thead><tr>")
+	for _, column := range []string{"Workflow", "Branch", "Runs", "Passed", "Failed", "Success Rate"} {
+		sb.WriteString(fmt.Sprintf("<th class=\"sortable\">%s</th>", column))
+	}
+	sb.WriteString("<th>Last Run</th></tr></thead>\n<tbody>\n")
+	for _, stats := range health.Workflows {
+		sb.WriteString("<tr>")
+		sb.WriteString(fmt.Sprintf("<td>%s</td><td><code>%s</code></td>", html.EscapeString(stats.Workflow), html.EscapeString(stats.Branch)))
+		sb.WriteString(fmt.Sprintf("<td>%d</td><td>%d</td><td>%d</td>", stats.Runs, stats.Passed, stats.Failed))
+		sb.WriteString(fmt.Sprintf("<td data-value=\"%.2f\">%.1f%%</td>", stats.SuccessRate, stats.SuccessRate))
+		sb.WriteString(fmt.Sprintf("<td>%s</td>", htmlLink(stats.LastConclusion, stats.LastRunURL)))
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+
+	if len(health.Flaky) > 0 {
+		sb.WriteString("<h3>Flaky Workflows</h3>\n<ul>\n")
+		for _, flaky := range health.Flaky {
+			sb.WriteString(fmt.Sprintf("<li><strong>%s</strong> on <code>%s</code> at %s: %d passed, %d failed</li>\n",
+				html.EscapeString(flaky.Workflow), html.EscapeString(flaky.Branch), htmlLink(shortSHA(flaky.SHA), flaky.URL), flaky.Passed, flaky.Failed))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	if len(health.LongestJobs) > 0 {
+		sb.WriteString("<h3>Longest Jobs</h3>\n<table>\n<thead><tr><th>Job</th><th>Workflow</th><th>Branch</th><th>Duration</th></tr></thead>\n<tbody>\n")
+		for _, job := range health.LongestJobs {
+			sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td><td><code>%s</code></td><td>%s</td></tr>\n",
+				htmlLink(job.Job, job.URL), html.EscapeString(job.Workflow), html.EscapeString(job.Branch), formatHours(job.Minutes/60)))
+		}
+		sb.WriteString("</tbody>\n</table>\n")
+	}
+
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLHygieneSection generates the section listing stale and merged
+// branches and abandoned or conflicting pull requests
+func generateHTMLHygieneSection(id string, data *types.ReportData) string {
+	hygiene := data.Hygiene
+	if hygiene == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>🧹 Repository Hygiene</h2>\n", id))
+
+	if len(hygiene.StaleBranches)+len(hygiene.MergedBranches)+len(hygiene.StalePRs)+len(hygiene.ConflictingPRs) == 0 {
+		sb.WriteString("<p>No stale branches or abandoned pull requests found</p>\n</section>\n")
+		return sb.String()
+	}
+
+	sb.WriteString("<ul>\n")
+	sb.WriteString(fmt.Sprintf("<li><strong>Stale Branches</strong> (no commits for %d days): %d</li>\n", hygiene.StaleBranchDays, len(hygiene.StaleBranches)))
+	sb.WriteString(fmt.Sprintf("<li><strong>Merged Branches Not Deleted</strong>: %d</li>\n", len(hygiene.MergedBranches)))
+	sb.WriteString(fmt.Sprintf("<li><strong>Stale Pull Requests</strong> (no activity for %d days): %d</li>\n", hygiene.StalePRDays, len(hygiene.StalePRs)))
+	sb.WriteString(fmt.Sprintf("<li><strong>Pull Requests with Merge Conflicts</strong>: %d</li>\n", len(hygiene.ConflictingPRs)))
+	sb.WriteString("</ul>\n")
+
+	now := data.Period.To
+
+	if len(hygiene.StaleBranches) > 0 {
+		sb.WriteString("<h3>Stale Branches</h3>\n<table class=\"sortable\">\n<thead><tr>")
+		for _, column := range []string{"Branch", "Last Commit", "Author", "Days Inactive"} {
+			sb.WriteString(fmt.Sprintf("<th class=\"sortable\">%s</th>", column))
+		}
+		sb.WriteString("</tr></thead>\n<tbody>\n")
+		for _, head := range hygiene.StaleBranches {
+			sb.WriteString("<tr>")
+			sb.WriteString(fmt.Sprintf("<td><code>%s</code></td>", html.EscapeString(head.Name)))
+			sb.WriteString(fmt.Sprintf("<td data-value=\"%s\">%s %s</td>", head.CommittedAt.Format("2006-01-02"),
+				htmlLink(shortSHA(head.SHA), data.RepositoryURL+"/commit/"+head.SHA), head.CommittedAt.Format("2006-01-02")))
+			sb.WriteString(fmt.Sprintf("<td>%s</td>", htmlAuthorLinks([]string{head.Author.Login})))
+			sb.WriteString(fmt.Sprintf("<td>%d</td>", daysSince(head.CommittedAt, now)))
+			sb.WriteString("</tr>\n")
+		}
+		sb.WriteString("</tbody>\n</table>\n")
+	}
+
+	if len(hygiene.MergedBranches) > 0 {
+		sb.WriteString("<h3>Merged Branches Not Deleted</h3>\n<ul>\n")
+		for _, head := range hygiene.MergedBranches {
+			sb.WriteString(fmt.Sprintf("<li><code>%s</code>: last commit %s by %s</li>\n",
+				html.EscapeString(head.Name), head.CommittedAt.Format("2006-01-02"), htmlAuthorLinks([]string{head.Author.Login})))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	if len(hygiene.StalePRs) > 0 {
+		sb.WriteString("<h3>Stale Pull Requests</h3>\n<table class=\"sortable\">\n<thead><tr>")
+		for _, column := range []string{"Pull Request", "Author", "Last Activity", "Days Inactive"} {
+			sb.WriteString(fmt.Sprintf("<th class=\"sortable\">%s</th>", column))
+		}
+		sb.WriteString("</tr></thead>\n<tbody>\n")
+		for _, pr := range hygiene.StalePRs {
+			title := fmt.Sprintf("#%d: %s", pr.Number, pr.Title)
+			if pr.Draft {
+				title += " (draft)"
+			}
+			sb.WriteString("<tr>")
+			sb.WriteString(fmt.Sprintf("<td>%s</td>", htmlLink(title, pr.URL)))
+			sb.WriteString(fmt.Sprintf("<td>%s</td>", htmlAuthorLinks([]string{pr.Author.Login})))
+			sb.WriteString(fmt.Sprintf("<td>%s</td>", pr.UpdatedAt.Format("2006-01-02")))
+			sb.WriteString(fmt.Sprintf("<td>%d</td>", daysSince(pr.UpdatedAt, now)))
+			sb.WriteString("</tr>\n")
+		}
+		sb.WriteString("</tbody>\n</table>\n")
+	}
+
+	if len(hygiene.ConflictingPRs) > 0 {
+		sb.WriteString("<h3>Pull Requests with Merge Conflicts</h3>\n<ul>\n")
+		for _, pr := range hygiene.ConflictingPRs {
+			sb.WriteString(fmt.Sprintf("<li>%s by %s (<code>%s</code> → <code>%s</code>)</li>\n",
+				htmlLink(fmt.Sprintf("#%d: %s", pr.Number, pr.Title), pr.URL), htmlAuthorLinks([]string{pr.Author.Login}),
+				html.EscapeString(pr.HeadRef), html.EscapeString(pr.BaseRef)))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLHotspotsSection generates the section listing the most changed
+// files, directories and modules
+func generateHTMLHotspotsSection(id string, hotspots *types.Hotspots) string {
+	if hotspots == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>🔥 Hotspots</h2>\n", id))
+
+	sb.WriteString("<h3>Most Changed Files</h3>\n")
+	sb.WriteString(generateHTMLPathStatsTable("File", hotspots.Files))
+
+	if len(hotspots.Directories) > 0 {
+		sb.WriteString("<h3>Most Changed Directories</h3>\n")
+		sb.WriteString(generateHTMLPathStatsTable("Directory", hotspots.Directories))
+	}
+
+	sb.WriteString("<h3>Churn by Module</h3>\n")
+	sb.WriteString(generateHTMLPathStatsTable("Module", hotspots.Modules))
+
+	if len(hotspots.SharedFiles) > 0 {
+		sb.WriteString("<h3>Files with Most Authors</h3>\n<ul>\n")
+		for _, file := range hotspots.SharedFiles {
+			sb.WriteString(fmt.Sprintf("<li><code>%s</code>: %d authors (%s) in %d commits</li>\n",
+				html.EscapeString(file.Path), len(file.Authors), htmlAuthorLinks(file.Authors), file.Commits))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
+
+// generateHTMLPathStatsTable generates a sortable table of files, directories
+// or modules with their changes
+func generateHTMLPathStatsTable(column string, stats []types.PathStats) string {
+	var sb strings.Builder
+
+	sb.WriteString("<table class=\"sortable\">\n<thead><tr>")
+	for _, name := range []string{column, "Commits", "Lines Changed", "Authors"} {
+		sb.WriteString(fmt.Sprintf("<th class=\"sortable\">%s</th>", name))
+	}
+	sb.WriteString("</tr></thead>\n<tbody>\n")
+	for _, s := range stats {
+		name := html.EscapeString(s.Path)
+		if s.Path != rootModule {
+			name = "<code>" + name + "</code>"
+		}
+		sb.WriteString("<tr>")
+		sb.WriteString(fmt.Sprintf("<td>%s</td>", name))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", s.Commits))
+		sb.WriteString(fmt.Sprintf("<td data-value=\"%d\">%d (+%d/-%d)</td>", s.Churn(), s.Churn(), s.Additions, s.Deletions))
+		sb.WriteString(fmt.Sprintf("<td>%d</td>", len(s.Authors)))
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+
+	return sb.String()
+}
+
+// generateHTMLDeploymentsSection generates the section listing deployments per environment
+func generateHTMLDeploymentsSection(anchors *htmlAnchors, id string, data *types.ReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>🚢 Deployments</h2>\n", id))
+
+	if len(data.Deployments) == 0 {
+		sb.WriteString("<p>No deployments were made during this period</p>\n</section>\n")
+		return sb.String()
+	}
+
+	environments := groupDeploymentsByEnvironment(data.Deployments)
+
+	sb.WriteString("<table>\n<thead><tr><th>Environment</th><th>Deployments</th><th>Succeeded</th><th>Failed</th><th>Rolled Back</th><th>Last Deployed</th></tr></thead>\n<tbody>\n")
+	for _, env := range environments {
+		sb.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%s (%s)</td></tr>\n",
+			html.EscapeString(env.Environment), len(env.Deployments), env.Succeeded, env.Failed, env.RolledBack,
+			env.LastDeployed.Format("2006-01-02"), htmlLink(shortSHA(env.LastSHA), data.RepositoryURL+"/commit/"+env.LastSHA)))
+	}
+	sb.WriteString("</tbody>\n</table>\n")
+
+	if problems := problemDeployments(data.Deployments); len(problems) > 0 {
+		sb.WriteString("<h3>⚠️ Failed and Rolled-Back Deployments</h3>\n<ul>\n")
+		for _, deployment := range problems {
+			sb.WriteString(fmt.Sprintf("<li><strong>%s</strong>: %s</li>\n",
+				html.EscapeString(deployment.Environment), formatHTMLDeployment(deployment, data.RepositoryURL)))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	for _, env := range environments {
+		sb.WriteString(fmt.Sprintf("<details id=\"%s\">\n", anchors.anchor(id, env.Environment)))
+		sb.WriteString(fmt.Sprintf("<summary>%s (%d deployments)</summary>\n<ul>\n", html.EscapeString(env.Environment), len(env.Deployments)))
+		for _, deployment := range env.Deployments {
+			sb.WriteString(fmt.Sprintf("<li>%s", formatHTMLDeployment(deployment, data.RepositoryURL)))
+
+			var changes []string
+			if deployment.PreviousSHA != "" && !deployment.RolledBack {
+				changes = append(changes, fmt.Sprintf("Changes since <code>%s</code>: %d commits, %d pull requests",
+					html.EscapeString(shortSHA(deployment.PreviousSHA)), len(deployment.Commits), len(deployment.PullRequests)))
+			}
+			for _, pr := range deployment.PullRequests {
+				changes = append(changes, fmt.Sprintf("%s by %s",
+					htmlLink(fmt.Sprintf("#%d: %s", pr.Number, pr.Title), pr.URL), htmlLink(pr.Author.Login, pr.Author.ProfileURL)))
+			}
+			direct := commitsWithoutPullRequest(deployment.Commits)
+			for i, commit := range direct {
+				if i == maxDeploymentCommits {
+					changes = append(changes, fmt.Sprintf("... and %d more commits", len(direct)-maxDeploymentCommits))
+					break
+				}
+				changes = append(changes, fmt.Sprintf("%s %s by %s",
+					htmlLink(shortSHA(commit.SHA), commit.URL), html.EscapeString(firstLine(commit.Message)), htmlLink(commit.Author.Login, commit.Author.ProfileURL)))
+			}
+			if len(changes) > 0 {
+				sb.WriteString("\n<ul>\n<li>" + strings.Join(changes, "</li>\n<li>") + "</li>\n</ul>\n")
+			}
+
+			sb.WriteString("</li>\n")
+		}
+		sb.WriteString("</ul>\n</details>\n")
+	}
+
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
+
+// formatHTMLDeployment formats a deployment as the HTML content of a list entry
+func formatHTMLDeployment(deployment types.Deployment, repositoryURL string) string {
+	state := html.EscapeString(formatDeploymentState(deployment))
+	if deployment.URL != "" {
+		state = htmlLink(formatDeploymentState(deployment), deployment.URL)
+	}
+
+	return fmt.Sprintf("%s %s <code>%s</code> by %s: %s",
+		formatDate(deployment.CreatedAt), htmlLink(shortSHA(deployment.SHA), repositoryURL+"/commit/"+deployment.SHA),
+		html.EscapeString(deployment.Ref), htmlLink(deployment.Creator.Login, deployment.Creator.ProfileURL), state)
+}
+
+// generateHTMLReleasesSection generates the section listing releases and tags
+func generateHTMLReleasesSection(anchors *htmlAnchors, id string, releases []types.Release) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("<section id=\"%s\">\n<h2>🏷️ Releases</h2>\n", id))
+
+	if len(releases) == 0 {
+		sb.WriteString("<p>No releases or tags were published during this period</p>\n</section>\n")
+		return sb.String()
+	}
+
+	for _, release := range releases {
+		sb.WriteString(fmt.Sprintf("<details id=\"%s\">\n", anchors.anchor(id, release.TagName)))
+		sb.WriteString(fmt.Sprintf("<summary>%s</summary>\n", html.EscapeString(formatReleaseTitle(release))))
+		sb.WriteString("<ul>\n")
+		sb.WriteString(fmt.Sprintf("<li><strong>Link</strong>: %s</li>\n", htmlLink(release.URL, release.URL)))
+		if release.TagOnly {
+			sb.WriteString(fmt.Sprintf("<li><strong>Tagged</strong>: %s (no release)</li>\n", release.PublishedAt.Format("2006-01-02")))
+		} else {
+			published := release.PublishedAt.Format("2006-01-02")
+			if release.Author.Login != "" {
+				published += " by " + htmlLink(release.Author.Login, release.Author.ProfileURL)
+			}
+			sb.WriteString(fmt.Sprintf("<li><strong>Published</strong>: %s</li>\n", published))
+		}
+		if release.Prerelease {
+			sb.WriteString("<li><strong>Pre-release</strong></li>\n")
+		}
+		if release.PreviousTag != "" {
+			sb.WriteString(fmt.Sprintf("<li><strong>Changes since %s</strong>: %s</li>\n",
+				html.EscapeString(release.PreviousTag), formatReleaseChanges(release)))
+		}
+		sb.WriteString("</ul>\n")
+
+		if release.AISummary != "" {
+			sb.WriteString(fmt.Sprintf("<div class=\"summary\">%s</div>\n", html.EscapeString(release.AISummary)))
+		}
+
+		if notes := strings.TrimSpace(release.Body); notes != "" {
+			sb.WriteString(fmt.Sprintf("<h3>Release Notes</h3>\n<pre>%s</pre>\n", html.EscapeString(notes)))
+		}
+
+		if len(release.PullRequests) > 0 {
+			sb.WriteString("<h3>Pull Requests</h3>\n<ul>\n")
+			for _, pr := range release.PullRequests {
+				sb.WriteString(fmt.Sprintf("<li>%s by %s</li>\n",
+					htmlLink(fmt.Sprintf("#%d: %s", pr.Number, pr.Title), pr.URL), htmlLink(pr.Author.Login, pr.Author.ProfileURL)))
+			}
+			sb.WriteString("</ul>\n")
+		}
+
+		if len(release.Commits) > 0 {
+			sb.WriteString("<h3>Commits</h3>\n<ul>\n")
+			for i, commit := range release.Commits {
+				if i == maxReleaseCommits {
+					sb.WriteString(fmt.Sprintf("<li>... and %d more commits</li>\n", len(release.Commits)-maxReleaseCommits))
+					break
+				}
+				sb.WriteString(fmt.Sprintf("<li>%s %s by %s</li>\n",
+					htmlLink(shortSHA(commit.SHA), commit.URL), html.EscapeString(firstLine(commit.Message)), htmlLink(commit.Author.Login, commit.Author.ProfileURL)))
+			}
+			sb.WriteString("</ul>\n")
+		}
+
+		sb.WriteString("</details>\n")
+	}
+
+	sb.WriteString("</section>\n")
+
+	return sb.String()
+}
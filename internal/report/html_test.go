@@ -0,0 +1,154 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestHTMLAnchor(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		input  string
+		want   string
+	}{
+		{
+			name:   "Simple name",
+			prefix: "branch",
+			input:  "main",
+			want:   "branch-main",
+		},
+		{
+			name:   "Name with slashes",
+			prefix: "branch",
+			input:  "feature/New-UI",
+			want:   "branch-feature-new-ui",
+		},
+		{
+			name:   "Name with special characters",
+			prefix: "author",
+			input:  "github-actions[bot]",
+			want:   "author-github-actions-bot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := htmlAnchor(tt.prefix, tt.input)
+			if got != tt.want {
+				t.Errorf("htmlAnchor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLAnchorsUnique(t *testing.T) {
+	anchors := newHTMLAnchors()
+
+	first := anchors.anchor("branch", "feature/x")
+	second := anchors.anchor("branch", "feature-x")
+	if first != "branch-feature-x" || second != "branch-feature-x-2" {
+		t.Errorf("anchor() = %q, %q, want branch-feature-x, branch-feature-x-2", first, second)
+	}
+	if got := anchors.anchor("branch", "feature/x"); got != first {
+		t.Errorf("anchor() for a repeated name = %q, want %q", got, first)
+	}
+	if got := anchors.anchor("branch", "feature-x-2"); got != "branch-feature-x-2-2" {
+		t.Errorf("anchor() for a name matching a suffixed id = %q, want branch-feature-x-2-2", got)
+	}
+}
+
+func TestGenerateHTMLCollidingBranchAnchors(t *testing.T) {
+	data := &types.ReportData{
+		Repository: "owner/repo",
+		User:       "alice",
+		Branches: []types.Branch{
+			{Name: "feature/x", Commits: []types.Commit{{SHA: "abc1234567"}}},
+			{Name: "feature-x", Commits: []types.Commit{{SHA: "def1234567"}}},
+		},
+	}
+
+	got := generateHTML(data, &GenerationStats{})
+
+	for _, want := range []string{`id="branch-feature-x"`, `id="branch-feature-x-2"`} {
+		if strings.Count(got, want) != 1 {
+			t.Errorf("generateHTML() contains %q %d times, want once", want, strings.Count(got, want))
+		}
+	}
+	// The user section links to both branches
+	for _, want := range []string{`href="#branch-feature-x"`, `href="#branch-feature-x-2"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generateHTML() missing link %q", want)
+		}
+	}
+}
+
+func TestGenerateHTML(t *testing.T) {
+	data := &types.ReportData{
+		Repository:    "owner/repo",
+		RepositoryURL: "https://github.com/owner/repo",
+		Period: types.Period{
+			From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		Branches: []types.Branch{
+			{
+				Name: "feature/login",
+				Commits: []types.Commit{
+					{SHA: "abc1234567", Message: "Add <login> form", Author: types.Author{Login: "alice"}},
+				},
+				Authors: []string{"alice"},
+			},
+		},
+		AuthorStats: []types.AuthorStats{
+			{Author: types.Author{Login: "alice"}, TotalCommits: 1},
+		},
+		AISummary: "Summary with <script>",
+	}
+
+	got := generateHTML(data, &GenerationStats{})
+
+	if !strings.HasPrefix(got, "<!DOCTYPE html>") {
+		t.Errorf("generateHTML() missing doctype")
+	}
+	if !strings.Contains(got, "<style>") {
+		t.Errorf("generateHTML() missing inline styles")
+	}
+	if !strings.Contains(got, "id=\"branch-feature-login\"") {
+		t.Errorf("generateHTML() missing branch anchor")
+	}
+	if !strings.Contains(got, "<table class=\"sortable\">") {
+		t.Errorf("generateHTML() missing sortable author table")
+	}
+	if !strings.Contains(got, "Add &lt;login&gt; form") {
+		t.Errorf("generateHTML() did not escape commit message")
+	}
+	if strings.Contains(got, "Summary with <script>") {
+		t.Errorf("generateHTML() did not escape AI summary")
+	}
+}
+
+func TestGenerateHTMLReleasesSection(t *testing.T) {
+	releases := []types.Release{{
+		TagName:     "v1.2.0",
+		Name:        "Spring <release>",
+		Body:        "Fixes <script> injection",
+		PublishedAt: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		URL:         "https://github.com/o/r/releases/tag/v1.2.0",
+	}}
+
+	got := generateHTMLReleasesSection(newHTMLAnchors(), "releases", releases)
+
+	if !strings.Contains(got, "id=\"releases-v1-2-0\"") {
+		t.Errorf("generateHTMLReleasesSection() missing release anchor, got:\n%s", got)
+	}
+	if !strings.Contains(got, "<summary>v1.2.0: Spring &lt;release&gt;</summary>") {
+		t.Errorf("generateHTMLReleasesSection() did not escape release name, got:\n%s", got)
+	}
+	if !strings.Contains(got, "<pre>Fixes &lt;script&gt; injection</pre>") {
+		t.Errorf("generateHTMLReleasesSection() did not escape release notes, got:\n%s", got)
+	}
+}
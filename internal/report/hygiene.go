@@ -0,0 +1,349 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
+)
+
+// Default hygiene thresholds
+const (
+	// DefaultStaleBranchDays is the number of days without commits after which a branch is stale
+	DefaultStaleBranchDays = 30
+	// DefaultStalePRDays is the number of days without updates after which an open PR is stale
+	DefaultStalePRDays = 14
+)
+
+// maxHygieneItems is the number of entries listed per hygiene category
+const maxHygieneItems = 20
+
+// branchHeadLister is implemented by GitHub clients that list the latest commit of every branch
+type branchHeadLister interface {
+	GetBranchHeads(ctx context.Context, repo string) ([]types.BranchHead, error)
+}
+
+// branchCounter is implemented by GitHub clients that count the commits a
+// branch is ahead and behind a base branch without listing them
+type branchCounter interface {
+	CountBranchCommits(ctx context.Context, repo, base, head string) (*types.BranchComparison, error)
+}
+
+// mergedPullRequestFinder is implemented by GitHub clients that look up the
+// merged pull requests of a branch regardless of when they were merged
+type mergedPullRequestFinder interface {
+	GetMergedPullRequests(ctx context.Context, repo, branch string) ([]types.PullRequest, error)
+}
+
+// conflictChecker is implemented by GitHub clients that look up which pull
+// requests have merge conflicts
+type conflictChecker interface {
+	GetConflictingPullRequests(ctx context.Context, repo string, numbers []int) ([]int, error)
+}
+
+// markMergedBranches sets when the latest pull request from each branch was
+// merged, looking it up whenever it was merged; clients that cannot look it up
+// fall back to the pull requests updated during the period. Only branches with
+// commits after that merge are compared with the default branch. Branches that
+// cannot be checked are left unmarked; a warning reports how many there were.
+func (g *Generator) markMergedBranches(ctx context.Context, repo, defaultBranch string, heads []types.BranchHead, updatedPRs []types.PullRequest) []string {
+	if len(heads) == 0 {
+		return nil
+	}
+	finder, canFind := g.githubClient.(mergedPullRequestFinder)
+	counter, canCount := g.githubClient.(branchCounter)
+
+	var indexes []int
+	for i, head := range heads {
+		if head.Name != defaultBranch {
+			indexes = append(indexes, i)
+		}
+	}
+
+	lookupsFailed, comparisonsFailed := 0, 0
+	var mu sync.Mutex
+
+	// Each worker writes only its own element of heads
+	maxWorkers := 5
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, maxWorkers, func(ctx context.Context, i int) error {
+		prs := updatedPRs
+		if canFind {
+			found, err := finder.GetMergedPullRequests(ctx, repo, heads[i].Name)
+			if err != nil {
+				if ctx.Err() == nil {
+					g.logger.Debug(fmt.Sprintf("Merged pull requests of branch %s could not be looked up: %v", heads[i].Name, err))
+					mu.Lock()
+					lookupsFailed++
+					mu.Unlock()
+				}
+				return nil
+			}
+			prs = found
+		}
+
+		heads[i].MergedAt = latestMerge(heads[i].Name, repo, prs)
+		if heads[i].MergedAt == nil || !heads[i].MergedAt.Before(heads[i].CommittedAt) || !canCount || defaultBranch == "" {
+			return nil
+		}
+
+		// Commits after the merge only count as merged when they are on the default branch
+		comparison, err := counter.CountBranchCommits(ctx, repo, defaultBranch, heads[i].Name)
+		if err != nil {
+			if ctx.Err() == nil {
+				g.logger.Debug(fmt.Sprintf("Branch %s could not be compared with %s: %v", heads[i].Name, defaultBranch, err))
+				mu.Lock()
+				comparisonsFailed++
+				mu.Unlock()
+			}
+			return nil
+		}
+		heads[i].Contained = comparison.Ahead == 0
+		return nil
+	})
+
+	var warnings []string
+	if lookupsFailed > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d branches could not be checked for merged pull requests", lookupsFailed))
+	}
+	if comparisonsFailed > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d branches could not be compared with %s to find merged branches", comparisonsFailed, defaultBranch))
+	}
+	for _, warning := range warnings {
+		g.logger.Warning(warning)
+	}
+	return warnings
+}
+
+// latestMerge returns when the latest pull request opened from the branch of
+// the repository was merged, nil if none was
+func latestMerge(branch, repo string, prs []types.PullRequest) *time.Time {
+	var latest *time.Time
+	for _, pr := range prs {
+		if !isOpenedFrom(pr, repo, branch) || pr.MergedAt == nil {
+			continue
+		}
+		if latest == nil || pr.MergedAt.After(*latest) {
+			latest = pr.MergedAt
+		}
+	}
+	return latest
+}
+
+// markConflicts marks the open pull requests that have merge conflicts. A
+// failure leaves all pull requests unmarked and returns a warning.
+func (g *Generator) markConflicts(ctx context.Context, repo string, prs []types.PullRequest) []string {
+	checker, ok := g.githubClient.(conflictChecker)
+	if !ok || len(prs) == 0 {
+		return nil
+	}
+
+	numbers := make([]int, len(prs))
+	for i, pr := range prs {
+		numbers[i] = pr.Number
+	}
+
+	conflicting, err := checker.GetConflictingPullRequests(ctx, repo, numbers)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		warning := fmt.Sprintf("Pull request merge conflicts could not be checked: %v", err)
+		g.logger.Warning(warning)
+		return []string{warning}
+	}
+
+	marked := make(map[int]bool, len(conflicting))
+	for _, number := range conflicting {
+		marked[number] = true
+	}
+	for i := range prs {
+		if marked[prs[i].Number] {
+			prs[i].HasConflicts = true
+		}
+	}
+	return nil
+}
+
+// calculateHygiene finds stale and merged branches and stale or conflicting
+// open pull requests as of the end of the report period. A branch counts as
+// merged only when a pull request from it was merged, whenever that was: after
+// its latest commit, which covers squash and rebase merges, or at any time if
+// all its commits are on the default branch. Being on the default branch alone
+// is no evidence, as a branch just created from it is too. Thresholds default to
+// DefaultStaleBranchDays and DefaultStalePRDays when not positive.
+func calculateHygiene(data *types.ReportData, staleBranchDays, stalePRDays int) *types.Hygiene {
+	if staleBranchDays <= 0 {
+		staleBranchDays = DefaultStaleBranchDays
+	}
+	if stalePRDays <= 0 {
+		stalePRDays = DefaultStalePRDays
+	}
+
+	hygiene := &types.Hygiene{
+		StaleBranchDays: staleBranchDays,
+		StalePRDays:     stalePRDays,
+		StaleBranches:   make([]types.BranchHead, 0),
+		MergedBranches:  make([]types.BranchHead, 0),
+		StalePRs:        make([]types.PullRequest, 0),
+		ConflictingPRs:  make([]types.PullRequest, 0),
+	}
+
+	// Pull requests still open keep their branch alive
+	openBranches := make(map[string]bool)
+	for _, pr := range data.OpenPRs {
+		if strings.EqualFold(pr.HeadRepo, data.Repository) {
+			openBranches[pr.HeadRef] = true
+		}
+	}
+
+	branchCutoff := data.Period.To.AddDate(0, 0, -staleBranchDays)
+	for _, head := range data.BranchHeads {
+		switch {
+		case head.Name == data.DefaultBranch:
+		case isMerged(head):
+			if !openBranches[head.Name] {
+				hygiene.MergedBranches = append(hygiene.MergedBranches, head)
+			}
+		case head.CommittedAt.Before(branchCutoff):
+			hygiene.StaleBranches = append(hygiene.StaleBranches, head)
+		}
+	}
+
+	prCutoff := data.Period.To.AddDate(0, 0, -stalePRDays)
+	for _, pr := range data.OpenPRs {
+		if pr.UpdatedAt.Before(prCutoff) {
+			hygiene.StalePRs = append(hygiene.StalePRs, pr)
+		}
+		if pr.HasConflicts {
+			hygiene.ConflictingPRs = append(hygiene.ConflictingPRs, pr)
+		}
+	}
+
+	sortBranchHeads(hygiene.StaleBranches)
+	sortBranchHeads(hygiene.MergedBranches)
+	sort.SliceStable(hygiene.StalePRs, func(i, j int) bool {
+		return hygiene.StalePRs[i].UpdatedAt.Before(hygiene.StalePRs[j].UpdatedAt)
+	})
+
+	return hygiene
+}
+
+// isMerged reports whether a pull request from the branch was merged after
+// the latest commit of the branch, or at any time when all commits of the
+// branch are on the default branch
+func isMerged(head types.BranchHead) bool {
+	return head.MergedAt != nil && (head.Contained || !head.MergedAt.Before(head.CommittedAt))
+}
+
+// sortBranchHeads sorts branches by the date of their latest commit, oldest first
+func sortBranchHeads(heads []types.BranchHead) {
+	sort.SliceStable(heads, func(i, j int) bool {
+		return heads[i].CommittedAt.Before(heads[j].CommittedAt)
+	})
+}
+
+// daysSince returns the number of whole days from t to now
+func daysSince(t, now time.Time) int {
+	return int(now.Sub(t).Hours() / 24)
+}
+
+// generateHygieneSection generates the section listing stale and merged
+// branches and abandoned or conflicting pull requests
+func generateHygieneSection(data *types.ReportData) string {
+	hygiene := data.Hygiene
+	if hygiene == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("## 🧹 Repository Hygiene\n\n")
+
+	if len(hygiene.StaleBranches)+len(hygiene.MergedBranches)+len(hygiene.StalePRs)+len(hygiene.ConflictingPRs) == 0 {
+		sb.WriteString("No stale branches or abandoned pull requests found\n\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("- **Stale Branches** (no commits for %d days): %d\n", hygiene.StaleBranchDays, len(hygiene.StaleBranches)))
+	sb.WriteString(fmt.Sprintf("- **Merged Branches Not Deleted**: %d\n", len(hygiene.MergedBranches)))
+	sb.WriteString(fmt.Sprintf("- **Stale Pull Requests** (no activity for %d days): %d\n", hygiene.StalePRDays, len(hygiene.StalePRs)))
+	sb.WriteString(fmt.Sprintf("- **Pull Requests with Merge Conflicts**: %d\n\n", len(hygiene.ConflictingPRs)))
+
+	now := data.Period.To
+
+	if len(hygiene.StaleBranches) > 0 {
+		sb.WriteString("### Stale Branches\n\n")
+		sb.WriteString("| Branch | Last Commit | Author | Days Inactive |\n")
+		sb.WriteString("|--------|-------------|--------|---------------|\n")
+		for i, head := range hygiene.StaleBranches {
+			if i == maxHygieneItems {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | [`%s`](%s/commit/%s) %s | %s | %d |\n",
+				head.Name, shortSHA(head.SHA), data.RepositoryURL, head.SHA, head.CommittedAt.Format("2006-01-02"),
+				formatAuthorLink(head.Author.Login), daysSince(head.CommittedAt, now)))
+		}
+		sb.WriteString("\n")
+		writeMoreItems(&sb, len(hygiene.StaleBranches), "branches")
+	}
+
+	if len(hygiene.MergedBranches) > 0 {
+		sb.WriteString("### Merged Branches Not Deleted\n\n")
+		for i, head := range hygiene.MergedBranches {
+			if i == maxHygieneItems {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- `%s`: last commit %s by %s\n",
+				head.Name, head.CommittedAt.Format("2006-01-02"), formatAuthorLink(head.Author.Login)))
+		}
+		sb.WriteString("\n")
+		writeMoreItems(&sb, len(hygiene.MergedBranches), "branches")
+	}
+
+	if len(hygiene.StalePRs) > 0 {
+		sb.WriteString("### Stale Pull Requests\n\n")
+		sb.WriteString("| Pull Request | Author | Last Activity | Days Inactive |\n")
+		sb.WriteString("|--------------|--------|---------------|---------------|\n")
+		for i, pr := range hygiene.StalePRs {
+			if i == maxHygieneItems {
+				break
+			}
+			title := pr.Title
+			if pr.Draft {
+				title += " (draft)"
+			}
+			sb.WriteString(fmt.Sprintf("| [#%d: %s](%s) | %s | %s | %d |\n",
+				pr.Number, title, pr.URL, formatAuthorLink(pr.Author.Login),
+				pr.UpdatedAt.Format("2006-01-02"), daysSince(pr.UpdatedAt, now)))
+		}
+		sb.WriteString("\n")
+		writeMoreItems(&sb, len(hygiene.StalePRs), "pull requests")
+	}
+
+	if len(hygiene.ConflictingPRs) > 0 {
+		sb.WriteString("### Pull Requests with Merge Conflicts\n\n")
+		for i, pr := range hygiene.ConflictingPRs {
+			if i == maxHygieneItems {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- [#%d: %s](%s) by %s (`%s` → `%s`)\n",
+				pr.Number, pr.Title, pr.URL, formatAuthorLink(pr.Author.Login), pr.HeadRef, pr.BaseRef))
+		}
+		sb.WriteString("\n")
+		writeMoreItems(&sb, len(hygiene.ConflictingPRs), "pull requests")
+	}
+
+	return sb.String()
+}
+
+// writeMoreItems notes how many items of a hygiene list were not shown
+func writeMoreItems(sb *strings.Builder, total int, what string) {
+	if total > maxHygieneItems {
+		sb.WriteString(fmt.Sprintf("... and %d more %s\n\n", total-maxHygieneItems, what))
+	}
+}
@@ -0,0 +1,194 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// hygieneData returns a report ending on 2025-03-01 with a fresh, a stale, a
+// merged, a squash-merged and a long-merged branch, a branch just created from
+// the default branch, and three open pull requests
+func hygieneData() *types.ReportData {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 12, 0, 0, 0, time.UTC)
+	}
+	mergedAt := day(2, 20)
+	earlyMergedAt := day(1, 4)
+	longAgoMergedAt := time.Date(2024, 10, 2, 12, 0, 0, 0, time.UTC)
+	alice := types.Author{Login: "alice"}
+	bob := types.Author{Login: "bob"}
+
+	return &types.ReportData{
+		Repository:    "o/r",
+		RepositoryURL: "https://github.com/o/r",
+		DefaultBranch: "main",
+		Period:        types.Period{From: day(2, 22), To: day(3, 1)},
+		BranchHeads: []types.BranchHead{
+			{Name: "main", SHA: "m1", Author: alice, CommittedAt: day(1, 1)},
+			{Name: "fresh", SHA: "f1", Author: alice, CommittedAt: day(2, 25)},
+			{Name: "old-feature", SHA: "o1o1o1o1o1", Author: bob, CommittedAt: day(1, 10)},
+			{Name: "older-feature", SHA: "o2", Author: alice, CommittedAt: day(1, 5)},
+			{Name: "merged", SHA: "g1", Author: alice, CommittedAt: day(1, 3), MergedAt: &earlyMergedAt},
+			{Name: "just-created", SHA: "m2", Author: alice, CommittedAt: day(2, 27), Contained: true},
+			{Name: "squashed", SHA: "s1", Author: bob, CommittedAt: day(2, 18), MergedAt: &mergedAt},
+			{Name: "in-review", SHA: "r1", Author: bob, CommittedAt: day(1, 2), MergedAt: &earlyMergedAt, Contained: true},
+			// Merged long before the period and never deleted
+			{Name: "forgotten", SHA: "z1", Author: alice, CommittedAt: time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC), MergedAt: &longAgoMergedAt},
+		},
+		OpenPRs: []types.PullRequest{
+			{Number: 1, Title: "Active", Author: alice, UpdatedAt: day(2, 28), HeadRef: "fresh", HeadRepo: "o/r", BaseRef: "main", HasConflicts: true, URL: "https://github.com/o/r/pull/1"},
+			{Number: 2, Title: "Forgotten", Author: bob, UpdatedAt: day(1, 20), HeadRef: "elsewhere", HeadRepo: "o/r", Draft: true, URL: "https://github.com/o/r/pull/2"},
+			{Number: 3, Title: "Waiting", Author: bob, UpdatedAt: day(2, 1), HeadRef: "in-review", HeadRepo: "o/r", URL: "https://github.com/o/r/pull/3"},
+		},
+		UpdatedPRs: []types.PullRequest{
+			{Number: 4, Title: "Squashed", Author: bob, HeadRef: "squashed", HeadRepo: "o/r", State: types.PRStateMerged, MergedAt: &mergedAt},
+			{Number: 6, Title: "Merged", Author: alice, HeadRef: "merged", HeadRepo: "o/r", State: types.PRStateMerged, MergedAt: &earlyMergedAt},
+			{Number: 7, Title: "First round", Author: bob, HeadRef: "in-review", HeadRepo: "o/r", State: types.PRStateMerged, MergedAt: &earlyMergedAt},
+			// A pull request from a fork branch of the same name does not merge old-feature
+			{Number: 5, Title: "Fork", Author: bob, HeadRef: "old-feature", HeadRepo: "fork/r", State: types.PRStateMerged, MergedAt: &mergedAt},
+		},
+	}
+}
+
+func TestCalculateHygiene(t *testing.T) {
+	hygiene := calculateHygiene(hygieneData(), 30, 14)
+
+	names := func(heads []types.BranchHead) []string {
+		result := make([]string, len(heads))
+		for i, head := range heads {
+			result[i] = head.Name
+		}
+		return result
+	}
+	numbers := func(prs []types.PullRequest) []int {
+		result := make([]int, len(prs))
+		for i, pr := range prs {
+			result[i] = pr.Number
+		}
+		return result
+	}
+
+	// The default branch is never stale; branches with an open PR are not
+	// reported as merged, and neither is a branch just created from the default branch
+	if got := strings.Join(names(hygiene.StaleBranches), ","); got != "older-feature,old-feature" {
+		t.Errorf("got stale branches %s, want older-feature,old-feature", got)
+	}
+	if got := strings.Join(names(hygiene.MergedBranches), ","); got != "forgotten,merged,squashed" {
+		t.Errorf("got merged branches %s, want forgotten,merged,squashed", got)
+	}
+	if got := numbers(hygiene.StalePRs); len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("got stale pull requests %v, want [2 3]", got)
+	}
+	if got := numbers(hygiene.ConflictingPRs); len(got) != 1 || got[0] != 1 {
+		t.Errorf("got conflicting pull requests %v, want [1]", got)
+	}
+}
+
+func TestCalculateHygieneFreshBranch(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	data := &types.ReportData{
+		Repository:    "o/r",
+		DefaultBranch: "main",
+		Period:        types.Period{From: now.AddDate(0, 0, -7), To: now},
+		// A branch created from main without commits of its own is on main too
+		BranchHeads: []types.BranchHead{
+			{Name: "main", SHA: "m1", CommittedAt: now.Add(-time.Hour)},
+			{Name: "new-branch", SHA: "m1", CommittedAt: now.Add(-time.Hour), Contained: true},
+		},
+	}
+
+	hygiene := calculateHygiene(data, 30, 14)
+	if len(hygiene.MergedBranches) != 0 || len(hygiene.StaleBranches) != 0 {
+		t.Errorf("got merged %+v and stale %+v, want a new branch reported as neither", hygiene.MergedBranches, hygiene.StaleBranches)
+	}
+
+	mergedAt := now.Add(-30 * time.Minute)
+	data.BranchHeads[1].MergedAt = &mergedAt
+	hygiene = calculateHygiene(data, 30, 14)
+	if len(hygiene.MergedBranches) != 1 || hygiene.MergedBranches[0].Name != "new-branch" {
+		t.Errorf("got merged branches %+v, want new-branch after its pull request was merged", hygiene.MergedBranches)
+	}
+}
+
+func TestLatestMerge(t *testing.T) {
+	data := hygieneData()
+
+	// A pull request from a fork branch of the same name does not merge old-feature
+	if got := latestMerge("old-feature", data.Repository, data.UpdatedPRs); got != nil {
+		t.Errorf("latestMerge(old-feature) = %v, want nil", got)
+	}
+	if got := latestMerge("fresh", data.Repository, data.UpdatedPRs); got != nil {
+		t.Errorf("latestMerge(fresh) = %v, want nil", got)
+	}
+
+	// The latest of several merged pull requests from a branch counts
+	later := *data.UpdatedPRs[0].MergedAt
+	prs := append(data.UpdatedPRs, types.PullRequest{Number: 8, HeadRef: "in-review", HeadRepo: "o/r", State: types.PRStateMerged, MergedAt: &later})
+	if got := latestMerge("in-review", data.Repository, prs); got == nil || !got.Equal(later) {
+		t.Errorf("latestMerge(in-review) = %v, want %v", got, later)
+	}
+}
+
+func TestCalculateHygieneDefaultThresholds(t *testing.T) {
+	hygiene := calculateHygiene(&types.ReportData{}, 0, 0)
+
+	if hygiene.StaleBranchDays != DefaultStaleBranchDays || hygiene.StalePRDays != DefaultStalePRDays {
+		t.Errorf("got thresholds %d and %d days, want the defaults", hygiene.StaleBranchDays, hygiene.StalePRDays)
+	}
+	if hygiene.StaleBranches == nil || hygiene.StalePRs == nil {
+		t.Error("got nil lists, want empty lists for the JSON report")
+	}
+}
+
+func TestGenerateHygieneSection(t *testing.T) {
+	if got := generateHygieneSection(&types.ReportData{}); got != "" {
+		t.Errorf("generateHygieneSection() without hygiene = %q, want empty", got)
+	}
+	if got := generateHygieneSection(&types.ReportData{Hygiene: calculateHygiene(&types.ReportData{}, 0, 0)}); !strings.Contains(got, "No stale branches or abandoned pull requests found") {
+		t.Errorf("generateHygieneSection() without findings = %q, want empty message", got)
+	}
+
+	data := hygieneData()
+	data.Hygiene = calculateHygiene(data, 30, 14)
+
+	got := generateHygieneSection(data)
+
+	for _, want := range []string{
+		"## 🧹 Repository Hygiene",
+		"- **Stale Branches** (no commits for 30 days): 2",
+		"- **Merged Branches Not Deleted**: 3",
+		"- **Stale Pull Requests** (no activity for 14 days): 2",
+		"- **Pull Requests with Merge Conflicts**: 1",
+		"| `old-feature` | [`o1o1o1o`](https://github.com/o/r/commit/o1o1o1o1o1) 2025-01-10 | [bob](https://github.com/bob) | 50 |",
+		"- `squashed`: last commit 2025-02-18 by [bob](https://github.com/bob)",
+		"| [#2: Forgotten (draft)](https://github.com/o/r/pull/2) | [bob](https://github.com/bob) | 2025-01-20 | 40 |",
+		"- [#1: Active](https://github.com/o/r/pull/1) by [alice](https://github.com/alice) (`fresh` → `main`)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	html := generateHTMLHygieneSection("hygiene", data)
+	if !strings.Contains(html, "<section id=\"hygiene\">") || !strings.Contains(html, "<code>old-feature</code>") {
+		t.Errorf("generateHTMLHygieneSection() missing section or stale branch, got:\n%s", html)
+	}
+}
+
+func TestFilterByUserKeepsUserBranchHeads(t *testing.T) {
+	data := hygieneData()
+
+	filterByUser(data, "bob")
+
+	for _, head := range data.BranchHeads {
+		if head.Author.Login != "bob" {
+			t.Errorf("got branch %s by %s, want only branches of bob", head.Name, head.Author.Login)
+		}
+	}
+	if len(data.BranchHeads) != 3 {
+		t.Errorf("got %d branch heads, want 3", len(data.BranchHeads))
+	}
+}
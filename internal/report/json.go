@@ -0,0 +1,134 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// JSONSchemaVersion is the version of the JSON report schema.
+// It is bumped whenever a field is renamed or removed; adding fields keeps the version.
+const JSONSchemaVersion = "1.0"
+
+// jsonReport is the top-level document produced by the JSON output format
+type jsonReport struct {
+	SchemaVersion   string            `json:"schema_version"`
+	Report          *types.ReportData `json:"report"`
+	GenerationStats *GenerationStats  `json:"generation_stats"`
+}
+
+// jsonMultiReport is the top-level document of a multi-repository report
+type jsonMultiReport struct {
+	SchemaVersion   string                 `json:"schema_version"`
+	MultiReport     *types.MultiReportData `json:"multi_report"`
+	GenerationStats *GenerationStats       `json:"generation_stats"`
+}
+
+// generateJSON serializes the complete report data into a versioned JSON document
+func generateJSON(data *types.ReportData, stats *GenerationStats) (string, error) {
+	normalizeReportData(data)
+
+	if stats == nil {
+		stats = &GenerationStats{}
+	}
+
+	doc := jsonReport{
+		SchemaVersion:   JSONSchemaVersion,
+		Report:          data,
+		GenerationStats: stats,
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report to JSON: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// generateMultiJSON serializes a multi-repository report into a versioned JSON document
+func generateMultiJSON(data *types.MultiReportData, stats *GenerationStats) (string, error) {
+	for i := range data.Reports {
+		normalizeReportData(&data.Reports[i])
+	}
+	if data.AuthorStats == nil {
+		data.AuthorStats = []types.AuthorStats{}
+	}
+
+	if stats == nil {
+		stats = &GenerationStats{}
+	}
+
+	doc := jsonMultiReport{
+		SchemaVersion:   JSONSchemaVersion,
+		MultiReport:     data,
+		GenerationStats: stats,
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report to JSON: %w", err)
+	}
+
+	return string(out), nil
+}
+
+// normalizeReportData replaces nil slices with empty ones so that
+// list fields are always serialized as arrays instead of null
+func normalizeReportData(data *types.ReportData) {
+	if data.Branches == nil {
+		data.Branches = []types.Branch{}
+	}
+	for i := range data.Branches {
+		if data.Branches[i].Commits == nil {
+			data.Branches[i].Commits = []types.Commit{}
+		}
+		if data.Branches[i].PRs == nil {
+			data.Branches[i].PRs = []types.PullRequest{}
+		}
+		if data.Branches[i].Authors == nil {
+			data.Branches[i].Authors = []string{}
+		}
+	}
+	if data.OpenPRs == nil {
+		data.OpenPRs = []types.PullRequest{}
+	}
+	if data.UpdatedPRs == nil {
+		data.UpdatedPRs = []types.PullRequest{}
+	}
+	if data.OpenIssues == nil {
+		data.OpenIssues = []types.Issue{}
+	}
+	if data.ClosedIssues == nil {
+		data.ClosedIssues = []types.Issue{}
+	}
+	normalizeIssues(data.OpenIssues)
+	normalizeIssues(data.ClosedIssues)
+	normalizePRs(data.OpenPRs)
+	normalizePRs(data.UpdatedPRs)
+	if data.AuthorStats == nil {
+		data.AuthorStats = []types.AuthorStats{}
+	}
+}
+
+// normalizeIssues replaces nil label and assignee slices with empty ones
+func normalizeIssues(issues []types.Issue) {
+	for i := range issues {
+		if issues[i].Labels == nil {
+			issues[i].Labels = []string{}
+		}
+		if issues[i].Assignees == nil {
+			issues[i].Assignees = []types.Author{}
+		}
+	}
+}
+
+// normalizePRs replaces nil review slices with empty ones
+func normalizePRs(prs []types.PullRequest) {
+	for i := range prs {
+		if prs[i].ReviewDetails == nil {
+			prs[i].ReviewDetails = []types.Review{}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestGenerateJSON(t *testing.T) {
+	data := &types.ReportData{
+		Repository:    "owner/repo",
+		RepositoryURL: "https://github.com/owner/repo",
+		Period: types.Period{
+			From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		Branches: []types.Branch{
+			{
+				Name: "main",
+				Commits: []types.Commit{
+					{SHA: "abc123", Author: types.Author{Login: "alice"}, Additions: 10, Deletions: 2,
+						Files: []types.FileChange{{Path: "main.go", Additions: 10, Deletions: 2}}},
+				},
+				AISummary: "Branch summary",
+			},
+		},
+		OverallStats: types.OverallStats{TotalCommits: 1, TotalAuthors: 1},
+		AISummary:    "Overall summary",
+	}
+	stats := &GenerationStats{TotalBranches: 1, TotalAISummaries: 2, SuccessfulSummaries: 2}
+
+	got, err := generateJSON(data, stats)
+	if err != nil {
+		t.Fatalf("generateJSON() error = %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(got), &doc); err != nil {
+		t.Fatalf("generateJSON() produced invalid JSON: %v", err)
+	}
+
+	if doc["schema_version"] != JSONSchemaVersion {
+		t.Errorf("schema_version = %v, want %v", doc["schema_version"], JSONSchemaVersion)
+	}
+
+	report, ok := doc["report"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("report field missing or not an object")
+	}
+	if report["repository"] != "owner/repo" {
+		t.Errorf("report.repository = %v, want owner/repo", report["repository"])
+	}
+	if report["ai_summary"] != "Overall summary" {
+		t.Errorf("report.ai_summary = %v, want Overall summary", report["ai_summary"])
+	}
+
+	// Empty lists must be serialized as arrays, not null
+	for _, key := range []string{"open_prs", "updated_prs", "open_issues", "closed_issues", "author_stats"} {
+		if _, ok := report[key].([]interface{}); !ok {
+			t.Errorf("report.%s = %v, want empty array", key, report[key])
+		}
+	}
+
+	branches := report["branches"].([]interface{})
+	branch := branches[0].(map[string]interface{})
+	if branch["ai_summary"] != "Branch summary" {
+		t.Errorf("branch ai_summary = %v, want Branch summary", branch["ai_summary"])
+	}
+	commit := branch["commits"].([]interface{})[0].(map[string]interface{})
+	files, ok := commit["files"].([]interface{})
+	if !ok || len(files) != 1 || files[0].(map[string]interface{})["path"] != "main.go" {
+		t.Errorf("commit files = %v, want main.go with its line statistics", commit["files"])
+	}
+
+	genStats, ok := doc["generation_stats"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("generation_stats field missing or not an object")
+	}
+	if genStats["total_ai_summaries"] != float64(2) {
+		t.Errorf("generation_stats.total_ai_summaries = %v, want 2", genStats["total_ai_summaries"])
+	}
+}
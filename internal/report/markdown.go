@@ -2,6 +2,8 @@ package report
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,14 +15,11 @@ func generateHeader(data *types.ReportData) string {
 	var sb strings.Builder
 
 	// Repository name as main heading
-	repoName := strings.Split(data.Repository, "/")
-	displayName := data.Repository
-	if len(repoName) == 2 {
-		displayName = repoName[1]
-	}
-
-	sb.WriteString(fmt.Sprintf("# Repository Activity Report: %s\n\n", displayName))
+	sb.WriteString(fmt.Sprintf("# Repository Activity Report: %s\n\n", repoDisplayName(data.Repository)))
 	sb.WriteString(fmt.Sprintf("**Repository**: [%s](%s)\n\n", data.Repository, data.RepositoryURL))
+	if data.User != "" {
+		sb.WriteString(fmt.Sprintf("**User**: %s\n\n", formatAuthorLinks([]string{data.User})))
+	}
 	sb.WriteString(fmt.Sprintf("**Period**: %s to %s\n\n",
 		data.Period.From.Format("2006-01-02"),
 		data.Period.To.Format("2006-01-02")))
@@ -38,32 +37,34 @@ func calculateOverallStats(data *types.ReportData) types.OverallStats {
 		ClosedIssuesCount: len(data.ClosedIssues),
 	}
 
-	// Count total commits and collect unique authors
+	// Count unique commits and collect unique authors
+	commits := uniqueCommits(data.Branches)
 	authorSet := make(map[string]bool)
-	for _, branch := range data.Branches {
-		stats.TotalCommits += len(branch.Commits)
-		for _, commit := range branch.Commits {
-			authorSet[commit.Author.Login] = true
-		}
+	for _, commit := range commits {
+		authorSet[commit.Author.Login] = true
 	}
+	stats.TotalCommits = len(commits)
+	stats.BranchCommits = countBranchCommits(data.Branches)
 	stats.TotalAuthors = len(authorSet)
 
-	// Count reviews (will be implemented when review functionality is added)
-	stats.ReviewsCount = 0
-	for _, pr := range data.OpenPRs {
-		stats.ReviewsCount += pr.Reviews
-	}
-	for _, pr := range data.UpdatedPRs {
-		// Avoid double counting PRs that are both open and updated
-		isOpen := false
-		for _, openPR := range data.OpenPRs {
-			if openPR.Number == pr.Number {
-				isOpen = true
-				break
-			}
+	// Count pull requests merged or closed without merging during the period
+	for _, pr := range uniquePRs(data.OpenPRs, data.UpdatedPRs) {
+		switch {
+		case pr.MergedAt != nil && inPeriod(*pr.MergedAt, data.Period):
+			stats.MergedPRCount++
+		case pr.MergedAt == nil && pr.ClosedAt != nil && inPeriod(*pr.ClosedAt, data.Period):
+			stats.ClosedUnmergedPRCount++
 		}
-		if !isOpen {
-			stats.ReviewsCount += pr.Reviews
+	}
+
+	// Count reviews submitted during the period, avoiding double counting PRs
+	// that are both open and updated
+	stats.ReviewsCount = 0
+	stats.ReviewsByState = make(map[string]int)
+	for _, pr := range uniquePRs(data.OpenPRs, data.UpdatedPRs) {
+		for _, review := range reviewsInPeriod(pr.ReviewDetails, data.Period) {
+			stats.ReviewsCount++
+			stats.ReviewsByState[review.State]++
 		}
 	}
 
@@ -75,9 +76,15 @@ func generateSummaryStats(stats types.OverallStats) string {
 	var sb strings.Builder
 
 	sb.WriteString("## Summary Statistics\n\n")
-	sb.WriteString(fmt.Sprintf("- **Total Commits**: %d\n", stats.TotalCommits))
+	if stats.BranchCommits > stats.TotalCommits {
+		sb.WriteString(fmt.Sprintf("- **Total Commits**: %d (%d across branches)\n", stats.TotalCommits, stats.BranchCommits))
+	} else {
+		sb.WriteString(fmt.Sprintf("- **Total Commits**: %d\n", stats.TotalCommits))
+	}
 	sb.WriteString(fmt.Sprintf("- **Total Authors**: %d\n", stats.TotalAuthors))
 	sb.WriteString(fmt.Sprintf("- **Open Pull Requests**: %d\n", stats.OpenPRCount))
+	sb.WriteString(fmt.Sprintf("- **Merged Pull Requests**: %d\n", stats.MergedPRCount))
+	sb.WriteString(fmt.Sprintf("- **Closed Without Merge**: %d\n", stats.ClosedUnmergedPRCount))
 	sb.WriteString(fmt.Sprintf("- **Open Issues**: %d\n", stats.OpenIssuesCount))
 	sb.WriteString(fmt.Sprintf("- **Closed Issues**: %d\n", stats.ClosedIssuesCount))
 	sb.WriteString(fmt.Sprintf("- **Code Reviews**: %d\n\n", stats.ReviewsCount))
@@ -85,6 +92,102 @@ func generateSummaryStats(stats types.OverallStats) string {
 	return sb.String()
 }
 
+// generateUserSection generates the person-focused overview for a user-filtered report
+func generateUserSection(data *types.ReportData) string {
+	if data.User == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("## 👤 Activity of %s\n\n", data.User))
+
+	// Branches the user committed to
+	sb.WriteString("### Branches\n\n")
+	if len(data.Branches) == 0 {
+		sb.WriteString("No commits during this period\n\n")
+	} else {
+		for _, branch := range data.Branches {
+			sb.WriteString(fmt.Sprintf("- **%s**: %d commits (+%d / -%d lines)\n",
+				branch.Name, len(branch.Commits), branch.TotalAdded, branch.TotalDeleted))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Pull requests authored by the user
+	sb.WriteString("### Pull Requests\n\n")
+	prs := uniquePRs(data.OpenPRs, data.UpdatedPRs)
+	if len(prs) == 0 {
+		sb.WriteString("No pull requests authored during this period\n\n")
+	} else {
+		for _, pr := range prs {
+			sb.WriteString(fmt.Sprintf("- [PR #%d: %s](%s) - %s, %d reviews received\n",
+				pr.Number, pr.Title, pr.URL, pr.State, pr.Reviews))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Reviews given on other authors' pull requests
+	sb.WriteString("### Reviews Given\n\n")
+	if len(data.ReviewsGiven) == 0 {
+		sb.WriteString("No reviews given on other pull requests\n\n")
+	} else {
+		for _, activity := range data.ReviewsGiven {
+			sb.WriteString(fmt.Sprintf("- [PR #%d: %s](%s) by %s - %s on %s\n",
+				activity.PRNumber, activity.PRTitle, activity.PRURL,
+				formatAuthorLinks([]string{activity.PRAuthor.Login}),
+				formatReviewStates(map[string]int{activity.Review.State: 1}),
+				activity.Review.SubmittedAt.Format("2006-01-02")))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Issues created by or assigned to the user
+	created, assigned := 0, 0
+	for _, issue := range append(append([]types.Issue{}, data.OpenIssues...), data.ClosedIssues...) {
+		if isUser(issue.Author.Login, data.User) {
+			created++
+		} else {
+			assigned++
+		}
+	}
+	sb.WriteString("### Issues\n\n")
+	sb.WriteString(fmt.Sprintf("- **Created**: %d\n", created))
+	sb.WriteString(fmt.Sprintf("- **Assigned (created by others)**: %d\n\n", assigned))
+
+	return sb.String()
+}
+
+// uniquePRs merges open and updated pull requests, skipping duplicates by number
+func uniquePRs(openPRs, updatedPRs []types.PullRequest) []types.PullRequest {
+	seen := make(map[int]bool)
+	result := make([]types.PullRequest, 0, len(openPRs)+len(updatedPRs))
+	for _, pr := range append(append([]types.PullRequest{}, openPRs...), updatedPRs...) {
+		if seen[pr.Number] {
+			continue
+		}
+		seen[pr.Number] = true
+		result = append(result, pr)
+	}
+	return result
+}
+
+// mergedPRs returns the pull requests merged during the report period, most recent first
+func mergedPRs(data *types.ReportData) []types.PullRequest {
+	var result []types.PullRequest
+	for _, pr := range uniquePRs(data.OpenPRs, data.UpdatedPRs) {
+		if pr.MergedAt != nil && inPeriod(*pr.MergedAt, data.Period) {
+			result = append(result, pr)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].MergedAt.After(*result[j].MergedAt)
+	})
+
+	return result
+}
+
 // formatDate formats a time.Time to a readable string
 func formatDate(t time.Time) string {
 	return t.Format("2006-01-02 15:04")
@@ -133,9 +236,22 @@ func generateBranchSection(branch types.Branch) string {
 
 	// Statistics subsection
 	sb.WriteString("### Statistics\n\n")
-	sb.WriteString(fmt.Sprintf("- **Total Commits**: %d\n", len(branch.Commits)))
+	if inherited := inheritedCommits(branch); inherited > 0 {
+		sb.WriteString(fmt.Sprintf("- **Total Commits**: %d (%d introduced on other branches)\n", len(branch.Commits), inherited))
+	} else {
+		sb.WriteString(fmt.Sprintf("- **Total Commits**: %d\n", len(branch.Commits)))
+	}
 	sb.WriteString(fmt.Sprintf("- **Lines Added**: +%d\n", branch.TotalAdded))
 	sb.WriteString(fmt.Sprintf("- **Lines Deleted**: -%d\n", branch.TotalDeleted))
+	if branch.BaseBranch != "" {
+		sb.WriteString(fmt.Sprintf("- **Compared to Default Branch**: %s\n", formatBranchComparison(branch)))
+	}
+	if !branch.LastActivity.IsZero() {
+		sb.WriteString(fmt.Sprintf("- **Last Activity**: %s\n", formatDate(branch.LastActivity)))
+	}
+	if branch.BaseBranch != "" || len(branch.PRs) > 0 {
+		sb.WriteString(fmt.Sprintf("- **Pull Requests**: %s\n", formatBranchPullRequests(branch)))
+	}
 	sb.WriteString(fmt.Sprintf("- **Contributors**: %s\n\n", formatAuthorLinks(branch.Authors)))
 
 	// Commits subsection
@@ -145,10 +261,14 @@ func generateBranchSection(branch types.Branch) string {
 		short, full := formatCommitMessage(commit.Message)
 
 		sb.WriteString(fmt.Sprintf("#### [%s](%s)\n\n", short, commit.URL))
-		sb.WriteString(fmt.Sprintf("**Author**: [%s](%s) | **Date**: %s\n\n",
+		sb.WriteString(fmt.Sprintf("**Author**: [%s](%s) | **Date**: %s",
 			commit.Author.Login,
 			commit.Author.ProfileURL,
 			formatDate(commit.Date)))
+		if commit.IntroducedOn != "" && commit.IntroducedOn != branch.Name {
+			sb.WriteString(fmt.Sprintf(" | **Introduced on**: `%s`", commit.IntroducedOn))
+		}
+		sb.WriteString("\n\n")
 		sb.WriteString(fmt.Sprintf("**Changes**: +%d / -%d lines\n\n",
 			commit.Additions,
 			commit.Deletions))
@@ -188,7 +308,18 @@ func generatePRSection(pr types.PullRequest) string {
 	// Metadata
 	sb.WriteString(fmt.Sprintf("- **Author**: [%s](%s)\n", pr.Author.Login, pr.Author.ProfileURL))
 	sb.WriteString(fmt.Sprintf("- **Created**: %s\n", pr.CreatedAt.Format("2006-01-02")))
-	sb.WriteString(fmt.Sprintf("- **Status**: %s\n", pr.State))
+	sb.WriteString(fmt.Sprintf("- **Status**: %s\n", formatPRStatus(pr)))
+	if pr.HeadRef != "" && pr.BaseRef != "" {
+		sb.WriteString(fmt.Sprintf("- **Branch**: `%s` → `%s`\n", pr.HeadRef, pr.BaseRef))
+	}
+	if len(pr.Labels) > 0 {
+		sb.WriteString(fmt.Sprintf("- **Labels**: %s\n", strings.Join(pr.Labels, ", ")))
+	}
+	if pr.MergedAt != nil {
+		sb.WriteString(fmt.Sprintf("- **Merged**: %s%s\n", pr.MergedAt.Format("2006-01-02"), formatMergedBy(pr.MergedBy)))
+	} else if pr.ClosedAt != nil {
+		sb.WriteString(fmt.Sprintf("- **Closed**: %s\n", pr.ClosedAt.Format("2006-01-02")))
+	}
 	sb.WriteString(fmt.Sprintf("- **Comments**: %d\n", pr.Comments))
 	sb.WriteString(fmt.Sprintf("- **Reviews**: %d\n\n", pr.Reviews))
 
@@ -204,6 +335,22 @@ func generatePRSection(pr types.PullRequest) string {
 	return sb.String()
 }
 
+// formatPRStatus formats the state of a pull request, marking drafts
+func formatPRStatus(pr types.PullRequest) string {
+	if pr.Draft && pr.State == types.PRStateOpen {
+		return pr.State + " (draft)"
+	}
+	return pr.State
+}
+
+// formatMergedBy formats who merged a pull request, e.g. " by [login](url)"
+func formatMergedBy(mergedBy *types.Author) string {
+	if mergedBy == nil || mergedBy.Login == "" {
+		return ""
+	}
+	return fmt.Sprintf(" by [%s](%s)", mergedBy.Login, mergedBy.ProfileURL)
+}
+
 // generateOpenPRsSection generates the section for open pull requests
 func generateOpenPRsSection(prs []types.PullRequest) string {
 	var sb strings.Builder
@@ -240,6 +387,32 @@ func generateUpdatedPRsSection(prs []types.PullRequest) string {
 	return sb.String()
 }
 
+// generateMergedPRsSection generates the section for pull requests merged during the period
+func generateMergedPRsSection(data *types.ReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString("## 🟣 Merged Pull Requests\n\n")
+
+	prs := mergedPRs(data)
+	if len(prs) == 0 {
+		sb.WriteString("No pull requests were merged during this period\n\n")
+		return sb.String()
+	}
+
+	for _, pr := range prs {
+		line := fmt.Sprintf("- [PR #%d: %s](%s) by [%s](%s), merged %s%s",
+			pr.Number, pr.Title, pr.URL, pr.Author.Login, pr.Author.ProfileURL,
+			pr.MergedAt.Format("2006-01-02"), formatMergedBy(pr.MergedBy))
+		if pr.HeadRef != "" && pr.BaseRef != "" {
+			line += fmt.Sprintf(" (`%s` → `%s`)", pr.HeadRef, pr.BaseRef)
+		}
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
 // generateIssueSection generates a section for a single issue
 func generateIssueSection(issue types.Issue) string {
 	var sb strings.Builder
@@ -311,33 +484,31 @@ func calculateAuthorStats(data *types.ReportData) []types.AuthorStats {
 	// Map to accumulate statistics by author login
 	authorMap := make(map[string]*types.AuthorStats)
 
-	// Process all commits in all branches
-	for _, branch := range data.Branches {
-		for _, commit := range branch.Commits {
-			login := commit.Author.Login
+	// Process every commit once, under the branch it was introduced on
+	for _, commit := range uniqueCommits(data.Branches) {
+		login := commit.Author.Login
 
-			// Initialize author stats if not exists
-			if _, exists := authorMap[login]; !exists {
-				authorMap[login] = &types.AuthorStats{
-					Author:         commit.Author,
-					BranchActivity: make(map[string]types.BranchActivity),
-				}
+		// Initialize author stats if not exists
+		if _, exists := authorMap[login]; !exists {
+			authorMap[login] = &types.AuthorStats{
+				Author:         commit.Author,
+				BranchActivity: make(map[string]types.BranchActivity),
 			}
+		}
 
-			stats := authorMap[login]
+		stats := authorMap[login]
 
-			// Update overall stats
-			stats.TotalCommits++
-			stats.TotalAdded += commit.Additions
-			stats.TotalDeleted += commit.Deletions
+		// Update overall stats
+		stats.TotalCommits++
+		stats.TotalAdded += commit.Additions
+		stats.TotalDeleted += commit.Deletions
 
-			// Update branch activity
-			branchActivity := stats.BranchActivity[branch.Name]
-			branchActivity.Commits++
-			branchActivity.Added += commit.Additions
-			branchActivity.Deleted += commit.Deletions
-			stats.BranchActivity[branch.Name] = branchActivity
-		}
+		// Update branch activity
+		branchActivity := stats.BranchActivity[commit.IntroducedOn]
+		branchActivity.Commits++
+		branchActivity.Added += commit.Additions
+		branchActivity.Deleted += commit.Deletions
+		stats.BranchActivity[commit.IntroducedOn] = branchActivity
 	}
 
 	// Process PRs
@@ -404,9 +575,37 @@ func calculateAuthorStats(data *types.ReportData) []types.AuthorStats {
 		}
 	}
 
-	// Note: ReviewsCount will be populated when review functionality is added
-	// In a real implementation, we would query actual reviewers from GitHub API
-	// For now, this is just structural preparation for future enhancement
+	// Process reviews submitted during the period on collected PRs and reviews
+	// given on other PRs (user-focused reports)
+	reviews := make([]types.ReviewActivity, 0)
+	for _, pr := range uniquePRs(data.OpenPRs, data.UpdatedPRs) {
+		for _, review := range reviewsInPeriod(pr.ReviewDetails, data.Period) {
+			reviews = append(reviews, types.ReviewActivity{PRNumber: pr.Number, PRAuthor: pr.Author, Review: review})
+		}
+	}
+	reviews = append(reviews, data.ReviewsGiven...)
+
+	for _, activity := range reviews {
+		// Reviewer statistics
+		login := activity.Review.Author.Login
+		if _, exists := authorMap[login]; !exists {
+			authorMap[login] = &types.AuthorStats{
+				Author:         activity.Review.Author,
+				BranchActivity: make(map[string]types.BranchActivity),
+			}
+		}
+		stats := authorMap[login]
+		stats.ReviewsCount++
+		if stats.ReviewsByState == nil {
+			stats.ReviewsByState = make(map[string]int)
+		}
+		stats.ReviewsByState[activity.Review.State]++
+
+		// PR author statistics
+		if prAuthor, exists := authorMap[activity.PRAuthor.Login]; exists {
+			prAuthor.ReviewsReceived++
+		}
+	}
 
 	// Convert map to slice and sort by total commits (descending)
 	result := make([]types.AuthorStats, 0, len(authorMap))
@@ -432,23 +631,12 @@ func generateCodeReviewsSection(data *types.ReportData) string {
 
 	sb.WriteString("## 👀 Code Reviews\n\n")
 
-	// Collect PRs with reviews
+	// Collect PRs with reviews submitted during the period
 	prsWithReviews := []types.PullRequest{}
-	for _, pr := range data.OpenPRs {
-		if pr.Reviews > 0 {
-			prsWithReviews = append(prsWithReviews, pr)
-		}
-	}
-	for _, pr := range data.UpdatedPRs {
-		// Check if not already counted in open PRs
-		isOpen := false
-		for _, openPR := range data.OpenPRs {
-			if openPR.Number == pr.Number {
-				isOpen = true
-				break
-			}
-		}
-		if !isOpen && pr.Reviews > 0 {
+	for _, pr := range uniquePRs(data.OpenPRs, data.UpdatedPRs) {
+		if reviews := reviewsInPeriod(pr.ReviewDetails, data.Period); len(reviews) > 0 {
+			pr.ReviewDetails = reviews
+			pr.Reviews = len(reviews)
 			prsWithReviews = append(prsWithReviews, pr)
 		}
 	}
@@ -462,16 +650,68 @@ func generateCodeReviewsSection(data *types.ReportData) string {
 
 	totalReviews := 0
 	for _, pr := range prsWithReviews {
-		sb.WriteString(fmt.Sprintf("- [PR #%d: %s](%s) - %d reviews\n",
-			pr.Number, pr.Title, pr.URL, pr.Reviews))
+		line := fmt.Sprintf("- [PR #%d: %s](%s) - %d reviews", pr.Number, pr.Title, pr.URL, pr.Reviews)
+		if states := formatReviewStates(countReviewStates(pr.ReviewDetails)); states != "" {
+			line += fmt.Sprintf(" (%s)", states)
+		}
+		sb.WriteString(line + "\n")
 		totalReviews += pr.Reviews
 	}
 
+	// Reviewers
+	reviewers := make([]types.AuthorStats, 0)
+	for _, stats := range data.AuthorStats {
+		if stats.ReviewsCount > 0 {
+			reviewers = append(reviewers, stats)
+		}
+	}
+	if len(reviewers) > 0 {
+		sort.SliceStable(reviewers, func(i, j int) bool {
+			return reviewers[i].ReviewsCount > reviewers[j].ReviewsCount
+		})
+
+		sb.WriteString("\n### Reviewers\n\n")
+		for _, stats := range reviewers {
+			sb.WriteString(fmt.Sprintf("- [%s](%s) - %d reviews (%s)\n",
+				stats.Author.Login, stats.Author.ProfileURL, stats.ReviewsCount, formatReviewStates(stats.ReviewsByState)))
+		}
+	}
+
 	sb.WriteString(fmt.Sprintf("\n**Total Reviews**: %d\n\n", totalReviews))
 
 	return sb.String()
 }
 
+// countReviewStates counts reviews by state
+func countReviewStates(reviews []types.Review) map[string]int {
+	counts := make(map[string]int)
+	for _, review := range reviews {
+		counts[review.State]++
+	}
+	return counts
+}
+
+// formatReviewStates formats review state counts in a fixed order, e.g. "2 approved, 1 commented"
+func formatReviewStates(counts map[string]int) string {
+	labels := []struct {
+		state string
+		label string
+	}{
+		{types.ReviewStateApproved, "approved"},
+		{types.ReviewStateChangesRequested, "changes requested"},
+		{types.ReviewStateCommented, "commented"},
+		{types.ReviewStateDismissed, "dismissed"},
+	}
+
+	parts := make([]string, 0, len(labels))
+	for _, l := range labels {
+		if counts[l.state] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[l.state], l.label))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // generateAuthorSection generates a detailed section for a single author
 func generateAuthorSection(stats types.AuthorStats) string {
 	var sb strings.Builder
@@ -486,7 +726,12 @@ func generateAuthorSection(stats types.AuthorStats) string {
 	sb.WriteString(fmt.Sprintf("- **Total Lines Deleted**: -%d\n", stats.TotalDeleted))
 	sb.WriteString(fmt.Sprintf("- **Pull Requests Created**: %d\n", stats.PRsCreated))
 	sb.WriteString(fmt.Sprintf("- **Issues Created**: %d\n", stats.IssuesCreated))
-	sb.WriteString(fmt.Sprintf("- **Code Reviews**: %d\n\n", stats.ReviewsCount))
+	if states := formatReviewStates(stats.ReviewsByState); states != "" {
+		sb.WriteString(fmt.Sprintf("- **Code Reviews**: %d (%s)\n", stats.ReviewsCount, states))
+	} else {
+		sb.WriteString(fmt.Sprintf("- **Code Reviews**: %d\n", stats.ReviewsCount))
+	}
+	sb.WriteString(fmt.Sprintf("- **Reviews Received**: %d\n\n", stats.ReviewsReceived))
 
 	// Activity by branch
 	if len(stats.BranchActivity) > 0 {
@@ -522,3 +767,279 @@ func generateAuthorStatsSection(authorStats []types.AuthorStats) string {
 
 	return sb.String()
 }
+
+// generateMultiHeader generates the header of a multi-repository report
+func generateMultiHeader(data *types.MultiReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Activity Report: %d Repositories\n\n", len(data.Repositories)))
+	if data.User != "" {
+		sb.WriteString(fmt.Sprintf("**User**: %s\n\n", formatAuthorLinks([]string{data.User})))
+	}
+	sb.WriteString(fmt.Sprintf("**Period**: %s to %s\n\n",
+		data.Period.From.Format("2006-01-02"),
+		data.Period.To.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("**Report Generated**: %s UTC\n\n",
+		data.GeneratedAt.Format("2006-01-02 15:04:05")))
+
+	return sb.String()
+}
+
+// generateRepositoriesSection generates the cross-repository overview table
+func generateRepositoriesSection(reports []types.ReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString("## 📦 Repositories\n\n")
+	sb.WriteString("| Repository | Commits | Authors | Open PRs | Updated PRs | Open Issues | Closed Issues | Reviews |\n")
+	sb.WriteString("|------------|---------|---------|----------|-------------|-------------|---------------|---------|\n")
+
+	for _, report := range reports {
+		sb.WriteString(fmt.Sprintf("| [%s](%s) | %d | %d | %d | %d | %d | %d | %d |\n",
+			report.Repository,
+			report.RepositoryURL,
+			report.OverallStats.TotalCommits,
+			report.OverallStats.TotalAuthors,
+			report.OverallStats.OpenPRCount,
+			len(report.UpdatedPRs),
+			report.OverallStats.OpenIssuesCount,
+			report.OverallStats.ClosedIssuesCount,
+			report.OverallStats.ReviewsCount))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// generateRepositorySection generates the section of a single repository in a multi-repository report
+func generateRepositorySection(data types.ReportData) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# 📦 %s\n\n", data.Repository))
+	sb.WriteString(fmt.Sprintf("**Repository**: [%s](%s)\n\n", data.Repository, data.RepositoryURL))
+	sb.WriteString(generateComparisonSection(data.Comparison))
+	sb.WriteString(generateUserSection(&data))
+
+	sb.WriteString("## 📊 Overall Summary\n\n")
+	sb.WriteString(data.AISummary)
+	sb.WriteString("\n\n")
+
+	sb.WriteString(generateBranchesSection(data.Branches))
+	sb.WriteString(generateOpenPRsSection(data.OpenPRs))
+	sb.WriteString(generateMergedPRsSection(&data))
+	sb.WriteString(generateReleasesSection(data.Releases))
+	sb.WriteString(generateDeploymentsSection(&data))
+	sb.WriteString(generateUpdatedPRsSection(data.UpdatedPRs))
+	sb.WriteString(generateOpenIssuesSection(data.OpenIssues))
+	sb.WriteString(generateClosedIssuesSection(data.ClosedIssues))
+	sb.WriteString(generateCodeReviewsSection(&data))
+	sb.WriteString(generateCycleTimeSection(data.CycleTime))
+	sb.WriteString(generateDeliverySection(data.Delivery))
+	sb.WriteString(generateCISection(data.CI))
+	sb.WriteString(generateHygieneSection(&data))
+	sb.WriteString(generateHotspotsSection(data.Hotspots))
+
+	return sb.String()
+}
+
+// formatChange formats the change of a metric with an up/down indicator,
+// e.g. "⬆️ +5 (+25%)"
+func formatChange(metric types.ComparisonMetric) string {
+	diff, percent, ok := metric.Change()
+
+	indicator := "➖"
+	switch {
+	case diff > 0:
+		indicator = "⬆️"
+	case diff < 0:
+		indicator = "⬇️"
+	}
+
+	if diff == 0 {
+		return indicator + " 0"
+	}
+	if !ok {
+		return fmt.Sprintf("%s %+d (new)", indicator, diff)
+	}
+	return fmt.Sprintf("%s %+d (%+.0f%%)", indicator, diff, percent)
+}
+
+// generateComparisonSection generates the period-over-period comparison table
+func generateComparisonSection(comparison *types.PeriodComparison) string {
+	if comparison == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("## 📈 Compared to Previous Period\n\n")
+	sb.WriteString(fmt.Sprintf("*Previous period: %s to %s*\n\n",
+		comparison.PreviousPeriod.From.Format("2006-01-02"),
+		comparison.PreviousPeriod.To.Format("2006-01-02")))
+	sb.WriteString("| Metric | Current | Previous | Change |\n")
+	sb.WriteString("|--------|---------|----------|--------|\n")
+	for _, metric := range comparison.Metrics() {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %s |\n", metric.Name, metric.Current, metric.Previous, formatChange(metric)))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// formatHours formats a duration in hours, e.g. "45m", "5h 20m" or "2d 4h"
+func formatHours(hours float64) string {
+	minutes := int(math.Round(hours * 60))
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes < 24*60:
+		return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+	default:
+		return fmt.Sprintf("%dd %dh", minutes/(24*60), minutes%(24*60)/60)
+	}
+}
+
+// formatDurationStats formats the median and 90th percentile of a duration,
+// or "-" when nothing was measured
+func formatDurationStats(stats types.DurationStats) (median, p90 string) {
+	if stats.Count == 0 {
+		return "-", "-"
+	}
+	return formatHours(stats.Median), formatHours(stats.P90)
+}
+
+// generateCycleTimeSection generates the pull request cycle-time and review-latency section
+func generateCycleTimeSection(analytics *types.CycleTimeAnalytics) string {
+	if analytics == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("## ⏱️ Pull Request Cycle Time\n\n")
+
+	if len(analytics.PullRequests) == 0 {
+		sb.WriteString("No pull requests were reviewed or merged during this period\n\n")
+		return sb.String()
+	}
+
+	overall := analytics.Overall
+	sb.WriteString("| Metric | PRs | Median | p90 |\n")
+	sb.WriteString("|--------|-----|--------|-----|\n")
+	for _, metric := range []struct {
+		name  string
+		stats types.DurationStats
+	}{
+		{"Time to First Review", overall.TimeToFirstReview},
+		{"Time to Approval", overall.TimeToApproval},
+		{"Time to Merge", overall.TimeToMerge},
+	} {
+		median, p90 := formatDurationStats(metric.stats)
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s |\n", metric.name, metric.stats.Count, median, p90))
+	}
+	if overall.ReviewedPRs > 0 {
+		sb.WriteString(fmt.Sprintf("| Review Rounds | %d | %.1f | %.1f |\n", overall.ReviewedPRs, overall.MedianReviewRounds, overall.P90ReviewRounds))
+	} else {
+		sb.WriteString("| Review Rounds | 0 | - | - |\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("### By Author\n\n")
+	sb.WriteString("| Author | PRs | First Review (median / p90) | Approval (median / p90) | Merge (median / p90) | Review Rounds (median) |\n")
+	sb.WriteString("|--------|-----|-----------------------------|-------------------------|----------------------|------------------------|\n")
+	for _, author := range analytics.Authors {
+		firstMedian, firstP90 := formatDurationStats(author.TimeToFirstReview)
+		approvalMedian, approvalP90 := formatDurationStats(author.TimeToApproval)
+		mergeMedian, mergeP90 := formatDurationStats(author.TimeToMerge)
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s / %s | %s / %s | %s / %s | %.1f |\n",
+			formatAuthorLink(author.Author), author.PRs,
+			firstMedian, firstP90, approvalMedian, approvalP90, mergeMedian, mergeP90,
+			author.MedianReviewRounds))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// deploymentSourceDescription explains what counts as a deployment
+func deploymentSourceDescription(source string) string {
+	switch source {
+	case types.DeploymentSourceMergedPRs:
+		return "Deployments are pull requests merged during the period"
+	case types.DeploymentSourceReleases:
+		return "Deployments are releases and tags published during the period, excluding pre-releases"
+	case types.DeploymentSourceDeployments:
+		return "Deployments are successful deployments to production environments during the period"
+	default:
+		return "Deployments are counted from " + source
+	}
+}
+
+// formatDurationSummary formats the median and 90th percentile of a duration
+// with the number of measurements, or "-" when nothing was measured
+func formatDurationSummary(stats types.DurationStats, unit string) string {
+	if stats.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("median %s, p90 %s (%d %s)", formatHours(stats.Median), formatHours(stats.P90), stats.Count, unit)
+}
+
+// formatChangeFailure formats a failed change as a list item
+func formatChangeFailure(failure types.ChangeFailure) string {
+	switch {
+	case failure.Kind == types.FailureKindIncident:
+		line := fmt.Sprintf("- **Incident**: [Issue #%d: %s](%s), opened %s", failure.Number, failure.Title, failure.URL, failure.At.Format("2006-01-02"))
+		if failure.RestoredAt != nil {
+			line += ", restored in " + formatHours(failure.RestoredAt.Sub(failure.At).Hours())
+		}
+		return line + "\n"
+	case failure.Number > 0:
+		return fmt.Sprintf("- **Revert**: [PR #%d: %s](%s), merged %s\n", failure.Number, failure.Title, failure.URL, failure.At.Format("2006-01-02"))
+	default:
+		return fmt.Sprintf("- **Revert**: [`%s`](%s) %s, committed %s\n", shortSHA(failure.SHA), failure.URL, failure.Title, failure.At.Format("2006-01-02"))
+	}
+}
+
+// generateDeliverySection generates the DORA-style delivery metrics section
+func generateDeliverySection(metrics *types.DeliveryMetrics) string {
+	if metrics == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("## 🚀 Delivery Metrics\n\n")
+	sb.WriteString(fmt.Sprintf("%s; failed changes are reverts and issues labeled %s.\n\n",
+		deploymentSourceDescription(metrics.DeploymentSource), formatLabelList(metrics.IncidentLabels)))
+
+	changeFailureRate := "-"
+	if metrics.Deployments > 0 {
+		changeFailureRate = fmt.Sprintf("%.1f%% (%d of %d)", metrics.ChangeFailureRate, metrics.FailedChanges, metrics.Deployments)
+	}
+
+	sb.WriteString("| Metric | Value |\n")
+	sb.WriteString("|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| Deployment Frequency | %d (%.1f per week) |\n", metrics.Deployments, metrics.DeploymentsPerWeek))
+	sb.WriteString(fmt.Sprintf("| Lead Time for Changes | %s |\n", formatDurationSummary(metrics.LeadTime, "changes")))
+	sb.WriteString(fmt.Sprintf("| Change Failure Rate | %s |\n", changeFailureRate))
+	sb.WriteString(fmt.Sprintf("| Time to Restore | %s |\n", formatDurationSummary(metrics.TimeToRestore, "incidents")))
+	sb.WriteString("\n")
+
+	if len(metrics.Failures) > 0 {
+		sb.WriteString("### Failed Changes\n\n")
+		for _, failure := range metrics.Failures {
+			sb.WriteString(formatChangeFailure(failure))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// formatLabelList formats labels as inline code joined with "or"
+func formatLabelList(labels []string) string {
+	quoted := make([]string, len(labels))
+	for i, label := range labels {
+		quoted[i] = "`" + label + "`"
+	}
+	return strings.Join(quoted, " or ")
+}
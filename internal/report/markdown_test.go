@@ -198,6 +198,8 @@ func TestGenerateSummaryStats(t *testing.T) {
 func TestCalculateOverallStats(t *testing.T) {
 	author1 := types.Author{Login: "alice", ProfileURL: "https://github.com/alice"}
 	author2 := types.Author{Login: "bob", ProfileURL: "https://github.com/bob"}
+	recent := types.Review{Author: author2, State: "APPROVED", SubmittedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)}
+	old := types.Review{Author: author2, State: "COMMENTED", SubmittedAt: time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)}
 
 	data := &types.ReportData{
 		Branches: []types.Branch{
@@ -216,12 +218,12 @@ func TestCalculateOverallStats(t *testing.T) {
 			},
 		},
 		OpenPRs: []types.PullRequest{
-			{Number: 1, Reviews: 2},
-			{Number: 2, Reviews: 3},
+			{Number: 1, Reviews: 3, ReviewDetails: []types.Review{recent, recent, old}}, // Review before the period is not counted
+			{Number: 2, Reviews: 3, ReviewDetails: []types.Review{recent, recent, recent}},
 		},
 		UpdatedPRs: []types.PullRequest{
-			{Number: 1, Reviews: 2}, // Same as open PR, should not double count
-			{Number: 3, Reviews: 1}, // Different PR
+			{Number: 1, Reviews: 3, ReviewDetails: []types.Review{recent, recent, old}}, // Same as open PR, should not double count
+			{Number: 3, Reviews: 1, ReviewDetails: []types.Review{recent}},              // Different PR
 		},
 		OpenIssues: []types.Issue{
 			{Number: 10},
@@ -230,6 +232,10 @@ func TestCalculateOverallStats(t *testing.T) {
 		ClosedIssues: []types.Issue{
 			{Number: 5},
 		},
+		Period: types.Period{
+			From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+		},
 	}
 
 	got := calculateOverallStats(data)
@@ -253,6 +259,9 @@ func TestCalculateOverallStats(t *testing.T) {
 	if got.ReviewsCount != 6 {
 		t.Errorf("calculateOverallStats() ReviewsCount = %d, want 6", got.ReviewsCount)
 	}
+	if got.ReviewsByState["COMMENTED"] != 0 {
+		t.Errorf("calculateOverallStats() counted %d reviews from before the period", got.ReviewsByState["COMMENTED"])
+	}
 }
 
 func TestCalculateAuthorStats(t *testing.T) {
@@ -330,3 +339,244 @@ func TestCalculateAuthorStats(t *testing.T) {
 		t.Errorf("calculateAuthorStats() bob TotalCommits = %d, want 1", got[1].TotalCommits)
 	}
 }
+
+func TestCalculateAuthorStatsReviews(t *testing.T) {
+	alice := types.Author{Login: "alice"}
+	bob := types.Author{Login: "bob"}
+	carol := types.Author{Login: "carol"}
+
+	data := &types.ReportData{
+		OpenPRs: []types.PullRequest{
+			{
+				Number:  1,
+				Author:  alice,
+				Reviews: 2,
+				ReviewDetails: []types.Review{
+					{Author: bob, State: types.ReviewStateChangesRequested},
+					{Author: bob, State: types.ReviewStateApproved},
+				},
+			},
+		},
+		UpdatedPRs: []types.PullRequest{
+			{
+				Number:  1, // Same as open PR, should not double count
+				Author:  alice,
+				Reviews: 2,
+				ReviewDetails: []types.Review{
+					{Author: bob, State: types.ReviewStateChangesRequested},
+					{Author: bob, State: types.ReviewStateApproved},
+				},
+			},
+			{
+				Number:        2,
+				Author:        bob,
+				Reviews:       1,
+				ReviewDetails: []types.Review{{Author: carol, State: types.ReviewStateCommented}},
+			},
+		},
+	}
+
+	got := calculateAuthorStats(data)
+
+	byLogin := make(map[string]types.AuthorStats)
+	for _, stats := range got {
+		byLogin[stats.Author.Login] = stats
+	}
+
+	if byLogin["bob"].ReviewsCount != 2 {
+		t.Errorf("bob ReviewsCount = %d, want 2", byLogin["bob"].ReviewsCount)
+	}
+	if byLogin["bob"].ReviewsByState[types.ReviewStateApproved] != 1 {
+		t.Errorf("bob approved reviews = %d, want 1", byLogin["bob"].ReviewsByState[types.ReviewStateApproved])
+	}
+	if byLogin["bob"].ReviewsReceived != 1 {
+		t.Errorf("bob ReviewsReceived = %d, want 1", byLogin["bob"].ReviewsReceived)
+	}
+	if byLogin["alice"].ReviewsReceived != 2 {
+		t.Errorf("alice ReviewsReceived = %d, want 2", byLogin["alice"].ReviewsReceived)
+	}
+	if byLogin["carol"].ReviewsCount != 1 {
+		t.Errorf("carol ReviewsCount = %d, want 1", byLogin["carol"].ReviewsCount)
+	}
+
+	overall := calculateOverallStats(data)
+	if overall.ReviewsByState[types.ReviewStateChangesRequested] != 1 {
+		t.Errorf("overall changes requested = %d, want 1", overall.ReviewsByState[types.ReviewStateChangesRequested])
+	}
+}
+
+func TestCalculateAuthorStatsReviewsInPeriod(t *testing.T) {
+	period := types.Period{
+		From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+	}
+	alice := types.Author{Login: "alice"}
+	bob := types.Author{Login: "bob"}
+
+	data := &types.ReportData{
+		Period: period,
+		OpenPRs: []types.PullRequest{
+			{
+				Number:  1,
+				Author:  alice,
+				Reviews: 2,
+				ReviewDetails: []types.Review{
+					// Submitted before the period on a PR that is still open
+					{Author: bob, State: types.ReviewStateChangesRequested, SubmittedAt: period.From.Add(-48 * time.Hour)},
+					{Author: bob, State: types.ReviewStateApproved, SubmittedAt: period.From.Add(24 * time.Hour)},
+				},
+			},
+		},
+	}
+
+	got := calculateAuthorStats(data)
+
+	for _, stats := range got {
+		if stats.Author.Login == "bob" && stats.ReviewsCount != 1 {
+			t.Errorf("bob ReviewsCount = %d, want 1", stats.ReviewsCount)
+		}
+		if stats.Author.Login == "alice" && stats.ReviewsReceived != 1 {
+			t.Errorf("alice ReviewsReceived = %d, want 1", stats.ReviewsReceived)
+		}
+	}
+
+	section := generateCodeReviewsSection(data)
+	if !strings.Contains(section, "- 1 reviews (1 approved)") {
+		t.Errorf("code reviews section should count only the review of the period, got:\n%s", section)
+	}
+}
+
+func TestFormatReviewStates(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts map[string]int
+		want   string
+	}{
+		{
+			name:   "No reviews",
+			counts: map[string]int{},
+			want:   "",
+		},
+		{
+			name: "All states in fixed order",
+			counts: map[string]int{
+				types.ReviewStateCommented:        3,
+				types.ReviewStateApproved:         2,
+				types.ReviewStateDismissed:        1,
+				types.ReviewStateChangesRequested: 1,
+			},
+			want: "2 approved, 1 changes requested, 3 commented, 1 dismissed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatReviewStates(tt.counts)
+			if got != tt.want {
+				t.Errorf("formatReviewStates() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateFooterWarnings(t *testing.T) {
+	stats := &GenerationStats{
+		Warnings: []string{"Results were truncated at the item limit: commits of owner/repo on branch main"},
+	}
+
+	got := generateFooter(stats)
+	if !strings.Contains(got, "> ⚠️ Results were truncated at the item limit: commits of owner/repo on branch main") {
+		t.Errorf("generateFooter() missing truncation warning, got:\n%s", got)
+	}
+
+	got = generateFooter(&GenerationStats{})
+	if strings.Contains(got, "⚠️") {
+		t.Errorf("generateFooter() should not contain warnings when there are none")
+	}
+}
+
+func TestMergedAndClosedPullRequests(t *testing.T) {
+	period := types.Period{
+		From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+	}
+	early := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)
+	merger := &types.Author{Login: "carol", ProfileURL: "https://github.com/carol"}
+
+	data := &types.ReportData{
+		Period: period,
+		UpdatedPRs: []types.PullRequest{
+			{Number: 1, Title: "First", State: types.PRStateMerged, ClosedAt: &early, MergedAt: &early, MergedBy: merger},
+			{Number: 2, Title: "Second", State: types.PRStateMerged, ClosedAt: &late, MergedAt: &late, BaseRef: "main", HeadRef: "feature"},
+			{Number: 3, Title: "Abandoned", State: types.PRStateClosed, ClosedAt: &late},
+			{Number: 4, Title: "Merged earlier", State: types.PRStateMerged, ClosedAt: &before, MergedAt: &before},
+		},
+	}
+
+	stats := calculateOverallStats(data)
+	if stats.MergedPRCount != 2 || stats.ClosedUnmergedPRCount != 1 {
+		t.Errorf("calculateOverallStats() merged = %d, closed unmerged = %d, want 2 and 1", stats.MergedPRCount, stats.ClosedUnmergedPRCount)
+	}
+
+	got := generateMergedPRsSection(data)
+
+	if !strings.Contains(got, "## 🟣 Merged Pull Requests") {
+		t.Errorf("generateMergedPRsSection() missing heading")
+	}
+	if strings.Contains(got, "Abandoned") || strings.Contains(got, "Merged earlier") {
+		t.Errorf("generateMergedPRsSection() lists pull requests not merged in the period\n%s", got)
+	}
+	if strings.Index(got, "PR #2") > strings.Index(got, "PR #1") {
+		t.Errorf("generateMergedPRsSection() should list the most recent merge first\n%s", got)
+	}
+	if !strings.Contains(got, "merged 2024-01-02 by [carol](https://github.com/carol)") {
+		t.Errorf("generateMergedPRsSection() missing merger\n%s", got)
+	}
+	if !strings.Contains(got, "(`feature` → `main`)") {
+		t.Errorf("generateMergedPRsSection() missing branches\n%s", got)
+	}
+
+	empty := generateMergedPRsSection(&types.ReportData{Period: period})
+	if !strings.Contains(empty, "No pull requests were merged during this period") {
+		t.Errorf("generateMergedPRsSection() without merges = %q", empty)
+	}
+}
+
+func TestGeneratePRSectionMetadata(t *testing.T) {
+	merged := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		pr   types.PullRequest
+		want []string
+	}{
+		{
+			name: "draft with labels",
+			pr:   types.PullRequest{Number: 1, State: types.PRStateOpen, Draft: true, Labels: []string{"wip", "ui"}, BaseRef: "main", HeadRef: "feature"},
+			want: []string{"- **Status**: open (draft)", "- **Labels**: wip, ui", "- **Branch**: `feature` → `main`"},
+		},
+		{
+			name: "merged",
+			pr:   types.PullRequest{Number: 2, State: types.PRStateMerged, ClosedAt: &merged, MergedAt: &merged, MergedBy: &types.Author{Login: "carol", ProfileURL: "https://github.com/carol"}},
+			want: []string{"- **Status**: merged", "- **Merged**: 2024-01-05 by [carol](https://github.com/carol)"},
+		},
+		{
+			name: "closed without merge",
+			pr:   types.PullRequest{Number: 3, State: types.PRStateClosed, ClosedAt: &merged},
+			want: []string{"- **Status**: closed", "- **Closed**: 2024-01-05"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := generatePRSection(tt.pr)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("generatePRSection() missing %q\n%s", want, got)
+				}
+			}
+		})
+	}
+}
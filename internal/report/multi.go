@@ -0,0 +1,218 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+	"github.com/hazadus/gh-repomon/internal/utils"
+)
+
+// maxRepoWorkers limits how many repositories are collected at the same time.
+// Each repository already issues several requests in parallel.
+const maxRepoWorkers = 3
+
+// generateMulti generates a combined report for opts.Repositories.
+// Repositories are collected in parallel; a repository that fails is skipped
+// with a warning, and the report fails only when no repository succeeded.
+func (g *Generator) generateMulti(ctx context.Context, opts Options) (string, error) {
+	// Fail before collecting anything if the report cannot be rendered
+	renderer, err := g.selectMultiRenderer(opts)
+	if err != nil {
+		return "", err
+	}
+
+	stats := &GenerationStats{}
+
+	g.logger.Info(fmt.Sprintf("Collecting data for %d repositories...", len(opts.Repositories)))
+
+	reports := make([]*types.ReportData, len(opts.Repositories))
+	repoStats := make([]*GenerationStats, len(opts.Repositories))
+	repoErrs := make([]error, len(opts.Repositories))
+
+	indexes := make([]int, len(opts.Repositories))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	var mu sync.Mutex
+	_ = utils.ProcessInParallelWithContext(ctx, indexes, maxRepoWorkers, func(ctx context.Context, i int) error {
+		repoOpts := opts
+		repoOpts.Repository = opts.Repositories[i]
+		repoOpts.Repositories = nil
+
+		rs := &GenerationStats{}
+		data, err := g.prepareData(ctx, repoOpts, rs)
+
+		mu.Lock()
+		reports[i], repoStats[i], repoErrs[i] = data, rs, err
+		mu.Unlock()
+		return nil
+	})
+
+	// Keep successful repositories in the requested order
+	var firstErr error
+	collected := make([]types.ReportData, 0, len(reports))
+	for i, repo := range opts.Repositories {
+		if repoErrs[i] != nil {
+			if firstErr == nil {
+				firstErr = repoErrs[i]
+			}
+			warning := fmt.Sprintf("Skipped %s: %v", repo, repoErrs[i])
+			g.logger.Warning(warning)
+			stats.Warnings = append(stats.Warnings, warning)
+			continue
+		}
+		if reports[i] == nil {
+			// Not started before the run was stopped
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("Skipped %s: the run %s before it was collected", repo, interruptReason(ctx.Err())))
+			continue
+		}
+
+		stats.TotalBranches += repoStats[i].TotalBranches
+		for _, warning := range repoStats[i].Warnings {
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("%s: %s", repo, warning))
+		}
+		collected = append(collected, *reports[i])
+	}
+
+	if len(collected) == 0 {
+		if firstErr != nil {
+			return "", firstErr
+		}
+		return "", fmt.Errorf("no repository data collected: %w", ctx.Err())
+	}
+
+	g.addTruncationWarnings(stats)
+
+	// Summaries are generated one repository at a time to respect LLM rate limits
+	for i := range collected {
+		g.summarize(ctx, &collected[i], opts, stats)
+		g.saveSnapshot(ctx, &collected[i])
+	}
+
+	data := &types.MultiReportData{
+		Repositories: make([]string, 0, len(collected)),
+		Period:       opts.Period,
+		User:         opts.User,
+		GeneratedAt:  time.Now().UTC(),
+		Reports:      collected,
+		AuthorStats:  mergeAuthorStats(collected),
+		OverallStats: mergeOverallStats(collected),
+	}
+	for _, report := range collected {
+		data.Repositories = append(data.Repositories, report.Repository)
+	}
+
+	return renderer.RenderMulti(data, stats)
+}
+
+// selectMultiRenderer picks the renderer for a multi-repository report
+func (g *Generator) selectMultiRenderer(opts Options) (MultiRenderer, error) {
+	renderer, err := g.selectRenderer(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if tmpl, ok := renderer.(*TemplateRenderer); ok && !tmpl.SupportsMulti() {
+		return nil, fmt.Errorf("custom templates can only render single-repository reports")
+	}
+
+	multi, ok := renderer.(MultiRenderer)
+	if !ok {
+		return nil, fmt.Errorf("the selected renderer does not support multi-repository reports")
+	}
+
+	return multi, nil
+}
+
+// mergeOverallStats sums the statistics of several repositories.
+// Authors active in more than one repository are counted once.
+func mergeOverallStats(reports []types.ReportData) types.OverallStats {
+	stats := types.OverallStats{
+		ReviewsByState: make(map[string]int),
+	}
+
+	authorSet := make(map[string]bool)
+	for _, report := range reports {
+		stats.TotalCommits += report.OverallStats.TotalCommits
+		stats.BranchCommits += report.OverallStats.BranchCommits
+		stats.OpenPRCount += report.OverallStats.OpenPRCount
+		stats.MergedPRCount += report.OverallStats.MergedPRCount
+		stats.ClosedUnmergedPRCount += report.OverallStats.ClosedUnmergedPRCount
+		stats.OpenIssuesCount += report.OverallStats.OpenIssuesCount
+		stats.ClosedIssuesCount += report.OverallStats.ClosedIssuesCount
+		stats.ReviewsCount += report.OverallStats.ReviewsCount
+		for state, count := range report.OverallStats.ReviewsByState {
+			stats.ReviewsByState[state] += count
+		}
+
+		for _, branch := range report.Branches {
+			for _, commit := range branch.Commits {
+				authorSet[commit.Author.Login] = true
+			}
+		}
+	}
+	stats.TotalAuthors = len(authorSet)
+
+	return stats
+}
+
+// mergeAuthorStats combines the author statistics of several repositories.
+// Branch activity keys are prefixed with the repository name ("repo:branch").
+func mergeAuthorStats(reports []types.ReportData) []types.AuthorStats {
+	authorMap := make(map[string]*types.AuthorStats)
+
+	for _, report := range reports {
+		repoName := repoDisplayName(report.Repository)
+
+		for _, authorStats := range report.AuthorStats {
+			login := authorStats.Author.Login
+			merged, exists := authorMap[login]
+			if !exists {
+				merged = &types.AuthorStats{
+					Author:         authorStats.Author,
+					BranchActivity: make(map[string]types.BranchActivity),
+				}
+				authorMap[login] = merged
+			}
+
+			merged.TotalCommits += authorStats.TotalCommits
+			merged.TotalAdded += authorStats.TotalAdded
+			merged.TotalDeleted += authorStats.TotalDeleted
+			merged.PRsCreated += authorStats.PRsCreated
+			merged.IssuesCreated += authorStats.IssuesCreated
+			merged.ReviewsCount += authorStats.ReviewsCount
+			merged.ReviewsReceived += authorStats.ReviewsReceived
+
+			for state, count := range authorStats.ReviewsByState {
+				if merged.ReviewsByState == nil {
+					merged.ReviewsByState = make(map[string]int)
+				}
+				merged.ReviewsByState[state] += count
+			}
+
+			for branch, activity := range authorStats.BranchActivity {
+				merged.BranchActivity[repoName+":"+branch] = activity
+			}
+		}
+	}
+
+	result := make([]types.AuthorStats, 0, len(authorMap))
+	for _, stats := range authorMap {
+		result = append(result, *stats)
+	}
+
+	// Sort by total commits (descending), then by login for a stable order
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TotalCommits != result[j].TotalCommits {
+			return result[i].TotalCommits > result[j].TotalCommits
+		}
+		return result[i].Author.Login < result[j].Author.Login
+	})
+
+	return result
+}
@@ -0,0 +1,165 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// multiTestReports returns two repositories sharing one author
+func multiTestReports() []types.ReportData {
+	alice := types.Author{Login: "alice"}
+	bob := types.Author{Login: "bob"}
+
+	return []types.ReportData{
+		{
+			Repository:    "org/api",
+			RepositoryURL: "https://github.com/org/api",
+			Branches: []types.Branch{
+				{Name: "main", Commits: []types.Commit{{SHA: "a1", Author: alice}, {SHA: "a2", Author: bob}}},
+			},
+			OverallStats: types.OverallStats{
+				TotalCommits:   2,
+				TotalAuthors:   2,
+				OpenPRCount:    1,
+				ReviewsCount:   1,
+				ReviewsByState: map[string]int{types.ReviewStateApproved: 1},
+			},
+			AuthorStats: []types.AuthorStats{
+				{
+					Author:         alice,
+					TotalCommits:   1,
+					TotalAdded:     10,
+					ReviewsCount:   1,
+					ReviewsByState: map[string]int{types.ReviewStateApproved: 1},
+					BranchActivity: map[string]types.BranchActivity{"main": {Commits: 1, Added: 10}},
+				},
+				{
+					Author:         bob,
+					TotalCommits:   1,
+					BranchActivity: map[string]types.BranchActivity{"main": {Commits: 1}},
+				},
+			},
+		},
+		{
+			Repository:    "org/web",
+			RepositoryURL: "https://github.com/org/web",
+			Branches: []types.Branch{
+				{Name: "main", Commits: []types.Commit{{SHA: "b1", Author: alice}, {SHA: "b2", Author: alice}}},
+			},
+			OverallStats: types.OverallStats{
+				TotalCommits:      2,
+				TotalAuthors:      1,
+				ClosedIssuesCount: 3,
+				ReviewsCount:      2,
+				ReviewsByState:    map[string]int{types.ReviewStateApproved: 1, types.ReviewStateCommented: 1},
+			},
+			AuthorStats: []types.AuthorStats{
+				{
+					Author:         alice,
+					TotalCommits:   2,
+					TotalAdded:     5,
+					PRsCreated:     1,
+					BranchActivity: map[string]types.BranchActivity{"main": {Commits: 2, Added: 5}},
+				},
+			},
+		},
+	}
+}
+
+func TestMergeOverallStats(t *testing.T) {
+	got := mergeOverallStats(multiTestReports())
+
+	if got.TotalCommits != 4 {
+		t.Errorf("TotalCommits = %d, want 4", got.TotalCommits)
+	}
+	if got.TotalAuthors != 2 {
+		t.Errorf("TotalAuthors = %d, want 2 (alice counted once)", got.TotalAuthors)
+	}
+	if got.OpenPRCount != 1 || got.ClosedIssuesCount != 3 {
+		t.Errorf("OpenPRCount = %d, ClosedIssuesCount = %d, want 1 and 3", got.OpenPRCount, got.ClosedIssuesCount)
+	}
+	if got.ReviewsCount != 3 || got.ReviewsByState[types.ReviewStateApproved] != 2 {
+		t.Errorf("ReviewsCount = %d, approved = %d, want 3 and 2", got.ReviewsCount, got.ReviewsByState[types.ReviewStateApproved])
+	}
+}
+
+func TestMergeAuthorStats(t *testing.T) {
+	got := mergeAuthorStats(multiTestReports())
+
+	if len(got) != 2 {
+		t.Fatalf("mergeAuthorStats() returned %d authors, want 2", len(got))
+	}
+
+	alice := got[0]
+	if alice.Author.Login != "alice" {
+		t.Fatalf("first author = %s, want alice (most commits)", alice.Author.Login)
+	}
+	if alice.TotalCommits != 3 || alice.TotalAdded != 15 || alice.PRsCreated != 1 || alice.ReviewsCount != 1 {
+		t.Errorf("alice = %+v, want 3 commits, 15 added, 1 PR, 1 review", alice)
+	}
+	if alice.BranchActivity["api:main"].Commits != 1 || alice.BranchActivity["web:main"].Commits != 2 {
+		t.Errorf("alice branch activity = %v, want api:main and web:main entries", alice.BranchActivity)
+	}
+}
+
+func TestGenerateRepositoriesSection(t *testing.T) {
+	got := generateRepositoriesSection(multiTestReports())
+
+	for _, want := range []string{
+		"## 📦 Repositories",
+		"| [org/api](https://github.com/org/api) | 2 | 2 | 1 | 0 | 0 | 0 | 1 |",
+		"| [org/web](https://github.com/org/web) | 2 | 1 | 0 | 0 | 0 | 3 | 2 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generateRepositoriesSection() missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestGenerateMultiHTMLUniqueAnchors(t *testing.T) {
+	reports := multiTestReports()
+	data := &types.MultiReportData{
+		Repositories: []string{"org/api", "org/web"},
+		Reports:      reports,
+		AuthorStats:  mergeAuthorStats(reports),
+		OverallStats: mergeOverallStats(reports),
+	}
+
+	got := generateMultiHTML(data, &GenerationStats{})
+
+	for _, want := range []string{`id="repo-org-api"`, `id="repo-org-api-branch-main"`, `id="repo-org-web-branch-main"`, `id="repo-org-web-open-prs"`} {
+		if strings.Count(got, want) != 1 {
+			t.Errorf("generateMultiHTML() contains %q %d times, want once", want, strings.Count(got, want))
+		}
+	}
+}
+
+func TestGenerateRepositorySectionUser(t *testing.T) {
+	reports := multiTestReports()
+	for i := range reports {
+		reports[i].User = "alice"
+	}
+
+	got := generateRepositorySection(reports[0])
+	for _, want := range []string{"## 👤 Activity of alice", "### Reviews Given"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generateRepositorySection() missing %q\n%s", want, got)
+		}
+	}
+
+	data := &types.MultiReportData{
+		Repositories: []string{"org/api", "org/web"},
+		User:         "alice",
+		Reports:      reports,
+		AuthorStats:  mergeAuthorStats(reports),
+		OverallStats: mergeOverallStats(reports),
+	}
+	html := generateMultiHTML(data, &GenerationStats{})
+	for _, want := range []string{`id="repo-org-api-user-activity"`, `id="repo-org-web-user-activity"`, `href="#repo-org-web-branch-main"`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("generateMultiHTML() missing %q", want)
+		}
+	}
+}
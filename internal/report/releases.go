@@ -0,0 +1,190 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// prReferencePatterns match the pull request number in the first line of
+// squash-merged ("Title (#123)") and merge ("Merge pull request #123 from ...") commits
+var prReferencePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\(#(\d+)\)$`),
+	regexp.MustCompile(`^Merge pull request #(\d+)`),
+}
+
+// maxReleaseCommits is the number of commits listed per release in the report
+const maxReleaseCommits = 20
+
+// linkReleasePullRequests fills in the pull requests of each release from the
+// pull request numbers referenced by its commits
+func linkReleasePullRequests(data *types.ReportData) {
+	known := knownPullRequests(data)
+	for i := range data.Releases {
+		release := &data.Releases[i]
+		release.PullRequests = commitPullRequests(release.Commits, known, data.RepositoryURL)
+	}
+}
+
+// releaseWarnings reports the releases whose commits could not be listed
+// completely, for the report footer
+func releaseWarnings(releases []types.Release) []string {
+	var warnings []string
+	for _, release := range releases {
+		switch {
+		case release.CommitsUnavailable:
+			warnings = append(warnings, fmt.Sprintf("Commits of release %s could not be collected; it is listed without them", release.TagName))
+		case release.CommitsTruncated:
+			warnings = append(warnings, fmt.Sprintf("Release %s has too many commits since %s; only the first %d are included", release.TagName, release.PreviousTag, len(release.Commits)))
+		}
+	}
+	return warnings
+}
+
+// formatReleaseChanges describes the commits and pull requests of a release,
+// e.g. "12 commits, 3 pull requests"
+func formatReleaseChanges(release types.Release) string {
+	switch {
+	case release.CommitsUnavailable:
+		return "commits could not be collected"
+	case release.CommitsTruncated:
+		return fmt.Sprintf("more than %d commits, %d pull requests", len(release.Commits), len(release.PullRequests))
+	default:
+		return fmt.Sprintf("%d commits, %d pull requests", len(release.Commits), len(release.PullRequests))
+	}
+}
+
+// knownPullRequests indexes the pull requests of the report data by number
+func knownPullRequests(data *types.ReportData) map[int]types.PullRequest {
+	known := make(map[int]types.PullRequest)
+	for _, pr := range uniquePRs(data.OpenPRs, data.UpdatedPRs) {
+		known[pr.Number] = pr
+	}
+	return known
+}
+
+// commitPullRequests lists the pull requests referenced by commits, in commit
+// order. Pull requests that are not known get their title from the commit message.
+func commitPullRequests(commits []types.Commit, known map[int]types.PullRequest, repositoryURL string) []types.PullRequest {
+	var prs []types.PullRequest
+	seen := make(map[int]bool)
+	for _, commit := range commits {
+		number, ok := referencedPRNumber(commit.Message)
+		if !ok || seen[number] {
+			continue
+		}
+		seen[number] = true
+
+		pr, ok := known[number]
+		if !ok {
+			pr = types.PullRequest{
+				Number: number,
+				Title:  strings.TrimSpace(prReferenceSuffix.ReplaceAllString(firstLine(commit.Message), "")),
+				Author: commit.Author,
+				URL:    fmt.Sprintf("%s/pull/%d", repositoryURL, number),
+			}
+		}
+		prs = append(prs, pr)
+	}
+	return prs
+}
+
+// referencedPRNumber returns the pull request number a commit was merged from
+func referencedPRNumber(message string) (int, bool) {
+	line := firstLine(message)
+	for _, pattern := range prReferencePatterns {
+		if match := pattern.FindStringSubmatch(line); match != nil {
+			number, err := strconv.Atoi(match[1])
+			return number, err == nil
+		}
+	}
+	return 0, false
+}
+
+// formatReleaseTitle formats the heading of a release, e.g. "v1.2.0: Spring release"
+func formatReleaseTitle(release types.Release) string {
+	if release.Name == "" || release.Name == release.TagName {
+		return release.TagName
+	}
+	return release.TagName + ": " + release.Name
+}
+
+// generateReleasesSection generates the section listing releases and tags
+func generateReleasesSection(releases []types.Release) string {
+	var sb strings.Builder
+
+	sb.WriteString("## 🏷️ Releases\n\n")
+
+	if len(releases) == 0 {
+		sb.WriteString("No releases or tags were published during this period\n\n")
+		return sb.String()
+	}
+
+	for _, release := range releases {
+		sb.WriteString(generateReleaseSection(release))
+	}
+
+	return sb.String()
+}
+
+// generateReleaseSection generates the section of a single release or tag
+func generateReleaseSection(release types.Release) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("### [%s](%s)\n\n", formatReleaseTitle(release), release.URL))
+
+	if release.TagOnly {
+		sb.WriteString(fmt.Sprintf("- **Tagged**: %s (no release)\n", release.PublishedAt.Format("2006-01-02")))
+	} else {
+		published := release.PublishedAt.Format("2006-01-02")
+		if release.Author.Login != "" {
+			published += " by " + formatAuthorLink(release.Author.Login)
+		}
+		sb.WriteString(fmt.Sprintf("- **Published**: %s\n", published))
+	}
+	if release.Prerelease {
+		sb.WriteString("- **Pre-release**\n")
+	}
+	if release.PreviousTag != "" {
+		sb.WriteString(fmt.Sprintf("- **Changes since %s**: %s\n", release.PreviousTag, formatReleaseChanges(release)))
+	}
+	sb.WriteString("\n")
+
+	if release.AISummary != "" {
+		sb.WriteString(fmt.Sprintf("**Summary**: %s\n\n", release.AISummary))
+	}
+
+	// Release notes are quoted so their headings don't break the report structure
+	if notes := strings.TrimSpace(release.Body); notes != "" {
+		sb.WriteString("**Release Notes**:\n\n")
+		for _, line := range strings.Split(strings.ReplaceAll(notes, "\r\n", "\n"), "\n") {
+			sb.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(release.PullRequests) > 0 {
+		sb.WriteString("**Pull Requests**:\n")
+		for _, pr := range release.PullRequests {
+			sb.WriteString(fmt.Sprintf("- [#%d: %s](%s) by %s\n", pr.Number, pr.Title, pr.URL, formatAuthorLink(pr.Author.Login)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(release.Commits) > 0 {
+		sb.WriteString("**Commits**:\n")
+		for i, commit := range release.Commits {
+			if i == maxReleaseCommits {
+				sb.WriteString(fmt.Sprintf("- ... and %d more commits\n", len(release.Commits)-maxReleaseCommits))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("- [`%s`](%s) %s by %s\n", shortSHA(commit.SHA), commit.URL, firstLine(commit.Message), formatAuthorLink(commit.Author.Login)))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,139 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+func TestReferencedPRNumber(t *testing.T) {
+	tests := []struct {
+		message string
+		want    int
+		wantOK  bool
+	}{
+		{message: "Add search (#12)\n\nDetails", want: 12, wantOK: true},
+		{message: "Merge pull request #34 from alice/feature", want: 34, wantOK: true},
+		{message: "Fix #56 in parser", wantOK: false},
+		{message: "Update deps", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := referencedPRNumber(tt.message)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("referencedPRNumber(%q) = %d, %v, want %d, %v", tt.message, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestLinkReleasePullRequests(t *testing.T) {
+	data := &types.ReportData{
+		RepositoryURL: "https://github.com/o/r",
+		UpdatedPRs: []types.PullRequest{
+			{Number: 12, Title: "Add search", Author: types.Author{Login: "alice"}, URL: "https://github.com/o/r/pull/12"},
+		},
+		Releases: []types.Release{{
+			TagName: "v1.2.0",
+			Commits: []types.Commit{
+				{SHA: "c1", Message: "Add search (#12)"},
+				{SHA: "c2", Message: "Fix search typo (#12)"},
+				{SHA: "c3", Message: "Old fix (#7)", Author: types.Author{Login: "bob"}},
+				{SHA: "c4", Message: "Direct push"},
+			},
+		}},
+	}
+
+	linkReleasePullRequests(data)
+
+	prs := data.Releases[0].PullRequests
+	if len(prs) != 2 {
+		t.Fatalf("got %d pull requests, want 2", len(prs))
+	}
+	if prs[0].Number != 12 || prs[0].Title != "Add search" {
+		t.Errorf("got %+v, want PR #12 from the report data", prs[0])
+	}
+	if prs[1].Number != 7 || prs[1].Title != "Old fix" || prs[1].Author.Login != "bob" || prs[1].URL != "https://github.com/o/r/pull/7" {
+		t.Errorf("got %+v, want PR #7 built from its commit", prs[1])
+	}
+}
+
+func TestReleaseWarnings(t *testing.T) {
+	releases := []types.Release{
+		{TagName: "v3.0.0", PreviousTag: "v2.0.0", Commits: make([]types.Commit, 1000), CommitsTruncated: true},
+		{TagName: "v2.0.0", PreviousTag: "v1.0.0", CommitsUnavailable: true},
+		{TagName: "v1.0.0", Commits: []types.Commit{{SHA: "c1"}}},
+	}
+
+	warnings := releaseWarnings(releases)
+
+	if len(warnings) != 2 {
+		t.Fatalf("got warnings %q, want 2", warnings)
+	}
+	if !strings.Contains(warnings[0], "v3.0.0") || !strings.Contains(warnings[0], "first 1000") {
+		t.Errorf("got warning %q, want the truncated release v3.0.0", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "v2.0.0") || !strings.Contains(warnings[1], "could not be collected") {
+		t.Errorf("got warning %q, want the release v2.0.0 without commits", warnings[1])
+	}
+
+	if got := formatReleaseChanges(releases[1]); got != "commits could not be collected" {
+		t.Errorf("formatReleaseChanges() = %q for a release without commits", got)
+	}
+	if got := formatReleaseChanges(releases[0]); got != "more than 1000 commits, 0 pull requests" {
+		t.Errorf("formatReleaseChanges() = %q for a truncated release", got)
+	}
+}
+
+func TestGenerateReleasesSection(t *testing.T) {
+	empty := generateReleasesSection(nil)
+	if !strings.Contains(empty, "No releases or tags were published during this period") {
+		t.Errorf("expected empty message, got:\n%s", empty)
+	}
+
+	published := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	releases := []types.Release{
+		{
+			TagName:     "v1.2.0",
+			Name:        "Spring release",
+			Body:        "## Highlights\n\n- Search",
+			Author:      types.Author{Login: "alice"},
+			PublishedAt: published,
+			URL:         "https://github.com/o/r/releases/tag/v1.2.0",
+			PreviousTag: "v1.1.0",
+			Commits: []types.Commit{
+				{SHA: "c1c1c1c1c1", Message: "Add search (#12)", Author: types.Author{Login: "alice"}, URL: "https://github.com/o/r/commit/c1"},
+			},
+			PullRequests: []types.PullRequest{
+				{Number: 12, Title: "Add search", Author: types.Author{Login: "alice"}, URL: "https://github.com/o/r/pull/12"},
+			},
+			AISummary: "Adds search.",
+		},
+		{
+			TagName:     "v1.1.0",
+			PublishedAt: published.AddDate(0, 0, -5),
+			TagOnly:     true,
+			URL:         "https://github.com/o/r/releases/tag/v1.1.0",
+		},
+	}
+
+	got := generateReleasesSection(releases)
+
+	for _, want := range []string{
+		"## 🏷️ Releases",
+		"### [v1.2.0: Spring release](https://github.com/o/r/releases/tag/v1.2.0)",
+		"- **Published**: 2025-01-10 by [alice](https://github.com/alice)",
+		"- **Changes since v1.1.0**: 1 commits, 1 pull requests",
+		"**Summary**: Adds search.",
+		"**Release Notes**:\n\n> ## Highlights\n>\n> - Search\n",
+		"- [#12: Add search](https://github.com/o/r/pull/12) by [alice](https://github.com/alice)",
+		"- [`c1c1c1c`](https://github.com/o/r/commit/c1) Add search (#12) by [alice](https://github.com/alice)",
+		"### [v1.1.0](https://github.com/o/r/releases/tag/v1.1.0)",
+		"- **Tagged**: 2025-01-05 (no release)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected section to contain %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// Renderer converts collected report data into the final report text
+type Renderer interface {
+	Render(data *types.ReportData, stats *GenerationStats) (string, error)
+}
+
+// MultiRenderer converts the data of a multi-repository report into the final report text.
+// All built-in renderers implement it.
+type MultiRenderer interface {
+	RenderMulti(data *types.MultiReportData, stats *GenerationStats) (string, error)
+}
+
+// jsonRenderer renders the report as a versioned JSON document
+type jsonRenderer struct{}
+
+// Render implements Renderer
+func (r *jsonRenderer) Render(data *types.ReportData, stats *GenerationStats) (string, error) {
+	return generateJSON(data, stats)
+}
+
+// RenderMulti implements MultiRenderer
+func (r *jsonRenderer) RenderMulti(data *types.MultiReportData, stats *GenerationStats) (string, error) {
+	return generateMultiJSON(data, stats)
+}
+
+// htmlRenderer renders the report as a self-contained HTML page
+type htmlRenderer struct{}
+
+// Render implements Renderer
+func (r *htmlRenderer) Render(data *types.ReportData, stats *GenerationStats) (string, error) {
+	return generateHTML(data, stats), nil
+}
+
+// RenderMulti implements MultiRenderer
+func (r *htmlRenderer) RenderMulti(data *types.MultiReportData, stats *GenerationStats) (string, error) {
+	return generateMultiHTML(data, stats), nil
+}
+
+// NewRenderer returns the built-in renderer for the given output format.
+// An empty format selects the default Markdown template.
+func NewRenderer(format string) (Renderer, error) {
+	switch format {
+	case "", FormatMarkdown:
+		return NewDefaultTemplateRenderer()
+	case FormatJSON:
+		return &jsonRenderer{}, nil
+	case FormatHTML:
+		return &htmlRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+}
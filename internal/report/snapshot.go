@@ -0,0 +1,59 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// SnapshotStore stores generated reports to follow activity over longer time spans
+type SnapshotStore interface {
+	Save(snapshot *types.Snapshot) error
+}
+
+// SetSnapshotStore enables storing a snapshot of every generated report
+func (g *Generator) SetSnapshotStore(store SnapshotStore) {
+	g.snapshots = store
+}
+
+// saveSnapshot stores data in the snapshot store, if one is set. Partial
+// reports of interrupted runs are not stored, as they would distort trends.
+// A failure only costs the snapshot, so it is logged instead of returned.
+func (g *Generator) saveSnapshot(ctx context.Context, data *types.ReportData) {
+	if g.snapshots == nil || ctx.Err() != nil {
+		return
+	}
+
+	if err := g.snapshots.Save(newSnapshot(data)); err != nil {
+		g.logger.Warning(fmt.Sprintf("Failed to store the report snapshot of %s: %v", data.Repository, err))
+	}
+}
+
+// newSnapshot creates the snapshot of a report
+func newSnapshot(data *types.ReportData) *types.Snapshot {
+	contributorSet := make(map[string]bool)
+	for _, branch := range data.Branches {
+		for _, commit := range branch.Commits {
+			contributorSet[commit.Author.Login] = true
+		}
+	}
+
+	contributors := make([]string, 0, len(contributorSet))
+	for login := range contributorSet {
+		contributors = append(contributors, login)
+	}
+	sort.Strings(contributors)
+
+	return &types.Snapshot{
+		Repository:   data.Repository,
+		Period:       data.Period,
+		User:         data.User,
+		SavedAt:      time.Now().UTC(),
+		Stats:        calculatePeriodStats(data, data.Period),
+		Contributors: contributors,
+		Report:       data,
+	}
+}
@@ -0,0 +1,204 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// defaultTemplate is the built-in Markdown report layout
+//
+//go:embed templates/default.md.tmpl
+var defaultTemplate string
+
+// multiTemplate is the built-in Markdown layout of multi-repository reports
+//
+//go:embed templates/multi.md.tmpl
+var multiTemplate string
+
+// TemplateData is the value passed to report templates.
+// All ReportData fields are available directly (e.g. {{ .Repository }}).
+type TemplateData struct {
+	*types.ReportData
+	// Stats holds statistics about the report generation process
+	Stats *GenerationStats
+}
+
+// MultiTemplateData is the value passed to the built-in multi-repository template.
+// All MultiReportData fields are available directly (e.g. {{ .Repositories }}).
+type MultiTemplateData struct {
+	*types.MultiReportData
+	// Stats holds statistics about the report generation process
+	Stats *GenerationStats
+}
+
+// TemplateRenderer renders reports through a text/template
+type TemplateRenderer struct {
+	tmpl *template.Template
+	// multi renders multi-repository reports; user-supplied templates have
+	// none, as they are written against single-repository TemplateData
+	multi *template.Template
+}
+
+// NewTemplateRenderer parses a template from text
+func NewTemplateRenderer(name, text string) (*TemplateRenderer, error) {
+	tmpl, err := template.New(name).Funcs(TemplateFuncs()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	return &TemplateRenderer{tmpl: tmpl}, nil
+}
+
+// NewTemplateRendererFromFile parses a user-supplied template file
+func NewTemplateRendererFromFile(path string) (*TemplateRenderer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file %s: %w", path, err)
+	}
+
+	return NewTemplateRenderer(filepath.Base(path), string(data))
+}
+
+// NewDefaultTemplateRenderer returns a renderer for the built-in Markdown layout
+func NewDefaultTemplateRenderer() (*TemplateRenderer, error) {
+	renderer, err := NewTemplateRenderer("default.md.tmpl", defaultTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	multi, err := NewTemplateRenderer("multi.md.tmpl", multiTemplate)
+	if err != nil {
+		return nil, err
+	}
+	renderer.multi = multi.tmpl
+
+	return renderer, nil
+}
+
+// DefaultTemplate returns the source of the built-in Markdown template,
+// which can be used as a starting point for custom layouts
+func DefaultTemplate() string {
+	return defaultTemplate
+}
+
+// Render implements Renderer
+func (r *TemplateRenderer) Render(data *types.ReportData, stats *GenerationStats) (string, error) {
+	var sb strings.Builder
+
+	if err := r.tmpl.Execute(&sb, TemplateData{ReportData: data, Stats: stats}); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return sb.String(), nil
+}
+
+// RenderMulti implements MultiRenderer
+func (r *TemplateRenderer) RenderMulti(data *types.MultiReportData, stats *GenerationStats) (string, error) {
+	if !r.SupportsMulti() {
+		return "", fmt.Errorf("template %s only renders single-repository reports", r.tmpl.Name())
+	}
+
+	var sb strings.Builder
+
+	if err := r.multi.Execute(&sb, MultiTemplateData{MultiReportData: data, Stats: stats}); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return sb.String(), nil
+}
+
+// SupportsMulti reports whether the renderer has a multi-repository layout
+func (r *TemplateRenderer) SupportsMulti() bool {
+	return r.multi != nil
+}
+
+// TemplateFuncs returns helper functions available in report templates.
+// Besides formatting helpers, every built-in Markdown section is exposed
+// so custom templates can reorder or omit them.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		// Formatting helpers
+		"authorLink":  formatAuthorLink,
+		"authorLinks": formatAuthorLinks,
+		"formatDate":  formatDate,
+		"formatDay":   formatDay,
+		"truncate":    truncate,
+		"firstLine":   firstLine,
+		"shortSHA":    shortSHA,
+		"join":        strings.Join,
+		"repoName":    repoDisplayName,
+
+		// Built-in Markdown sections
+		"header":              generateHeader,
+		"summaryStats":        generateSummaryStats,
+		"comparisonSection":   generateComparisonSection,
+		"userSection":         generateUserSection,
+		"branchSection":       generateBranchSection,
+		"branchesSection":     generateBranchesSection,
+		"prSection":           generatePRSection,
+		"openPRsSection":      generateOpenPRsSection,
+		"mergedPRsSection":    generateMergedPRsSection,
+		"releasesSection":     generateReleasesSection,
+		"releaseSection":      generateReleaseSection,
+		"deploymentsSection":  generateDeploymentsSection,
+		"updatedPRsSection":   generateUpdatedPRsSection,
+		"issueSection":        generateIssueSection,
+		"openIssuesSection":   generateOpenIssuesSection,
+		"closedIssuesSection": generateClosedIssuesSection,
+		"codeReviewsSection":  generateCodeReviewsSection,
+		"cycleTimeSection":    generateCycleTimeSection,
+		"deliverySection":     generateDeliverySection,
+		"ciSection":           generateCISection,
+		"hygieneSection":      generateHygieneSection,
+		"hotspotsSection":     generateHotspotsSection,
+		"authorSection":       generateAuthorSection,
+		"authorStatsSection":  generateAuthorStatsSection,
+		"multiHeader":         generateMultiHeader,
+		"repositoriesSection": generateRepositoriesSection,
+		"repositorySection":   generateRepositorySection,
+		"footer":              generateFooter,
+	}
+}
+
+// formatAuthorLink formats a single author login as a markdown link
+func formatAuthorLink(login string) string {
+	return formatAuthorLinks([]string{login})
+}
+
+// formatDay formats a time.Time as a date without time
+func formatDay(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// truncate shortens a string to at most length characters, adding an ellipsis if needed
+func truncate(length int, s string) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+	if length <= 3 {
+		return string(runes[:length])
+	}
+	return string(runes[:length-3]) + "..."
+}
+
+// firstLine returns the first line of a multi-line string
+func firstLine(s string) string {
+	return strings.SplitN(s, "\n", 2)[0]
+}
+
+// repoDisplayName returns the repository name without the owner part
+func repoDisplayName(repository string) string {
+	parts := strings.Split(repository, "/")
+	if len(parts) == 2 {
+		return parts[1]
+	}
+	return repository
+}
@@ -0,0 +1,194 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazadus/gh-repomon/internal/types"
+)
+
+// sampleTemplateData returns report data used by template tests
+func sampleTemplateData() *types.ReportData {
+	author := types.Author{Login: "alice", ProfileURL: "https://github.com/alice"}
+
+	return &types.ReportData{
+		Repository:    "owner/repo",
+		RepositoryURL: "https://github.com/owner/repo",
+		Period: types.Period{
+			From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+		},
+		GeneratedAt: time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC),
+		Branches: []types.Branch{
+			{
+				Name:    "main",
+				Commits: []types.Commit{{SHA: "abc1234567", Message: "Fix bug\n\nDetails", Author: author}},
+				Authors: []string{"alice"},
+			},
+		},
+		OpenPRs:      []types.PullRequest{{Number: 1, Title: "Add feature", Author: author, Reviews: 1}},
+		OpenIssues:   []types.Issue{{Number: 2, Title: "Bug", Author: author}},
+		AuthorStats:  []types.AuthorStats{{Author: author, TotalCommits: 1}},
+		OverallStats: types.OverallStats{TotalCommits: 1, TotalAuthors: 1},
+		AISummary:    "Overall summary",
+	}
+}
+
+func TestDefaultTemplateMatchesSections(t *testing.T) {
+	data := sampleTemplateData()
+	stats := &GenerationStats{TotalAISummaries: 1, SuccessfulSummaries: 1}
+
+	renderer, err := NewDefaultTemplateRenderer()
+	if err != nil {
+		t.Fatalf("NewDefaultTemplateRenderer() error = %v", err)
+	}
+
+	got, err := renderer.Render(data, stats)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	// The default template must produce the same layout as concatenating the built-in sections
+	var sb strings.Builder
+	sb.WriteString(generateHeader(data))
+	sb.WriteString(generateSummaryStats(data.OverallStats))
+	sb.WriteString(generateComparisonSection(data.Comparison))
+	sb.WriteString(generateUserSection(data))
+	sb.WriteString("## 📊 Overall Summary\n\n")
+	sb.WriteString(data.AISummary)
+	sb.WriteString("\n\n")
+	sb.WriteString(generateBranchesSection(data.Branches))
+	sb.WriteString(generateOpenPRsSection(data.OpenPRs))
+	sb.WriteString(generateMergedPRsSection(data))
+	sb.WriteString(generateReleasesSection(data.Releases))
+	sb.WriteString(generateDeploymentsSection(data))
+	sb.WriteString(generateUpdatedPRsSection(data.UpdatedPRs))
+	sb.WriteString(generateOpenIssuesSection(data.OpenIssues))
+	sb.WriteString(generateClosedIssuesSection(data.ClosedIssues))
+	sb.WriteString(generateCodeReviewsSection(data))
+	sb.WriteString(generateCycleTimeSection(data.CycleTime))
+	sb.WriteString(generateDeliverySection(data.Delivery))
+	sb.WriteString(generateCISection(data.CI))
+	sb.WriteString(generateHygieneSection(data))
+	sb.WriteString(generateHotspotsSection(data.Hotspots))
+	sb.WriteString(generateAuthorStatsSection(data.AuthorStats))
+	sb.WriteString(generateFooter(stats))
+
+	if got != sb.String() {
+		t.Errorf("default template output differs from built-in sections\ngot:\n%s\nwant:\n%s", got, sb.String())
+	}
+}
+
+func TestTemplateRendererFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "custom.tmpl")
+	content := `{{ repoName .Repository }}: {{ .OverallStats.TotalCommits }} commits
+{{ range .Branches }}{{ .Name }} by {{ authorLinks .Authors }}
+{{ range .Commits }}- {{ shortSHA .SHA }} {{ firstLine .Message | truncate 5 }} ({{ formatDay .Date }})
+{{ end }}{{ end }}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	renderer, err := NewTemplateRendererFromFile(path)
+	if err != nil {
+		t.Fatalf("NewTemplateRendererFromFile() error = %v", err)
+	}
+
+	got, err := renderer.Render(sampleTemplateData(), nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := "repo: 1 commits\nmain by [alice](https://github.com/alice)\n- abc1234 Fi... (0001-01-01)\n"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateRendererErrors(t *testing.T) {
+	if _, err := NewTemplateRendererFromFile(filepath.Join(t.TempDir(), "missing.tmpl")); err == nil {
+		t.Error("NewTemplateRendererFromFile() expected error for missing file")
+	}
+
+	if _, err := NewTemplateRenderer("broken", "{{ .Repository "); err == nil {
+		t.Error("NewTemplateRenderer() expected error for invalid template")
+	}
+
+	renderer, err := NewTemplateRenderer("unknown", "{{ .DoesNotExist }}")
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() error = %v", err)
+	}
+	if _, err := renderer.Render(sampleTemplateData(), nil); err == nil {
+		t.Error("Render() expected error for unknown field")
+	}
+}
+
+func TestTemplateRendererRenderMulti(t *testing.T) {
+	data := &types.MultiReportData{Repositories: []string{"org/api", "org/web"}, Reports: multiTestReports()}
+
+	// User templates are written for a single repository
+	custom, err := NewTemplateRenderer("custom", "{{ .Repository }}")
+	if err != nil {
+		t.Fatalf("NewTemplateRenderer() error = %v", err)
+	}
+	if custom.SupportsMulti() {
+		t.Error("SupportsMulti() = true for a user template, want false")
+	}
+	if _, err := custom.RenderMulti(data, &GenerationStats{}); err == nil || !strings.Contains(err.Error(), "single-repository") {
+		t.Errorf("RenderMulti() error = %v, want a single-repository error", err)
+	}
+	if _, err := (&Generator{}).selectMultiRenderer(Options{Template: writeTemplate(t, "{{ .Repository }}")}); err == nil {
+		t.Error("selectMultiRenderer() expected error for a user template")
+	}
+
+	builtin, err := NewDefaultTemplateRenderer()
+	if err != nil {
+		t.Fatalf("NewDefaultTemplateRenderer() error = %v", err)
+	}
+	got, err := builtin.RenderMulti(data, &GenerationStats{})
+	if err != nil {
+		t.Fatalf("RenderMulti() error = %v", err)
+	}
+	if !strings.Contains(got, "org/web") {
+		t.Errorf("RenderMulti() missing repository org/web, got:\n%s", got)
+	}
+}
+
+// writeTemplate writes a template file and returns its path
+func writeTemplate(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "custom.tmpl")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+	return path
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		input  string
+		want   string
+	}{
+		{name: "Short string", length: 10, input: "hello", want: "hello"},
+		{name: "Exact length", length: 5, input: "hello", want: "hello"},
+		{name: "Long string", length: 8, input: "hello world", want: "hello..."},
+		{name: "Unicode string", length: 4, input: "привет", want: "п..."},
+		{name: "Tiny length", length: 2, input: "hello", want: "he"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncate(tt.length, tt.input)
+			if got != tt.want {
+				t.Errorf("truncate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
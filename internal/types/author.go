@@ -3,13 +3,13 @@ package types
 // Author represents a GitHub user who contributed to the repository.
 type Author struct {
 	// Login is the GitHub username
-	Login string
+	Login string `json:"login"`
 	// Name is the full name of the user (may be empty)
-	Name string
+	Name string `json:"name"`
 	// ProfileURL is the link to the GitHub profile
-	ProfileURL string
+	ProfileURL string `json:"profile_url"`
 	// IsBot indicates whether this author is a bot account
-	IsBot bool
+	IsBot bool `json:"is_bot"`
 }
 
 // NewAuthor creates a new Author instance.
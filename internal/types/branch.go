@@ -1,19 +1,45 @@
 package types
 
+import "time"
+
 // Branch represents a branch with its activity.
 type Branch struct {
 	// Name is the branch name
-	Name string
-	// Commits is the list of commits in this branch during the period
-	Commits []Commit
+	Name string `json:"name"`
+	// Commits is the list of commits in this branch during the period; for
+	// branches compared with BaseBranch only the commits that are not on it
+	Commits []Commit `json:"commits"`
+	// IntroducedCommits is the number of commits attributed to this branch; the
+	// other commits are also on the default branch or the branch this one was created from
+	IntroducedCommits int `json:"introduced_commits"`
 	// PRs is the list of pull requests associated with this branch
-	PRs []PullRequest
+	PRs []PullRequest `json:"prs"`
 	// TotalAdded is the total number of lines added across all commits
-	TotalAdded int
+	TotalAdded int `json:"total_added"`
 	// TotalDeleted is the total number of lines deleted across all commits
-	TotalDeleted int
+	TotalDeleted int `json:"total_deleted"`
 	// Authors is the list of unique author logins who contributed to this branch
-	Authors []string
+	Authors []string `json:"authors"`
+	// BaseBranch is the default branch this branch was compared with (empty if not compared)
+	BaseBranch string `json:"base_branch,omitempty"`
+	// Ahead is the number of commits on this branch that are not on BaseBranch
+	Ahead int `json:"ahead"`
+	// Behind is the number of commits on BaseBranch that are not on this branch
+	Behind int `json:"behind"`
+	// LastActivity is the date of the latest commit on this branch
+	LastActivity time.Time `json:"last_activity"`
 	// AISummary is the AI-generated summary of branch activity
-	AISummary string
+	AISummary string `json:"ai_summary"`
+}
+
+// BranchComparison compares a branch with a base branch.
+type BranchComparison struct {
+	// Ahead is the number of commits on the branch that are not on the base branch
+	Ahead int `json:"ahead"`
+	// Behind is the number of commits on the base branch that are not on the branch
+	Behind int `json:"behind"`
+	// Commits lists the SHAs of the commits on the branch that are not on the base branch
+	Commits []string `json:"commits"`
+	// Truncated is true when Commits does not list all Ahead commits
+	Truncated bool `json:"truncated"`
 }
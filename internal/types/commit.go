@@ -5,17 +5,31 @@ import "time"
 // Commit represents a single commit in the repository.
 type Commit struct {
 	// SHA is the unique identifier of the commit
-	SHA string
+	SHA string `json:"sha"`
 	// Message is the commit message
-	Message string
+	Message string `json:"message"`
 	// Author is the author of the commit
-	Author Author
+	Author Author `json:"author"`
 	// Date is when the commit was created
-	Date time.Time
+	Date time.Time `json:"date"`
 	// Additions is the number of lines added in this commit
-	Additions int
+	Additions int `json:"additions"`
 	// Deletions is the number of lines deleted in this commit
-	Deletions int
+	Deletions int `json:"deletions"`
+	// Files lists the changed files with their line statistics (empty if unknown)
+	Files []FileChange `json:"files,omitempty"`
 	// URL is the link to the commit on GitHub
-	URL string
+	URL string `json:"url"`
+	// IntroducedOn is the branch the commit is attributed to when it is on several branches
+	IntroducedOn string `json:"introduced_on,omitempty"`
+}
+
+// FileChange represents the changes of a commit to a single file.
+type FileChange struct {
+	// Path is the path of the file after the commit
+	Path string `json:"path"`
+	// Additions is the number of lines added to the file
+	Additions int `json:"additions"`
+	// Deletions is the number of lines deleted from the file
+	Deletions int `json:"deletions"`
 }
@@ -0,0 +1,62 @@
+package types
+
+// PeriodStats holds the activity counted over one period for comparison.
+type PeriodStats struct {
+	// Commits is the number of commits in the period
+	Commits int `json:"commits"`
+	// Authors is the number of unique commit authors
+	Authors int `json:"authors"`
+	// PRsOpened is the number of pull requests created in the period
+	PRsOpened int `json:"prs_opened"`
+	// PRsMerged is the number of pull requests merged in the period
+	PRsMerged int `json:"prs_merged"`
+	// IssuesClosed is the number of issues closed in the period
+	IssuesClosed int `json:"issues_closed"`
+	// LinesAdded is the number of lines added by the commits
+	LinesAdded int `json:"lines_added"`
+	// LinesDeleted is the number of lines deleted by the commits
+	LinesDeleted int `json:"lines_deleted"`
+}
+
+// PeriodComparison compares the report period with the preceding period of equal length.
+type PeriodComparison struct {
+	// PreviousPeriod is the period the report is compared with
+	PreviousPeriod Period `json:"previous_period"`
+	// Current is the activity in the report period
+	Current PeriodStats `json:"current"`
+	// Previous is the activity in the previous period
+	Previous PeriodStats `json:"previous"`
+}
+
+// ComparisonMetric is a single compared value.
+type ComparisonMetric struct {
+	// Name is the display name of the metric
+	Name string
+	// Current is the value in the report period
+	Current int
+	// Previous is the value in the previous period
+	Previous int
+}
+
+// Metrics returns the compared metrics in display order.
+func (c *PeriodComparison) Metrics() []ComparisonMetric {
+	return []ComparisonMetric{
+		{Name: "Commits", Current: c.Current.Commits, Previous: c.Previous.Commits},
+		{Name: "Authors", Current: c.Current.Authors, Previous: c.Previous.Authors},
+		{Name: "PRs Opened", Current: c.Current.PRsOpened, Previous: c.Previous.PRsOpened},
+		{Name: "PRs Merged", Current: c.Current.PRsMerged, Previous: c.Previous.PRsMerged},
+		{Name: "Issues Closed", Current: c.Current.IssuesClosed, Previous: c.Previous.IssuesClosed},
+		{Name: "Lines Added", Current: c.Current.LinesAdded, Previous: c.Previous.LinesAdded},
+		{Name: "Lines Deleted", Current: c.Current.LinesDeleted, Previous: c.Previous.LinesDeleted},
+	}
+}
+
+// Change returns the difference between the periods and the relative change in
+// percent. The percentage is only meaningful when ok is true (previous value not zero).
+func (m ComparisonMetric) Change() (diff int, percent float64, ok bool) {
+	diff = m.Current - m.Previous
+	if m.Previous == 0 {
+		return diff, 0, false
+	}
+	return diff, float64(diff) * 100 / float64(m.Previous), true
+}
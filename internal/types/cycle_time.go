@@ -0,0 +1,63 @@
+package types
+
+// PRCycleTime holds the review and merge timings of a single pull request.
+// Durations are in hours; a nil value means the event did not happen in the period.
+type PRCycleTime struct {
+	// Number is the PR number
+	Number int `json:"number"`
+	// Title is the PR title
+	Title string `json:"title"`
+	// URL is the link to the PR on GitHub
+	URL string `json:"url"`
+	// Author is the login of the PR author
+	Author string `json:"author"`
+	// TimeToFirstReview is the time from creation to the first review by someone else
+	TimeToFirstReview *float64 `json:"time_to_first_review_hours,omitempty"`
+	// TimeToApproval is the time from creation to the first approval
+	TimeToApproval *float64 `json:"time_to_approval_hours,omitempty"`
+	// TimeToMerge is the time from creation to merge
+	TimeToMerge *float64 `json:"time_to_merge_hours,omitempty"`
+	// ReviewRounds is the number of review rounds: one per review requesting
+	// changes, plus one for the final round (0 if the PR was not reviewed)
+	ReviewRounds int `json:"review_rounds"`
+}
+
+// DurationStats summarizes a set of durations in hours.
+type DurationStats struct {
+	// Count is the number of measured pull requests
+	Count int `json:"count"`
+	// Median is the median duration in hours
+	Median float64 `json:"median_hours"`
+	// P90 is the 90th percentile duration in hours
+	P90 float64 `json:"p90_hours"`
+}
+
+// CycleTimeStats aggregates the cycle times of a group of pull requests.
+type CycleTimeStats struct {
+	// Author is the login of the PR author (empty for repository-wide statistics)
+	Author string `json:"author,omitempty"`
+	// PRs is the number of pull requests with at least one measured event
+	PRs int `json:"prs"`
+	// TimeToFirstReview summarizes the time to the first review
+	TimeToFirstReview DurationStats `json:"time_to_first_review"`
+	// TimeToApproval summarizes the time to the first approval
+	TimeToApproval DurationStats `json:"time_to_approval"`
+	// TimeToMerge summarizes the time to merge
+	TimeToMerge DurationStats `json:"time_to_merge"`
+	// ReviewedPRs is the number of pull requests with at least one review
+	ReviewedPRs int `json:"reviewed_prs"`
+	// MedianReviewRounds is the median number of review rounds of reviewed PRs
+	MedianReviewRounds float64 `json:"median_review_rounds"`
+	// P90ReviewRounds is the 90th percentile of review rounds of reviewed PRs
+	P90ReviewRounds float64 `json:"p90_review_rounds"`
+}
+
+// CycleTimeAnalytics holds the pull request cycle-time and review-latency analytics.
+type CycleTimeAnalytics struct {
+	// PullRequests lists the timings of each pull request with a measured event
+	PullRequests []PRCycleTime `json:"pull_requests"`
+	// Overall is the repository-wide summary
+	Overall CycleTimeStats `json:"overall"`
+	// Authors is the summary per PR author, ordered by number of PRs
+	Authors []CycleTimeStats `json:"authors"`
+}
@@ -0,0 +1,62 @@
+package types
+
+import "time"
+
+// Deployment sources of delivery metrics
+const (
+	// DeploymentSourceMergedPRs counts each pull request merged during the period as a deployment
+	DeploymentSourceMergedPRs = "merged_pull_requests"
+	// DeploymentSourceReleases counts each release or tag published during the period as a deployment
+	DeploymentSourceReleases = "releases"
+	// DeploymentSourceDeployments counts each successful production deployment created during the period
+	DeploymentSourceDeployments = "deployments"
+)
+
+// Change failure kinds
+const (
+	// FailureKindRevert is a change that reverted an earlier change
+	FailureKindRevert = "revert"
+	// FailureKindIncident is an issue labeled as an incident
+	FailureKindIncident = "incident"
+)
+
+// ChangeFailure is a change that failed in production: a revert or an incident.
+type ChangeFailure struct {
+	// Kind is the failure kind (revert or incident)
+	Kind string `json:"kind"`
+	// Number is the number of the revert PR or incident issue (0 for a revert commit)
+	Number int `json:"number,omitempty"`
+	// SHA is the revert commit SHA (empty for pull requests and issues)
+	SHA string `json:"sha,omitempty"`
+	// Title is the PR or issue title, or the first line of the commit message
+	Title string `json:"title"`
+	// URL is the link to the failure on GitHub
+	URL string `json:"url"`
+	// At is when the failure was recorded (revert merged or incident opened)
+	At time.Time `json:"at"`
+	// RestoredAt is when the incident was closed (nil for reverts and open incidents)
+	RestoredAt *time.Time `json:"restored_at,omitempty"`
+}
+
+// DeliveryMetrics holds DORA-style software delivery metrics of the period.
+type DeliveryMetrics struct {
+	// DeploymentSource describes what counts as a deployment
+	DeploymentSource string `json:"deployment_source"`
+	// Deployments is the number of deployments during the period
+	Deployments int `json:"deployments"`
+	// DeploymentsPerWeek is the average number of deployments per week
+	DeploymentsPerWeek float64 `json:"deployments_per_week"`
+	// LeadTime summarizes the time from the first commit of a change to its deployment
+	// (for releases, from each included commit to the release)
+	LeadTime DurationStats `json:"lead_time"`
+	// FailedChanges is the number of reverts and incidents opened during the period
+	FailedChanges int `json:"failed_changes"`
+	// ChangeFailureRate is the percentage of deployments that failed (0-100)
+	ChangeFailureRate float64 `json:"change_failure_rate"`
+	// TimeToRestore summarizes the time from opening to closing incidents closed during the period
+	TimeToRestore DurationStats `json:"time_to_restore"`
+	// Failures lists the reverts and incidents counted as failed changes
+	Failures []ChangeFailure `json:"failures"`
+	// IncidentLabels lists the issue labels that mark incidents
+	IncidentLabels []string `json:"incident_labels"`
+}
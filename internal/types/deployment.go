@@ -0,0 +1,60 @@
+package types
+
+import "time"
+
+// Deployment states, from the latest deployment status
+const (
+	// DeploymentStateSuccess means the deployment succeeded
+	DeploymentStateSuccess = "success"
+	// DeploymentStateInactive means the deployment succeeded and was replaced by a later one
+	DeploymentStateInactive = "inactive"
+	// DeploymentStateFailure means the deployment failed
+	DeploymentStateFailure = "failure"
+	// DeploymentStateError means the deployment could not be carried out
+	DeploymentStateError = "error"
+)
+
+// Deployment represents a GitHub deployment to an environment.
+type Deployment struct {
+	// ID is the deployment ID
+	ID int64 `json:"id"`
+	// Environment is the environment deployed to (e.g. staging, production)
+	Environment string `json:"environment"`
+	// Production is true for deployments to a production environment
+	Production bool `json:"production"`
+	// Ref is the branch, tag or SHA that was deployed
+	Ref string `json:"ref"`
+	// SHA is the deployed commit
+	SHA string `json:"sha"`
+	// Description is the optional deployment description
+	Description string `json:"description,omitempty"`
+	// Creator is the user or app that created the deployment
+	Creator Author `json:"creator"`
+	// CreatedAt is when the deployment was created
+	CreatedAt time.Time `json:"created_at"`
+	// State is the state of the latest deployment status (empty without statuses)
+	State string `json:"state"`
+	// StateAt is when the latest deployment status was reported
+	StateAt *time.Time `json:"state_at,omitempty"`
+	// URL is the link to the deployment log or the deployed environment
+	URL string `json:"url,omitempty"`
+	// PreviousSHA is the commit of the preceding deployment to the same environment
+	PreviousSHA string `json:"previous_sha,omitempty"`
+	// RolledBack is true when the deployment went back to an older commit than the preceding one
+	RolledBack bool `json:"rolled_back"`
+	// Commits lists the commits included since the preceding deployment
+	Commits []Commit `json:"commits"`
+	// PullRequests lists the pull requests included since the preceding deployment
+	PullRequests []PullRequest `json:"pull_requests"`
+}
+
+// IsSuccessful reports whether the deployment succeeded, including successful
+// deployments that were replaced by a later one
+func (d Deployment) IsSuccessful() bool {
+	return d.State == DeploymentStateSuccess || d.State == DeploymentStateInactive
+}
+
+// IsFailed reports whether the latest status of the deployment is a failure or an error
+func (d Deployment) IsFailed() bool {
+	return d.State == DeploymentStateFailure || d.State == DeploymentStateError
+}
@@ -0,0 +1,32 @@
+package types
+
+// PathStats represents the changes to a file, directory or module during the period.
+type PathStats struct {
+	// Path is the file or directory path; modules use their top-level directory
+	Path string `json:"path"`
+	// Commits is the number of commits that changed the path
+	Commits int `json:"commits"`
+	// Additions is the number of lines added under the path
+	Additions int `json:"additions"`
+	// Deletions is the number of lines deleted under the path
+	Deletions int `json:"deletions"`
+	// Authors lists the distinct authors who changed the path, sorted by login
+	Authors []string `json:"authors"`
+}
+
+// Churn returns the number of changed lines under the path.
+func (s PathStats) Churn() int {
+	return s.Additions + s.Deletions
+}
+
+// Hotspots lists the parts of the codebase that changed the most.
+type Hotspots struct {
+	// Files lists the most frequently changed files
+	Files []PathStats `json:"files"`
+	// Directories lists the most frequently changed directories
+	Directories []PathStats `json:"directories"`
+	// Modules lists the churn per top-level directory, highest first
+	Modules []PathStats `json:"modules"`
+	// SharedFiles lists the files changed by the most distinct authors
+	SharedFiles []PathStats `json:"shared_files"`
+}
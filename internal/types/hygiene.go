@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// BranchHead represents the latest commit of a branch, regardless of the report period.
+type BranchHead struct {
+	// Name is the branch name
+	Name string `json:"name"`
+	// SHA is the commit the branch points to
+	SHA string `json:"sha"`
+	// Author is the author of the latest commit
+	Author Author `json:"author"`
+	// CommittedAt is the date of the latest commit
+	CommittedAt time.Time `json:"committed_at"`
+	// MergedAt is when the latest pull request from the branch was merged,
+	// nil if none was
+	MergedAt *time.Time `json:"merged_at,omitempty"`
+	// Contained is true when all commits of the branch are on the default
+	// branch, which is also the case for a branch that never had own commits.
+	// It is only checked for branches with commits after their merge.
+	Contained bool `json:"contained"`
+}
+
+// Hygiene lists forgotten branches and pull requests.
+type Hygiene struct {
+	// StaleBranchDays is the number of days without commits after which a branch is stale
+	StaleBranchDays int `json:"stale_branch_days"`
+	// StalePRDays is the number of days without updates after which an open PR is stale
+	StalePRDays int `json:"stale_pr_days"`
+	// StaleBranches lists unmerged branches without commits for StaleBranchDays, oldest first
+	StaleBranches []BranchHead `json:"stale_branches"`
+	// MergedBranches lists branches that were merged but not deleted, oldest first
+	MergedBranches []BranchHead `json:"merged_branches"`
+	// StalePRs lists open PRs without updates for StalePRDays, oldest first
+	StalePRs []PullRequest `json:"stale_prs"`
+	// ConflictingPRs lists open PRs that cannot be merged because of conflicts
+	ConflictingPRs []PullRequest `json:"conflicting_prs"`
+}
@@ -5,23 +5,23 @@ import "time"
 // Issue represents a GitHub issue.
 type Issue struct {
 	// Number is the issue number
-	Number int
+	Number int `json:"number"`
 	// Title is the issue title
-	Title string
+	Title string `json:"title"`
 	// Body is the issue description/body
-	Body string
+	Body string `json:"body"`
 	// Author is the author of the issue
-	Author Author
+	Author Author `json:"author"`
 	// State is the current state (open, closed)
-	State string
+	State string `json:"state"`
 	// CreatedAt is when the issue was created
-	CreatedAt time.Time
+	CreatedAt time.Time `json:"created_at"`
 	// ClosedAt is when the issue was closed (nil if still open)
-	ClosedAt *time.Time
+	ClosedAt *time.Time `json:"closed_at"`
 	// Labels is the list of labels attached to the issue
-	Labels []string
+	Labels []string `json:"labels"`
 	// Assignees is the list of users assigned to the issue
-	Assignees []Author
+	Assignees []Author `json:"assignees"`
 	// URL is the link to the issue on GitHub
-	URL string
+	URL string `json:"url"`
 }
@@ -2,28 +2,68 @@ package types
 
 import "time"
 
+// Pull request states.
+const (
+	// PRStateOpen means the pull request is open
+	PRStateOpen = "open"
+	// PRStateClosed means the pull request was closed without being merged
+	PRStateClosed = "closed"
+	// PRStateMerged means the pull request was merged
+	PRStateMerged = "merged"
+)
+
 // PullRequest represents a GitHub pull request.
 type PullRequest struct {
 	// Number is the PR number
-	Number int
+	Number int `json:"number"`
 	// Title is the PR title
-	Title string
+	Title string `json:"title"`
 	// Body is the PR description/body
-	Body string
+	Body string `json:"body"`
 	// Author is the author of the PR
-	Author Author
+	Author Author `json:"author"`
 	// State is the current state (open, closed, merged)
-	State string
+	State string `json:"state"`
 	// CreatedAt is when the PR was created
-	CreatedAt time.Time
+	CreatedAt time.Time `json:"created_at"`
 	// UpdatedAt is when the PR was last updated
-	UpdatedAt time.Time
+	UpdatedAt time.Time `json:"updated_at"`
+	// ClosedAt is when the PR was closed or merged (nil if still open)
+	ClosedAt *time.Time `json:"closed_at"`
+	// MergedAt is when the PR was merged (nil if not merged)
+	MergedAt *time.Time `json:"merged_at"`
+	// FirstCommitAt is the author date of the first commit of the PR (nil if
+	// unknown; the REST backend fetches it only for PRs merged in the period)
+	FirstCommitAt *time.Time `json:"first_commit_at,omitempty"`
+	// MergedBy is the user who merged the PR (nil if not merged or unknown)
+	MergedBy *Author `json:"merged_by,omitempty"`
+	// BaseRef is the name of the branch the PR is merged into
+	BaseRef string `json:"base_ref"`
+	// HeadRef is the name of the branch with the PR changes
+	HeadRef string `json:"head_ref"`
+	// HeadRepo is the repository (owner/repo) of HeadRef; it differs from the
+	// base repository for PRs from forks and is empty when the fork was deleted
+	HeadRepo string `json:"head_repo"`
+	// Draft is true for draft pull requests
+	Draft bool `json:"draft"`
+	// HasConflicts is true when the PR cannot be merged because of conflicts
+	// (only known for open PRs)
+	HasConflicts bool `json:"has_conflicts"`
+	// Labels is the list of labels attached to the PR
+	Labels []string `json:"labels"`
 	// Comments is the number of comments on the PR
-	Comments int
+	Comments int `json:"comments"`
 	// Reviews is the number of reviews on the PR
-	Reviews int
+	Reviews int `json:"reviews"`
+	// ReviewDetails is the list of reviews submitted on the PR
+	ReviewDetails []Review `json:"review_details"`
 	// URL is the link to the PR on GitHub
-	URL string
+	URL string `json:"url"`
 	// AISummary is the AI-generated summary of the PR
-	AISummary string
+	AISummary string `json:"ai_summary"`
+}
+
+// IsMerged reports whether the pull request was merged
+func (pr PullRequest) IsMerged() bool {
+	return pr.MergedAt != nil
 }
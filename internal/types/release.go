@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// Release represents a GitHub release or a tag without a release.
+type Release struct {
+	// TagName is the name of the git tag
+	TagName string `json:"tag_name"`
+	// Name is the release title (empty for tags without a release)
+	Name string `json:"name"`
+	// Body holds the release notes
+	Body string `json:"body"`
+	// Author is the user who published the release (empty for tags without a release)
+	Author Author `json:"author"`
+	// PublishedAt is when the release was published, or the commit date of a tag without a release
+	PublishedAt time.Time `json:"published_at"`
+	// Prerelease is true for releases marked as pre-releases
+	Prerelease bool `json:"prerelease"`
+	// TagOnly is true for tags without a GitHub release
+	TagOnly bool `json:"tag_only"`
+	// URL is the link to the release or tag on GitHub
+	URL string `json:"url"`
+	// PreviousTag is the tag of the preceding release or tag (empty for the first one)
+	PreviousTag string `json:"previous_tag,omitempty"`
+	// Commits lists the commits included since the previous tag
+	Commits []Commit `json:"commits"`
+	// CommitsTruncated is true when only the first commits since the previous tag could be listed
+	CommitsTruncated bool `json:"commits_truncated"`
+	// CommitsUnavailable is true when the commits since the previous tag could not be fetched
+	CommitsUnavailable bool `json:"commits_unavailable"`
+	// PullRequests lists the pull requests included since the previous tag
+	PullRequests []PullRequest `json:"pull_requests"`
+	// AISummary is the AI-generated summary of the release
+	AISummary string `json:"ai_summary"`
+}
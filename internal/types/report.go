@@ -5,33 +5,82 @@ import "time"
 // Period represents a time period for the report.
 type Period struct {
 	// From is the start date of the period
-	From time.Time
+	From time.Time `json:"from"`
 	// To is the end date of the period
-	To time.Time
+	To time.Time `json:"to"`
 }
 
 // ReportData contains all data collected for the report.
 type ReportData struct {
 	// Repository is the repository name (owner/repo)
-	Repository string
+	Repository string `json:"repository"`
 	// RepositoryURL is the full URL to the repository
-	RepositoryURL string
+	RepositoryURL string `json:"repository_url"`
+	// DefaultBranch is the repository default branch (empty if unknown)
+	DefaultBranch string `json:"default_branch,omitempty"`
 	// Period is the time period covered by this report
-	Period Period
+	Period Period `json:"period"`
+	// User is the login the report is focused on (empty for a full-repository report)
+	User string `json:"user,omitempty"`
 	// GeneratedAt is when this report was generated
-	GeneratedAt time.Time
+	GeneratedAt time.Time `json:"generated_at"`
 	// Branches is the list of branches with activity during the period
-	Branches []Branch
+	Branches []Branch `json:"branches"`
 	// OpenPRs is the list of currently open pull requests
-	OpenPRs []PullRequest
+	OpenPRs []PullRequest `json:"open_prs"`
 	// UpdatedPRs is the list of pull requests updated during the period
-	UpdatedPRs []PullRequest
+	UpdatedPRs []PullRequest `json:"updated_prs"`
 	// OpenIssues is the list of currently open issues
-	OpenIssues []Issue
+	OpenIssues []Issue `json:"open_issues"`
 	// ClosedIssues is the list of issues closed during the period
-	ClosedIssues []Issue
+	ClosedIssues []Issue `json:"closed_issues"`
+	// Releases lists the releases and tags published during the period, newest first
+	Releases []Release `json:"releases"`
+	// Deployments lists the deployments created during the period, newest first
+	Deployments []Deployment `json:"deployments"`
+	// WorkflowRuns lists the GitHub Actions workflow runs created during the period
+	// (not serialized, summarized in CI)
+	WorkflowRuns []WorkflowRun `json:"-"`
+	// BranchHeads lists the latest commit of every branch of the repository
+	// (not serialized, summarized in Hygiene)
+	BranchHeads []BranchHead `json:"-"`
+	// ReviewsGiven is the list of reviews the user submitted on other authors' pull requests
+	// (only populated for user-focused reports)
+	ReviewsGiven []ReviewActivity `json:"reviews_given,omitempty"`
 	// AuthorStats is the statistics per author
-	AuthorStats []AuthorStats
+	AuthorStats []AuthorStats `json:"author_stats"`
 	// OverallStats is the overall statistics for the repository
-	OverallStats OverallStats
+	OverallStats OverallStats `json:"overall_stats"`
+	// CycleTime holds the pull request cycle-time and review-latency analytics
+	CycleTime *CycleTimeAnalytics `json:"cycle_time,omitempty"`
+	// Delivery holds the DORA-style delivery metrics
+	Delivery *DeliveryMetrics `json:"delivery,omitempty"`
+	// CI holds the GitHub Actions workflow run health
+	CI *CIHealth `json:"ci,omitempty"`
+	// Hygiene lists stale and merged branches and abandoned or conflicting pull requests
+	Hygiene *Hygiene `json:"hygiene,omitempty"`
+	// Hotspots lists the most changed files, directories and modules
+	Hotspots *Hotspots `json:"hotspots,omitempty"`
+	// Comparison compares the period with the preceding one (only with --compare)
+	Comparison *PeriodComparison `json:"comparison,omitempty"`
+	// AISummary is the AI-generated summary of overall repository activity
+	AISummary string `json:"ai_summary"`
+}
+
+// MultiReportData contains the data of a combined report covering several repositories.
+type MultiReportData struct {
+	// Repositories is the list of repository names (owner/repo) in report order
+	Repositories []string `json:"repositories"`
+	// Period is the time period covered by this report
+	Period Period `json:"period"`
+	// User is the login the report is focused on (empty for a full report)
+	User string `json:"user,omitempty"`
+	// GeneratedAt is when this report was generated
+	GeneratedAt time.Time `json:"generated_at"`
+	// Reports holds the data of each repository
+	Reports []ReportData `json:"reports"`
+	// AuthorStats is the statistics per author aggregated across all repositories
+	AuthorStats []AuthorStats `json:"author_stats"`
+	// OverallStats is the overall statistics aggregated across all repositories
+	OverallStats OverallStats `json:"overall_stats"`
 }
@@ -0,0 +1,13 @@
+package types
+
+// Repository represents a repository returned by an organization listing.
+type Repository struct {
+	// FullName is the repository name (owner/repo)
+	FullName string `json:"full_name"`
+	// Topics is the list of topics assigned to the repository
+	Topics []string `json:"topics"`
+	// Archived reports whether the repository is archived (read-only)
+	Archived bool `json:"archived"`
+	// Fork reports whether the repository is a fork
+	Fork bool `json:"fork"`
+}
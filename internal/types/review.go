@@ -0,0 +1,41 @@
+package types
+
+import "time"
+
+// Review states as reported by the GitHub API.
+const (
+	// ReviewStateApproved means the reviewer approved the changes
+	ReviewStateApproved = "APPROVED"
+	// ReviewStateChangesRequested means the reviewer requested changes
+	ReviewStateChangesRequested = "CHANGES_REQUESTED"
+	// ReviewStateCommented means the reviewer left comments without a verdict
+	ReviewStateCommented = "COMMENTED"
+	// ReviewStateDismissed means the review was dismissed
+	ReviewStateDismissed = "DISMISSED"
+)
+
+// Review represents a code review submitted on a pull request.
+type Review struct {
+	// Author is the reviewer
+	Author Author `json:"author"`
+	// State is the review state (APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED)
+	State string `json:"state"`
+	// SubmittedAt is when the review was submitted
+	SubmittedAt time.Time `json:"submitted_at"`
+	// URL is the link to the review on GitHub
+	URL string `json:"url"`
+}
+
+// ReviewActivity links a review to the pull request it was submitted on.
+type ReviewActivity struct {
+	// PRNumber is the number of the reviewed pull request
+	PRNumber int `json:"pr_number"`
+	// PRTitle is the title of the reviewed pull request
+	PRTitle string `json:"pr_title"`
+	// PRURL is the link to the reviewed pull request
+	PRURL string `json:"pr_url"`
+	// PRAuthor is the author of the reviewed pull request
+	PRAuthor Author `json:"pr_author"`
+	// Review is the submitted review
+	Review Review `json:"review"`
+}
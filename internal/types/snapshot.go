@@ -0,0 +1,21 @@
+package types
+
+import "time"
+
+// Snapshot is a stored report used to follow activity over longer time spans.
+type Snapshot struct {
+	// Repository is the repository name (owner/repo)
+	Repository string `json:"repository"`
+	// Period is the time period covered by the report
+	Period Period `json:"period"`
+	// User is the login the report is focused on (empty for a full-repository report)
+	User string `json:"user,omitempty"`
+	// SavedAt is when the snapshot was stored
+	SavedAt time.Time `json:"saved_at"`
+	// Stats is the activity counted over the period
+	Stats PeriodStats `json:"stats"`
+	// Contributors lists the logins of commit authors in the period
+	Contributors []string `json:"contributors"`
+	// Report is the complete report data
+	Report *ReportData `json:"report"`
+}
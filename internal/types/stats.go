@@ -3,45 +3,57 @@ package types
 // BranchActivity represents activity statistics for a specific branch.
 type BranchActivity struct {
 	// Commits is the number of commits in this branch
-	Commits int
+	Commits int `json:"commits"`
 	// Added is the number of lines added in this branch
-	Added int
+	Added int `json:"added"`
 	// Deleted is the number of lines deleted in this branch
-	Deleted int
+	Deleted int `json:"deleted"`
 }
 
 // AuthorStats represents statistics for a single author.
 type AuthorStats struct {
 	// Author is the author information
-	Author Author
+	Author Author `json:"author"`
 	// TotalCommits is the total number of commits by this author
-	TotalCommits int
+	TotalCommits int `json:"total_commits"`
 	// TotalAdded is the total number of lines added by this author
-	TotalAdded int
+	TotalAdded int `json:"total_added"`
 	// TotalDeleted is the total number of lines deleted by this author
-	TotalDeleted int
+	TotalDeleted int `json:"total_deleted"`
 	// PRsCreated is the number of pull requests created by this author
-	PRsCreated int
+	PRsCreated int `json:"prs_created"`
 	// IssuesCreated is the number of issues created by this author
-	IssuesCreated int
+	IssuesCreated int `json:"issues_created"`
 	// ReviewsCount is the number of code reviews performed by this author
-	ReviewsCount int
+	ReviewsCount int `json:"reviews_count"`
+	// ReviewsByState maps review states to the number of reviews performed by this author
+	ReviewsByState map[string]int `json:"reviews_by_state"`
+	// ReviewsReceived is the number of reviews received on pull requests created by this author
+	ReviewsReceived int `json:"reviews_received"`
 	// BranchActivity maps branch names to activity statistics
-	BranchActivity map[string]BranchActivity
+	BranchActivity map[string]BranchActivity `json:"branch_activity"`
 }
 
 // OverallStats represents overall statistics for the repository activity.
 type OverallStats struct {
-	// TotalCommits is the total number of commits across all branches
-	TotalCommits int
+	// TotalCommits is the number of unique commits across all branches
+	TotalCommits int `json:"total_commits"`
+	// BranchCommits is the number of commits counted per branch, a commit on several branches once per branch
+	BranchCommits int `json:"branch_commits"`
 	// TotalAuthors is the total number of unique authors
-	TotalAuthors int
+	TotalAuthors int `json:"total_authors"`
 	// OpenPRCount is the number of currently open pull requests
-	OpenPRCount int
+	OpenPRCount int `json:"open_pr_count"`
+	// MergedPRCount is the number of pull requests merged during the period
+	MergedPRCount int `json:"merged_pr_count"`
+	// ClosedUnmergedPRCount is the number of pull requests closed without merging during the period
+	ClosedUnmergedPRCount int `json:"closed_unmerged_pr_count"`
 	// OpenIssuesCount is the number of currently open issues
-	OpenIssuesCount int
+	OpenIssuesCount int `json:"open_issues_count"`
 	// ClosedIssuesCount is the number of issues closed during the period
-	ClosedIssuesCount int
+	ClosedIssuesCount int `json:"closed_issues_count"`
 	// ReviewsCount is the total number of code reviews
-	ReviewsCount int
+	ReviewsCount int `json:"reviews_count"`
+	// ReviewsByState maps review states to the total number of reviews
+	ReviewsByState map[string]int `json:"reviews_by_state"`
 }
@@ -0,0 +1,134 @@
+package types
+
+import "time"
+
+// Workflow run conclusions
+const (
+	// RunConclusionSuccess means the run passed
+	RunConclusionSuccess = "success"
+	// RunConclusionFailure means the run failed
+	RunConclusionFailure = "failure"
+	// RunConclusionTimedOut means the run was stopped after its timeout
+	RunConclusionTimedOut = "timed_out"
+	// RunConclusionStartupFailure means the run could not start
+	RunConclusionStartupFailure = "startup_failure"
+)
+
+// WorkflowRun represents a GitHub Actions workflow run.
+type WorkflowRun struct {
+	// ID is the run ID
+	ID int64 `json:"id"`
+	// WorkflowID is the ID of the workflow, which stays the same when the workflow is renamed
+	WorkflowID int64 `json:"workflow_id"`
+	// Workflow is the workflow name
+	Workflow string `json:"workflow"`
+	// Branch is the branch the run was triggered on
+	Branch string `json:"branch"`
+	// SHA is the commit the run was triggered for
+	SHA string `json:"sha"`
+	// Event is the event that triggered the run (push, pull_request, schedule, ...)
+	Event string `json:"event"`
+	// Status is the run status (queued, in_progress, completed)
+	Status string `json:"status"`
+	// Conclusion is the result of a completed run (success, failure, cancelled, skipped, ...)
+	Conclusion string `json:"conclusion"`
+	// Attempt is the number of the latest attempt of the run (greater than 1 after re-runs)
+	Attempt int `json:"attempt"`
+	// PreviousConclusions lists the conclusions of the earlier attempts of a
+	// re-run, oldest first (only fetched for the most recent re-runs)
+	PreviousConclusions []string `json:"previous_conclusions,omitempty"`
+	// Actor is the user who triggered the run
+	Actor Author `json:"actor"`
+	// StartedAt is when the latest attempt started
+	StartedAt time.Time `json:"started_at"`
+	// UpdatedAt is when the run was last updated (its end for completed runs)
+	UpdatedAt time.Time `json:"updated_at"`
+	// URL is the link to the run on GitHub
+	URL string `json:"url"`
+	// Jobs lists the jobs of the run (only fetched for the longest runs)
+	Jobs []WorkflowJob `json:"jobs,omitempty"`
+}
+
+// WorkflowJob represents a job of a workflow run.
+type WorkflowJob struct {
+	// Name is the job name
+	Name string `json:"name"`
+	// Conclusion is the result of the job
+	Conclusion string `json:"conclusion"`
+	// StartedAt is when the job started
+	StartedAt time.Time `json:"started_at"`
+	// CompletedAt is when the job finished
+	CompletedAt time.Time `json:"completed_at"`
+	// URL is the link to the job on GitHub
+	URL string `json:"url"`
+}
+
+// WorkflowStats aggregates the runs of a workflow on a branch.
+type WorkflowStats struct {
+	// Workflow is the workflow name
+	Workflow string `json:"workflow"`
+	// Branch is the branch name
+	Branch string `json:"branch"`
+	// Runs is the number of completed runs that passed or failed
+	Runs int `json:"runs"`
+	// Passed is the number of successful runs
+	Passed int `json:"passed"`
+	// Failed is the number of failed runs
+	Failed int `json:"failed"`
+	// SuccessRate is the percentage of successful runs (0-100)
+	SuccessRate float64 `json:"success_rate"`
+	// LastConclusion is the conclusion of the most recent run
+	LastConclusion string `json:"last_conclusion"`
+	// LastRunURL is the link to the most recent run
+	LastRunURL string `json:"last_run_url"`
+	// LastRunAt is when the most recent run started
+	LastRunAt time.Time `json:"last_run_at"`
+}
+
+// FlakyWorkflow is a workflow that both passed and failed on the same commit.
+type FlakyWorkflow struct {
+	// Workflow is the workflow name
+	Workflow string `json:"workflow"`
+	// Branch is the branch of the runs
+	Branch string `json:"branch"`
+	// SHA is the commit the workflow passed and failed on
+	SHA string `json:"sha"`
+	// Passed is the number of successful runs or attempts
+	Passed int `json:"passed"`
+	// Failed is the number of failed runs or attempts
+	Failed int `json:"failed"`
+	// URL is the link to the most recent run
+	URL string `json:"url"`
+}
+
+// JobDuration is the duration of a workflow job.
+type JobDuration struct {
+	// Workflow is the workflow name
+	Workflow string `json:"workflow"`
+	// Job is the job name
+	Job string `json:"job"`
+	// Branch is the branch of the run
+	Branch string `json:"branch"`
+	// Minutes is the job duration in minutes
+	Minutes float64 `json:"minutes"`
+	// URL is the link to the job on GitHub
+	URL string `json:"url"`
+}
+
+// CIHealth holds the GitHub Actions workflow run health of the period.
+type CIHealth struct {
+	// DefaultBranch is the repository default branch (empty if unknown)
+	DefaultBranch string `json:"default_branch,omitempty"`
+	// TotalRuns is the number of completed runs that passed or failed
+	TotalRuns int `json:"total_runs"`
+	// SuccessRate is the percentage of successful runs (0-100)
+	SuccessRate float64 `json:"success_rate"`
+	// Workflows lists the statistics per workflow and branch
+	Workflows []WorkflowStats `json:"workflows"`
+	// Flaky lists workflows that both passed and failed on the same commit
+	Flaky []FlakyWorkflow `json:"flaky"`
+	// LongestJobs lists the longest-running jobs, longest first
+	LongestJobs []JobDuration `json:"longest_jobs"`
+	// Red lists the workflows whose latest run on the default branch failed
+	Red []WorkflowStats `json:"red"`
+}
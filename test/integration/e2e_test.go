@@ -4,11 +4,14 @@
 package integration
 
 import (
+	"context"
+	"encoding/json"
 	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/hazadus/gh-repomon/internal/history"
 	"github.com/hazadus/gh-repomon/internal/report"
 	"github.com/hazadus/gh-repomon/internal/types"
 )
@@ -36,7 +39,7 @@ func TestGenerateReport(t *testing.T) {
 	}
 
 	// Generate report
-	reportText, err := gen.Generate(opts)
+	reportText, err := gen.Generate(context.Background(), opts)
 	if err != nil {
 		t.Fatalf("Failed to generate report: %v", err)
 	}
@@ -174,8 +177,14 @@ func TestGenerateReport(t *testing.T) {
 		if !strings.Contains(reportText, "## 👀 Code Reviews") {
 			t.Error("Report missing Code Reviews section")
 		}
-		if !strings.Contains(reportText, "**Total Reviews**:") {
-			t.Error("Report missing total reviews count")
+		if !strings.Contains(reportText, "**Total Reviews**: 5") {
+			t.Error("Report missing or incorrect total reviews count")
+		}
+		if !strings.Contains(reportText, "### Reviewers") {
+			t.Error("Report missing reviewers list")
+		}
+		if !strings.Contains(reportText, "1 approved, 1 changes requested") {
+			t.Error("Report missing review state breakdown")
 		}
 	})
 
@@ -261,13 +270,12 @@ func TestGenerateReport(t *testing.T) {
 func TestGenerateReportWithNoActivity(t *testing.T) {
 	// Create empty mock client
 	mockGitHub := &MockGitHubClient{
-		activeBranches:   []types.Branch{},
-		openPRs:          []types.PullRequest{},
-		updatedPRs:       []types.PullRequest{},
-		openIssues:       []types.Issue{},
-		closedIssues:     []types.Issue{},
-		reviewsByAuthor:  map[string]int{},
-		totalReviewCount: 0,
+		activeBranches: []types.Branch{},
+		openPRs:        []types.PullRequest{},
+		updatedPRs:     []types.PullRequest{},
+		openIssues:     []types.Issue{},
+		closedIssues:   []types.Issue{},
+		reviews:        map[int][]types.Review{},
 	}
 	mockLLM := NewMockLLMClient()
 
@@ -284,7 +292,7 @@ func TestGenerateReportWithNoActivity(t *testing.T) {
 		Language: "english",
 	}
 
-	reportText, err := gen.Generate(opts)
+	reportText, err := gen.Generate(context.Background(), opts)
 	if err != nil {
 		t.Fatalf("Failed to generate report for empty repository: %v", err)
 	}
@@ -321,8 +329,649 @@ func TestGenerateReportErrorHandling(t *testing.T) {
 	}
 
 	// Should not panic, even with invalid input
-	_, err := gen.Generate(opts)
+	_, err := gen.Generate(context.Background(), opts)
 	// The actual behavior (error or proceeding) depends on implementation
 	// For now, we just verify it doesn't panic
 	_ = err
 }
+
+// TestGenerateReportForUser tests that the --user filter restricts the report to a single person
+func TestGenerateReportForUser(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/test-repo",
+		Period: types.Period{
+			From: now.Add(-24 * time.Hour),
+			To:   now,
+		},
+		User:     "developer2",
+		Model:    "openai/gpt-4o",
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate user report: %v", err)
+	}
+
+	if !strings.Contains(reportText, "## 👤 Activity of developer2") {
+		t.Error("User report missing personal activity section")
+	}
+	if !strings.Contains(reportText, "fix: resolve bug in authentication") {
+		t.Error("User report missing the user's commit")
+	}
+	if strings.Contains(reportText, "feat: redesign user interface") {
+		t.Error("User report contains another author's commit")
+	}
+	if strings.Contains(reportText, "## 🌿 Branch: feature/new-ui") {
+		t.Error("User report contains a branch without the user's commits")
+	}
+	if strings.Contains(reportText, "### [PR #1: Add authentication feature]") {
+		t.Error("User report contains another author's pull request")
+	}
+	if strings.Contains(reportText, "### [developer1]") {
+		t.Error("User report contains another author's statistics")
+	}
+	if !strings.Contains(reportText, "### Reviews Given") || !strings.Contains(reportText, "PR #1: Add authentication feature") {
+		t.Error("User report missing reviews given on other pull requests")
+	}
+	if !strings.Contains(reportText, "- **Code Reviews**: 2 (1 approved, 1 changes requested)") {
+		t.Error("User report missing the user's review count")
+	}
+	if !strings.Contains(reportText, "- **Reviews Received**: 3") {
+		t.Error("User report missing reviews received")
+	}
+}
+
+// TestGenerateReportTimeout tests that an expired deadline produces a partial report instead of an error
+func TestGenerateReportTimeout(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/test-repo",
+		Period: types.Period{
+			From: now.Add(-24 * time.Hour),
+			To:   now,
+		},
+		Model:    "openai/gpt-4o",
+		Language: "english",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	reportText, err := gen.Generate(ctx, opts)
+	if err != nil {
+		t.Fatalf("Generate() with expired deadline error = %v, want partial report", err)
+	}
+
+	if !strings.Contains(reportText, "Report is incomplete: the run timed out") {
+		t.Error("Partial report missing incomplete warning")
+	}
+	if !strings.Contains(reportText, "[AI summary skipped: the run timed out]") {
+		t.Error("Partial report should skip AI summaries")
+	}
+	if count := mockLLM.GetSummaryCount(); count != 0 {
+		t.Errorf("LLM called %d times after the deadline, want 0", count)
+	}
+}
+
+// TestGenerateMultiRepositoryReport tests a combined report covering several repositories
+func TestGenerateMultiRepositoryReport(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repositories: []string{"owner/api", "owner/web"},
+		Period: types.Period{
+			From: now.Add(-24 * time.Hour),
+			To:   now,
+		},
+		Model:    "openai/gpt-4o",
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate multi-repository report: %v", err)
+	}
+
+	for _, want := range []string{
+		"# Activity Report: 2 Repositories",
+		"## 📦 Repositories",
+		"| [owner/api](https://github.com/owner/api) |",
+		"| [owner/web](https://github.com/owner/web) |",
+		"# 📦 owner/api",
+		"# 📦 owner/web",
+		"## 👥 Author Activity",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Multi-repository report missing %q", want)
+		}
+	}
+
+	// Author statistics are aggregated: the same author appears once
+	if count := strings.Count(reportText, "### [developer1]"); count != 1 {
+		t.Errorf("Author developer1 listed %d times, want 1", count)
+	}
+
+	// JSON output uses the multi-repository document
+	opts.Format = report.FormatJSON
+	jsonText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate multi-repository JSON report: %v", err)
+	}
+
+	var doc struct {
+		MultiReport types.MultiReportData `json:"multi_report"`
+	}
+	if err := json.Unmarshal([]byte(jsonText), &doc); err != nil {
+		t.Fatalf("Invalid JSON report: %v", err)
+	}
+	if len(doc.MultiReport.Reports) != 2 {
+		t.Errorf("JSON report has %d repositories, want 2", len(doc.MultiReport.Reports))
+	}
+}
+
+func TestGenerateReportWithComparison(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-7 * 24 * time.Hour),
+			To:   now,
+		},
+		Model:    "openai/gpt-4o",
+		Language: "english",
+		Compare:  report.ComparePrevious,
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report with comparison: %v", err)
+	}
+
+	for _, want := range []string{
+		"## 📈 Compared to Previous Period",
+		"| Metric | Current | Previous | Change |",
+		"| Commits |",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report with comparison missing %q", want)
+		}
+	}
+
+	// The comparison is part of the JSON report as well
+	opts.Format = report.FormatJSON
+	jsonText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate JSON report with comparison: %v", err)
+	}
+
+	var doc struct {
+		Report types.ReportData `json:"report"`
+	}
+	if err := json.Unmarshal([]byte(jsonText), &doc); err != nil {
+		t.Fatalf("Invalid JSON report: %v", err)
+	}
+	if doc.Report.Comparison == nil {
+		t.Fatal("JSON report has no comparison")
+	}
+	if !doc.Report.Comparison.PreviousPeriod.To.Equal(opts.Period.From) {
+		t.Errorf("Previous period ends at %v, want %v", doc.Report.Comparison.PreviousPeriod.To, opts.Period.From)
+	}
+}
+
+func TestGenerateReportStoresSnapshot(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, nil)
+	store := history.NewStore(t.TempDir())
+	gen.SetSnapshotStore(store)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-24 * time.Hour),
+			To:   now,
+		},
+		Language: "english",
+	}
+
+	if _, err := gen.Generate(context.Background(), opts); err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	snapshots, err := store.List("owner/repo", "")
+	if err != nil {
+		t.Fatalf("Failed to list snapshots: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("Stored %d snapshots, want 1", len(snapshots))
+	}
+
+	snapshot := snapshots[0]
+	if snapshot.Stats.Commits != snapshot.Report.OverallStats.TotalCommits {
+		t.Errorf("Snapshot has %d commits, report has %d", snapshot.Stats.Commits, snapshot.Report.OverallStats.TotalCommits)
+	}
+	if len(snapshot.Contributors) != snapshot.Report.OverallStats.TotalAuthors {
+		t.Errorf("Snapshot has %d contributors, report has %d authors", len(snapshot.Contributors), snapshot.Report.OverallStats.TotalAuthors)
+	}
+
+	// Interrupted runs produce partial data and are not stored
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	opts.Period.From = now.Add(-72 * time.Hour)
+	if _, err := gen.Generate(ctx, opts); err != nil {
+		t.Fatalf("Failed to generate partial report: %v", err)
+	}
+	if snapshots, _ := store.List("owner/repo", ""); len(snapshots) != 1 {
+		t.Errorf("Stored %d snapshots after an interrupted run, want 1", len(snapshots))
+	}
+}
+
+func TestGenerateReportMergedPullRequests(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, nil)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-48 * time.Hour),
+			To:   now,
+		},
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	for _, want := range []string{
+		"- **Merged Pull Requests**: 1",
+		"- **Closed Without Merge**: 0",
+		"## 🟣 Merged Pull Requests",
+		"[PR #3: Redesign user interface](https://github.com/owner/repo/pull/3) by [developer1](https://github.com/developer1), merged",
+		"by [developer2](https://github.com/developer2) (`feature/new-ui` → `main`)",
+		"- **Status**: merged",
+		"- **Labels**: ui",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+}
+
+func TestGenerateReportCycleTime(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, nil)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-48 * time.Hour),
+			To:   now.Add(time.Hour),
+		},
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	for _, want := range []string{
+		"## ⏱️ Pull Request Cycle Time",
+		"| Time to First Review |",
+		"### By Author",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+
+	opts.Format = report.FormatJSON
+	jsonText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate JSON report: %v", err)
+	}
+
+	var doc struct {
+		Report types.ReportData `json:"report"`
+	}
+	if err := json.Unmarshal([]byte(jsonText), &doc); err != nil {
+		t.Fatalf("Invalid JSON report: %v", err)
+	}
+	if doc.Report.CycleTime == nil {
+		t.Fatal("JSON report has no cycle time analytics")
+	}
+	if doc.Report.CycleTime.Overall.TimeToMerge.Count != 1 {
+		t.Errorf("Measured %d merged PRs, want 1", doc.Report.CycleTime.Overall.TimeToMerge.Count)
+	}
+}
+
+func TestGenerateReportDeliveryMetrics(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, nil)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-7 * 24 * time.Hour),
+			To:   now.Add(time.Hour),
+		},
+		Language: "english",
+		Format:   report.FormatJSON,
+	}
+
+	jsonText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate JSON report: %v", err)
+	}
+
+	var doc struct {
+		Report types.ReportData `json:"report"`
+	}
+	if err := json.Unmarshal([]byte(jsonText), &doc); err != nil {
+		t.Fatalf("Invalid JSON report: %v", err)
+	}
+
+	delivery := doc.Report.Delivery
+	if delivery == nil {
+		t.Fatal("JSON report has no delivery metrics")
+	}
+	// The failed staging deployment and the release don't count next to the production deployment
+	if delivery.DeploymentSource != types.DeploymentSourceDeployments {
+		t.Errorf("Deployment source = %q, want production deployments", delivery.DeploymentSource)
+	}
+	if delivery.Deployments != 1 || delivery.LeadTime.Count != 1 {
+		t.Errorf("Got %d deployments with %d lead times, want 1 production deployment with 1 commit", delivery.Deployments, delivery.LeadTime.Count)
+	}
+	if len(delivery.IncidentLabels) != 1 || delivery.IncidentLabels[0] != "incident" {
+		t.Errorf("Incident labels = %v, want the default", delivery.IncidentLabels)
+	}
+
+	opts.Format = report.FormatMarkdown
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+	for _, want := range []string{
+		"## 🚀 Delivery Metrics",
+		"Deployments are successful deployments to production environments during the period",
+		"| Deployment Frequency | 1 (",
+		"| Change Failure Rate | 0.0% (0 of 1) |",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+}
+
+func TestGenerateReportReleases(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-48 * time.Hour),
+			To:   now,
+		},
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	for _, want := range []string{
+		"## 🏷️ Releases",
+		"### [v1.1.0: New UI](https://github.com/owner/repo/releases/tag/v1.1.0)",
+		"- **Changes since v1.0.0**: 1 commits, 1 pull requests",
+		"**Summary**: Release v1.1.0 ships 1 pull requests.",
+		"> - Redesigned user interface",
+		"- [#3: Redesign user interface](https://github.com/owner/repo/pull/3) by [developer1](https://github.com/developer1)",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+}
+
+func TestGenerateReportCIHealth(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-48 * time.Hour),
+			To:   now,
+		},
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	for _, want := range []string{
+		"## 🧪 CI Health",
+		"**Success Rate**: 66.7% (3 runs)",
+		"### 🔴 Failing on main",
+		"- [CI](https://github.com/owner/repo/actions/runs/102): last run failure on",
+		"| CI | `main` | 2 | 1 | 1 | 50.0% | [failure](https://github.com/owner/repo/actions/runs/102) |",
+		"- **CI** on `main` at [`abc123`](https://github.com/owner/repo/actions/runs/101): 1 passed, 1 failed",
+		"| [test](https://github.com/owner/repo/actions/runs/101/job/1) | CI | `main` | 12m |",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+}
+
+func TestGenerateReportDeployments(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-48 * time.Hour),
+			To:   now,
+		},
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	for _, want := range []string{
+		"## 🚢 Deployments",
+		"| production | 1 | 1 | 0 | 0 |",
+		"| staging | 1 | 0 | 1 | 0 |",
+		"### ⚠️ Failed and Rolled-Back Deployments",
+		"`main` by [developer2](https://github.com/developer2): [failure](https://github.com/owner/repo/actions/runs/202)",
+		"  - Changes since `ghi789`: 1 commits, 1 pull requests",
+		"  - [#3: Redesign user interface](https://github.com/owner/repo/pull/3) by [developer1](https://github.com/developer1)",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+}
+
+func TestGenerateReportComparesBranches(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-48 * time.Hour),
+			To:   now,
+		},
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	for _, want := range []string{
+		"- **Compared to Default Branch**: 1 ahead, 2 behind `main`",
+		"- **Pull Requests**: [#3: Redesign user interface](https://github.com/owner/repo/pull/3) (merged)",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+
+	// The default branch is not compared with itself
+	if strings.Contains(reportText, "could not be compared") {
+		t.Error("Report should not warn about branch comparisons")
+	}
+}
+
+func TestGenerateReportHygiene(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-48 * time.Hour),
+			To:   now,
+		},
+		Language:        "english",
+		Hygiene:         true,
+		StaleBranchDays: 60,
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	for _, want := range []string{
+		"## 🧹 Repository Hygiene",
+		"- **Stale Branches** (no commits for 60 days): 1",
+		"- **Merged Branches Not Deleted**: 2",
+		"- **Stale Pull Requests** (no activity for 14 days): 0",
+		"- **Pull Requests with Merge Conflicts**: 1",
+		"| `old/experiment` | [`aaa111`](https://github.com/owner/repo/commit/aaa111)",
+		// Pull request #3 was merged from feature/new-ui after its latest commit
+		"- `feature/new-ui`: last commit",
+		// old/merged was merged long before the period and is not reported as stale
+		"- `old/merged`: last commit",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+
+	// release/1.0 has no commits beyond main, but no pull request from it was merged
+	if strings.Contains(reportText, "- `release/1.0`: last commit") {
+		t.Error("Report lists release/1.0 as merged without a merged pull request")
+	}
+
+	// Only old/merged has commits after its merge that need a comparison
+	if mockGitHub.branchCountCalls != 1 {
+		t.Errorf("Compared %d branches with main, want only old/merged", mockGitHub.branchCountCalls)
+	}
+}
+
+func TestGenerateReportWithoutHygiene(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-48 * time.Hour),
+			To:   now,
+		},
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	if strings.Contains(reportText, "Repository Hygiene") {
+		t.Error("Report has a hygiene section without the hygiene option")
+	}
+	if mockGitHub.hygieneCalls != 0 {
+		t.Errorf("Made %d hygiene requests without the hygiene option, want none", mockGitHub.hygieneCalls)
+	}
+}
+
+func TestGenerateReportHotspots(t *testing.T) {
+	mockGitHub := NewMockGitHubClient()
+	mockLLM := NewMockLLMClient()
+	gen := report.NewGeneratorWithClients(mockGitHub, mockLLM)
+
+	now := time.Now()
+	opts := report.Options{
+		Repository: "owner/repo",
+		Period: types.Period{
+			From: now.Add(-48 * time.Hour),
+			To:   now,
+		},
+		Language: "english",
+	}
+
+	reportText, err := gen.Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Failed to generate report: %v", err)
+	}
+
+	for _, want := range []string{
+		"## 🔥 Hotspots",
+		"| `internal/auth/oauth.go` | 2 | 155 (+130/-25) | 2 |",
+		"| `internal/auth` | 2 | 155 (+130/-25) | 2 |",
+		"| `web` | 1 | 250 (+200/-50) | 1 |",
+		"| (root) | 1 | 25 (+20/-5) | 1 |",
+		"- `internal/auth/oauth.go`: 2 authors",
+	} {
+		if !strings.Contains(reportText, want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+}
@@ -4,6 +4,9 @@
 package integration
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/hazadus/gh-repomon/internal/types"
@@ -11,19 +14,33 @@ import (
 
 // MockGitHubClient is a mock implementation of GitHub API client for testing
 type MockGitHubClient struct {
-	activeBranches   []types.Branch
-	openPRs          []types.PullRequest
-	updatedPRs       []types.PullRequest
-	openIssues       []types.Issue
-	closedIssues     []types.Issue
-	reviewsByAuthor  map[string]int
-	totalReviewCount int
+	activeBranches []types.Branch
+	openPRs        []types.PullRequest
+	updatedPRs     []types.PullRequest
+	openIssues     []types.Issue
+	closedIssues   []types.Issue
+	reviews        map[int][]types.Review
+	releases       []types.Release
+	deployments    []types.Deployment
+	workflowRuns   []types.WorkflowRun
+	comparisons    map[string]*types.BranchComparison
+	branchHeads    []types.BranchHead
+	conflicting    []int
+	// mergedPRs lists pull requests merged before the report period
+	mergedPRs []types.PullRequest
+
+	mu sync.Mutex
+	// hygieneCalls counts the requests made only for the repository hygiene section
+	hygieneCalls int
+	// branchCountCalls counts the comparisons of branches with the default branch
+	branchCountCalls int
 }
 
 // NewMockGitHubClient creates a new mock GitHub client with predefined test data
 func NewMockGitHubClient() *MockGitHubClient {
 	now := time.Now()
 	yesterday := now.Add(-24 * time.Hour)
+	longAgo := now.AddDate(0, 0, -75)
 
 	return &MockGitHubClient{
 		activeBranches: []types.Branch{
@@ -37,7 +54,11 @@ func NewMockGitHubClient() *MockGitHubClient {
 						Date:      yesterday,
 						Additions: 100,
 						Deletions: 20,
-						URL:       "https://github.com/owner/repo/commit/abc123",
+						Files: []types.FileChange{
+							{Path: "internal/auth/oauth.go", Additions: 80, Deletions: 15},
+							{Path: "README.md", Additions: 20, Deletions: 5},
+						},
+						URL: "https://github.com/owner/repo/commit/abc123",
 					},
 					{
 						SHA:       "def456",
@@ -46,7 +67,10 @@ func NewMockGitHubClient() *MockGitHubClient {
 						Date:      now,
 						Additions: 50,
 						Deletions: 10,
-						URL:       "https://github.com/owner/repo/commit/def456",
+						Files: []types.FileChange{
+							{Path: "internal/auth/oauth.go", Additions: 50, Deletions: 10},
+						},
+						URL: "https://github.com/owner/repo/commit/def456",
 					},
 				},
 				TotalAdded:   150,
@@ -63,12 +87,16 @@ func NewMockGitHubClient() *MockGitHubClient {
 						Date:      yesterday,
 						Additions: 200,
 						Deletions: 50,
-						URL:       "https://github.com/owner/repo/commit/ghi789",
+						Files: []types.FileChange{
+							{Path: "web/ui/app.tsx", Additions: 200, Deletions: 50},
+						},
+						URL: "https://github.com/owner/repo/commit/ghi789",
 					},
 				},
 				TotalAdded:   200,
 				TotalDeleted: 50,
 				Authors:      []string{"developer1"},
+				LastActivity: yesterday,
 			},
 		},
 		openPRs: []types.PullRequest{
@@ -98,6 +126,23 @@ func NewMockGitHubClient() *MockGitHubClient {
 				Reviews:   3,
 				URL:       "https://github.com/owner/repo/pull/2",
 			},
+			{
+				Number:    3,
+				Title:     "Redesign user interface",
+				Body:      "This PR introduces the new UI",
+				Author:    types.Author{Login: "developer1", Name: "Developer One", ProfileURL: "https://github.com/developer1", IsBot: false},
+				State:     types.PRStateMerged,
+				CreatedAt: now.Add(-72 * time.Hour),
+				UpdatedAt: yesterday,
+				ClosedAt:  &yesterday,
+				MergedAt:  &yesterday,
+				MergedBy:  &types.Author{Login: "developer2", ProfileURL: "https://github.com/developer2"},
+				BaseRef:   "main",
+				HeadRef:   "feature/new-ui",
+				HeadRepo:  "owner/repo",
+				Labels:    []string{"ui"},
+				URL:       "https://github.com/owner/repo/pull/3",
+			},
 		},
 		openIssues: []types.Issue{
 			{
@@ -130,45 +175,214 @@ func NewMockGitHubClient() *MockGitHubClient {
 				URL: "https://github.com/owner/repo/issues/9",
 			},
 		},
-		reviewsByAuthor: map[string]int{
-			"developer1": 3,
-			"developer2": 2,
+		reviews: map[int][]types.Review{
+			1: {
+				{Author: types.Author{Login: "developer2", ProfileURL: "https://github.com/developer2"}, State: types.ReviewStateChangesRequested, SubmittedAt: now.Add(-time.Hour)},
+				{Author: types.Author{Login: "developer2", ProfileURL: "https://github.com/developer2"}, State: types.ReviewStateApproved, SubmittedAt: now},
+			},
+			2: {
+				{Author: types.Author{Login: "developer1", ProfileURL: "https://github.com/developer1"}, State: types.ReviewStateCommented, SubmittedAt: now.Add(-time.Hour)},
+				{Author: types.Author{Login: "developer1", ProfileURL: "https://github.com/developer1"}, State: types.ReviewStateApproved, SubmittedAt: now.Add(-time.Hour)},
+				{Author: types.Author{Login: "reviewer3", ProfileURL: "https://github.com/reviewer3"}, State: types.ReviewStateApproved, SubmittedAt: now},
+			},
+		},
+		releases: []types.Release{
+			{
+				TagName:     "v1.1.0",
+				Name:        "New UI",
+				Body:        "- Redesigned user interface",
+				Author:      types.Author{Login: "developer2", ProfileURL: "https://github.com/developer2"},
+				PublishedAt: now.Add(-12 * time.Hour),
+				URL:         "https://github.com/owner/repo/releases/tag/v1.1.0",
+				PreviousTag: "v1.0.0",
+				Commits: []types.Commit{
+					{
+						SHA:     "jkl012",
+						Message: "Redesign user interface (#3)",
+						Author:  types.Author{Login: "developer1", ProfileURL: "https://github.com/developer1"},
+						Date:    yesterday,
+						URL:     "https://github.com/owner/repo/commit/jkl012",
+					},
+				},
+			},
+		},
+		deployments: []types.Deployment{
+			{
+				ID: 2, Environment: "staging", Ref: "main", SHA: "def456",
+				Creator:   types.Author{Login: "developer2", ProfileURL: "https://github.com/developer2"},
+				CreatedAt: now.Add(-time.Hour), State: types.DeploymentStateFailure,
+				URL: "https://github.com/owner/repo/actions/runs/202",
+			},
+			{
+				ID: 1, Environment: "production", Production: true, Ref: "v1.1.0", SHA: "jkl012",
+				Creator:   types.Author{Login: "developer2", ProfileURL: "https://github.com/developer2"},
+				CreatedAt: now.Add(-11 * time.Hour), State: types.DeploymentStateSuccess,
+				PreviousSHA: "ghi789",
+				Commits: []types.Commit{
+					{
+						SHA:     "jkl012",
+						Message: "Redesign user interface (#3)",
+						Author:  types.Author{Login: "developer1", ProfileURL: "https://github.com/developer1"},
+						Date:    yesterday,
+						URL:     "https://github.com/owner/repo/commit/jkl012",
+					},
+				},
+			},
+		},
+		workflowRuns: []types.WorkflowRun{
+			{
+				ID: 102, WorkflowID: 1, Workflow: "CI", Branch: "main", SHA: "def456", Event: "push",
+				Status: "completed", Conclusion: types.RunConclusionFailure, Attempt: 1,
+				StartedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-50 * time.Minute),
+				URL: "https://github.com/owner/repo/actions/runs/102",
+			},
+			{
+				ID: 101, WorkflowID: 1, Workflow: "CI", Branch: "main", SHA: "abc123", Event: "push",
+				Status: "completed", Conclusion: types.RunConclusionSuccess,
+				Attempt: 2, PreviousConclusions: []string{types.RunConclusionFailure},
+				StartedAt: yesterday, UpdatedAt: yesterday.Add(12 * time.Minute),
+				URL: "https://github.com/owner/repo/actions/runs/101",
+				Jobs: []types.WorkflowJob{
+					{Name: "test", Conclusion: types.RunConclusionSuccess, StartedAt: yesterday, CompletedAt: yesterday.Add(12 * time.Minute), URL: "https://github.com/owner/repo/actions/runs/101/job/1"},
+				},
+			},
+			{
+				ID: 100, WorkflowID: 2, Workflow: "Lint", Branch: "feature/new-ui", SHA: "jkl012", Event: "pull_request",
+				Status: "completed", Conclusion: types.RunConclusionSuccess, Attempt: 1,
+				StartedAt: yesterday, UpdatedAt: yesterday.Add(2 * time.Minute),
+				URL: "https://github.com/owner/repo/actions/runs/100",
+			},
+		},
+		comparisons: map[string]*types.BranchComparison{
+			"feature/new-ui": {Ahead: 1, Behind: 2, Commits: []string{"ghi789"}},
+			"old/experiment": {Ahead: 3, Behind: 40},
+			"release/1.0":    {Ahead: 0, Behind: 12},
+			"old/merged":     {Ahead: 0, Behind: 30},
+		},
+		branchHeads: []types.BranchHead{
+			{Name: "main", SHA: "def456", Author: types.Author{Login: "developer2", ProfileURL: "https://github.com/developer2"}, CommittedAt: now},
+			{Name: "feature/new-ui", SHA: "ghi789", Author: types.Author{Login: "developer1", ProfileURL: "https://github.com/developer1"}, CommittedAt: yesterday},
+			{Name: "old/experiment", SHA: "aaa111", Author: types.Author{Login: "developer2", ProfileURL: "https://github.com/developer2"}, CommittedAt: now.AddDate(0, 0, -90)},
+			{Name: "release/1.0", SHA: "bbb222", Author: types.Author{Login: "developer1", ProfileURL: "https://github.com/developer1"}, CommittedAt: now.AddDate(0, 0, -20)},
+			{Name: "old/merged", SHA: "ccc333", Author: types.Author{Login: "developer2", ProfileURL: "https://github.com/developer2"}, CommittedAt: now.AddDate(0, 0, -70)},
+		},
+		conflicting: []int{1},
+		// old/merged was merged long ago and main was merged into it afterwards
+		mergedPRs: []types.PullRequest{
+			{
+				Number:   1,
+				Title:    "Old experiment",
+				State:    types.PRStateMerged,
+				MergedAt: &longAgo,
+				BaseRef:  "main",
+				HeadRef:  "old/merged",
+				HeadRepo: "owner/repo",
+				URL:      "https://github.com/owner/repo/pull/1",
+			},
 		},
-		totalReviewCount: 5,
 	}
 }
 
 // GetActiveBranches returns mock active branches
-func (m *MockGitHubClient) GetActiveBranches(repo string, from, to time.Time) ([]types.Branch, error) {
+func (m *MockGitHubClient) GetActiveBranches(ctx context.Context, repo string, from, to time.Time) ([]types.Branch, error) {
 	return m.activeBranches, nil
 }
 
 // GetOpenPullRequests returns mock open PRs
-func (m *MockGitHubClient) GetOpenPullRequests(repo string) ([]types.PullRequest, error) {
+func (m *MockGitHubClient) GetOpenPullRequests(ctx context.Context, repo string) ([]types.PullRequest, error) {
 	return m.openPRs, nil
 }
 
 // GetUpdatedPullRequests returns mock updated PRs
-func (m *MockGitHubClient) GetUpdatedPullRequests(repo, from, to string) ([]types.PullRequest, error) {
+func (m *MockGitHubClient) GetUpdatedPullRequests(ctx context.Context, repo, from, to string) ([]types.PullRequest, error) {
 	return m.updatedPRs, nil
 }
 
 // GetOpenIssues returns mock open issues
-func (m *MockGitHubClient) GetOpenIssues(repo string) ([]types.Issue, error) {
+func (m *MockGitHubClient) GetOpenIssues(ctx context.Context, repo string) ([]types.Issue, error) {
 	return m.openIssues, nil
 }
 
 // GetClosedIssues returns mock closed issues
-func (m *MockGitHubClient) GetClosedIssues(repo, from, to string) ([]types.Issue, error) {
+func (m *MockGitHubClient) GetClosedIssues(ctx context.Context, repo, from, to string) ([]types.Issue, error) {
 	return m.closedIssues, nil
 }
 
-// GetReviewsByAuthor returns mock reviews by author
-func (m *MockGitHubClient) GetReviewsByAuthor(repo string, prs []types.PullRequest) (map[string]int, error) {
-	return m.reviewsByAuthor, nil
+// GetReleases returns mock releases
+func (m *MockGitHubClient) GetReleases(ctx context.Context, repo string, from, to time.Time) ([]types.Release, error) {
+	return m.releases, nil
+}
+
+// GetDeployments returns mock deployments
+func (m *MockGitHubClient) GetDeployments(ctx context.Context, repo string, from, to time.Time) ([]types.Deployment, error) {
+	return m.deployments, nil
+}
+
+// GetWorkflowRuns returns mock workflow runs
+func (m *MockGitHubClient) GetWorkflowRuns(ctx context.Context, repo string, from, to time.Time) ([]types.WorkflowRun, error) {
+	return m.workflowRuns, nil
+}
+
+// GetDefaultBranch returns the mock default branch
+func (m *MockGitHubClient) GetDefaultBranch(ctx context.Context, repo string) (string, error) {
+	return "main", nil
+}
+
+// GetBranchHeads returns the mock latest commit of every branch
+func (m *MockGitHubClient) GetBranchHeads(ctx context.Context, repo string) ([]types.BranchHead, error) {
+	m.mu.Lock()
+	m.hygieneCalls++
+	m.mu.Unlock()
+	// Callers mark the heads, so each report gets its own copy
+	return append([]types.BranchHead(nil), m.branchHeads...), nil
+}
+
+// GetMergedPullRequests returns the mock merged pull requests of a branch, whenever they were merged
+func (m *MockGitHubClient) GetMergedPullRequests(ctx context.Context, repo, branch string) ([]types.PullRequest, error) {
+	m.mu.Lock()
+	m.hygieneCalls++
+	m.mu.Unlock()
+
+	var prs []types.PullRequest
+	for _, pr := range append(append([]types.PullRequest(nil), m.updatedPRs...), m.mergedPRs...) {
+		if pr.HeadRef == branch && pr.MergedAt != nil {
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
+
+// CountBranchCommits returns the mock ahead and behind counts of a branch
+func (m *MockGitHubClient) CountBranchCommits(ctx context.Context, repo, base, head string) (*types.BranchComparison, error) {
+	m.mu.Lock()
+	m.branchCountCalls++
+	m.mu.Unlock()
+
+	comparison, err := m.CompareBranches(ctx, repo, base, head)
+	if err != nil {
+		return nil, err
+	}
+	return &types.BranchComparison{Ahead: comparison.Ahead, Behind: comparison.Behind}, nil
+}
+
+// GetConflictingPullRequests returns the mock pull requests with merge conflicts
+func (m *MockGitHubClient) GetConflictingPullRequests(ctx context.Context, repo string, numbers []int) ([]int, error) {
+	m.mu.Lock()
+	m.hygieneCalls++
+	m.mu.Unlock()
+	return m.conflicting, nil
+}
+
+// CompareBranches returns the mock comparison of a branch with the default branch
+func (m *MockGitHubClient) CompareBranches(ctx context.Context, repo, base, head string) (*types.BranchComparison, error) {
+	comparison, ok := m.comparisons[head]
+	if !ok {
+		return nil, fmt.Errorf("no comparison for %s...%s", base, head)
+	}
+	return comparison, nil
 }
 
-// GetAllReviews returns mock total review count
-func (m *MockGitHubClient) GetAllReviews(repo string, prs []types.PullRequest) (int, error) {
-	return m.totalReviewCount, nil
+// GetPullRequestReviews returns mock reviews for a pull request
+func (m *MockGitHubClient) GetPullRequestReviews(ctx context.Context, repo string, prNumber int) ([]types.Review, error) {
+	return m.reviews[prNumber], nil
 }
@@ -4,6 +4,7 @@
 package integration
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -24,7 +25,7 @@ func NewMockLLMClient() *MockLLMClient {
 }
 
 // GenerateOverallSummary generates a mock overall summary
-func (m *MockLLMClient) GenerateOverallSummary(data *types.ReportData, language, model string) (string, error) {
+func (m *MockLLMClient) GenerateOverallSummary(ctx context.Context, data *types.ReportData, language, model string) (string, error) {
 	m.mu.Lock()
 	m.summaryCounter++
 	m.mu.Unlock()
@@ -36,7 +37,7 @@ func (m *MockLLMClient) GenerateOverallSummary(data *types.ReportData, language,
 }
 
 // GenerateBranchSummary generates a mock branch summary
-func (m *MockLLMClient) GenerateBranchSummary(branch *types.Branch, language, model string) (string, error) {
+func (m *MockLLMClient) GenerateBranchSummary(ctx context.Context, branch *types.Branch, language, model string) (string, error) {
 	m.mu.Lock()
 	m.summaryCounter++
 	m.mu.Unlock()
@@ -52,7 +53,7 @@ func (m *MockLLMClient) GenerateBranchSummary(branch *types.Branch, language, mo
 }
 
 // GeneratePRSummary generates a mock PR summary
-func (m *MockLLMClient) GeneratePRSummary(pr *types.PullRequest, language, model string) (string, error) {
+func (m *MockLLMClient) GeneratePRSummary(ctx context.Context, pr *types.PullRequest, language, model string) (string, error) {
 	m.mu.Lock()
 	m.summaryCounter++
 	m.mu.Unlock()
@@ -62,6 +63,15 @@ func (m *MockLLMClient) GeneratePRSummary(pr *types.PullRequest, language, model
 		pr.Title, pr.Reviews, pr.Comments), nil
 }
 
+// GenerateReleaseSummary generates a mock release summary
+func (m *MockLLMClient) GenerateReleaseSummary(ctx context.Context, release *types.Release, language, model string) (string, error) {
+	m.mu.Lock()
+	m.summaryCounter++
+	m.mu.Unlock()
+
+	return fmt.Sprintf("Release %s ships %d pull requests.", release.TagName, len(release.PullRequests)), nil
+}
+
 // GetSummaryCount returns the number of summaries generated (for testing)
 func (m *MockLLMClient) GetSummaryCount() int {
 	m.mu.Lock()